@@ -16,11 +16,14 @@
 package gosec
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/build"
 	"go/token"
 	"go/types"
+	"io/ioutil"
 	"log"
 	"os"
 	"path"
@@ -51,17 +54,28 @@ const LoadMode = packages.NeedName |
 // this data in conjunction withe the encountered AST node.
 type Context struct {
 	FileSet      *token.FileSet
+	Filename     string
+	IsTestFile   bool // the checked file is a _test.go file
+	TestFiles    bool // the rules allowing the test files by design check them as well
 	Comments     ast.CommentMap
 	Info         *types.Info
 	Pkg          *types.Package
 	PkgFiles     []*ast.File
+	PkgImports   map[string]*packages.Package // the imported packages, with their own imports
 	Root         *ast.File
 	Config       Config
 	Imports      *ImportTracker
 	Ignores      []map[string]bool
+	Enables      []map[string]bool
 	PassedValues map[string]interface{}
 }
 
+// SkipTestFile returns true if the checked file is a test file which the rules
+// allowing the test files by design should leave out
+func (ctx *Context) SkipTestFile() bool {
+	return ctx.IsTestFile && !ctx.TestFiles
+}
+
 // Metrics used when reporting information about a scanning run.
 type Metrics struct {
 	NumFiles int `json:"files"`
@@ -70,6 +84,33 @@ type Metrics struct {
 	NumFound int `json:"found"`
 }
 
+// Summary extends the metrics with the number of issues found per rule, per
+// severity and per confidence
+type Summary struct {
+	Metrics
+	ByRule       map[string]int `json:"by_rule"`
+	BySeverity   map[string]int `json:"by_severity"`
+	ByConfidence map[string]int `json:"by_confidence"`
+}
+
+// NewSummary buckets the issues by rule, severity and confidence
+func NewSummary(issues []*Issue, metrics *Metrics) *Summary {
+	summary := &Summary{
+		ByRule:       make(map[string]int),
+		BySeverity:   make(map[string]int),
+		ByConfidence: make(map[string]int),
+	}
+	if metrics != nil {
+		summary.Metrics = *metrics
+	}
+	for _, issue := range issues {
+		summary.ByRule[issue.RuleID]++
+		summary.BySeverity[issue.Severity.String()]++
+		summary.ByConfidence[issue.Confidence.String()]++
+	}
+	return summary
+}
+
 // Analyzer object is the main object of gosec. It has methods traverse an AST
 // and invoke the correct checking rules as on each node as required.
 type Analyzer struct {
@@ -82,6 +123,17 @@ type Analyzer struct {
 	stats       *Metrics
 	errors      map[string][]Error // keys are file paths; values are the golang errors in those files
 	tests       bool
+	testFiles   bool // the rules allowing the test files by design check them as well
+	generated   bool // the generated files are analyzed as well
+	jobs        int
+	builders    map[string]RuleBuilder // the loaded rules, instantiated again by each worker
+	disabled    map[string]bool        // the loaded rules which only run where a #gosec:enable directive enables them
+	severities  map[string]Score       // the severities of the issues of the loaded rules set by the configuration
+	onIssue     func(*Issue)           // called with each issue as soon as it is found
+	onFile      func(string, int)      // called with each file and the number of its issues once it is checked
+	cache       *Cache                 // the findings of the previous scans, if enabled
+	dirConfigs  *DirConfigs            // the configuration files of the scanned directories, if enabled
+	vendor      Config                 // the configuration of the vendored packages, nil when they use the one of the scan
 }
 
 // NewAnalyzer builds a new analyzer.
@@ -103,7 +155,69 @@ func NewAnalyzer(conf Config, tests bool, logger *log.Logger) *Analyzer {
 		stats:       &Metrics{},
 		errors:      make(map[string][]Error),
 		tests:       tests,
+		jobs:        1,
+		builders:    make(map[string]RuleBuilder),
+		disabled:    make(map[string]bool),
+		severities:  make(map[string]Score),
+	}
+}
+
+// SetJobs sets the number of files analyzed in parallel, the files are
+// analyzed one after the other by default
+func (gosec *Analyzer) SetJobs(jobs int) {
+	if jobs < 1 {
+		jobs = 1
 	}
+	gosec.jobs = jobs
+}
+
+// SetCheckTestFiles makes the rules which allow the test files by design check
+// them as well, the test files are only scanned when the analyzer is built with
+// the tests
+func (gosec *Analyzer) SetCheckTestFiles(check bool) {
+	gosec.testFiles = check
+}
+
+// SetIncludeGenerated makes the analyzer check the generated files as well,
+// which are skipped by default as their code is out of the direct control of
+// the project
+func (gosec *Analyzer) SetIncludeGenerated(include bool) {
+	gosec.generated = include
+}
+
+// SetIssueHandler registers a function called with each issue as soon as it is
+// found, for streaming the issues out while the scan is running. The calls are
+// serialized when the files are analyzed in parallel.
+func (gosec *Analyzer) SetIssueHandler(handler func(*Issue)) {
+	gosec.onIssue = handler
+}
+
+// SetFileHandler registers a function called with each file and the number of
+// issues found in it once it is checked, for reporting the progress of the
+// scan. The calls are serialized when the files are analyzed in parallel.
+func (gosec *Analyzer) SetFileHandler(handler func(file string, numIssues int)) {
+	gosec.onFile = handler
+}
+
+// SetCache enables the cache of the findings, the files which did not change
+// since a previous scan with the same rules and configuration are not analyzed
+// again
+func (gosec *Analyzer) SetCache(cache *Cache) {
+	gosec.cache = cache
+}
+
+// SetDirConfigs enables the discovery of the configuration files of the
+// directories of the packages, the packages to which one applies are analyzed
+// with the rules instantiated again with their own configuration
+func (gosec *Analyzer) SetDirConfigs(configs *DirConfigs) {
+	gosec.dirConfigs = configs
+}
+
+// SetVendorConfig sets the configuration of the packages under a vendor
+// directory, which are analyzed with the rules instantiated again with it, e.g.
+// to only audit the dependencies with the critical rules
+func (gosec *Analyzer) SetVendorConfig(conf Config) {
+	gosec.vendor = conf
 }
 
 // SetConfig upates the analyzer configuration
@@ -117,7 +231,8 @@ func (gosec *Analyzer) Config() Config {
 }
 
 // LoadRules instantiates all the rules to be used when analyzing source
-// packages
+// packages. The rules disabled by the configuration are loaded as well, but
+// only run on the nodes annotated with a #gosec:enable directive.
 func (gosec *Analyzer) LoadRules(ruleDefinitions map[string]RuleBuilder) {
 	ids := make([]string, 0, len(ruleDefinitions))
 	for id := range ruleDefinitions {
@@ -125,11 +240,125 @@ func (gosec *Analyzer) LoadRules(ruleDefinitions map[string]RuleBuilder) {
 	}
 	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
 
+	disabled := make(map[string]bool)
+	for _, id := range gosec.config.GetDisabledRules() {
+		disabled[id] = true
+	}
+
 	for _, id := range ids {
+		if disabled[id] {
+			gosec.logger.Printf("Rule %s is disabled by the configuration", id)
+			gosec.disabled[id] = true
+		}
 		def := ruleDefinitions[id]
 		r, nodes := def(id, gosec.config)
 		gosec.ruleset.Register(r, nodes...)
+		gosec.builders[id] = def
+		gosec.loadSeverity(id)
+	}
+}
+
+// loadSeverity records the severity of the issues of the rule set by the
+// configuration, if any
+func (gosec *Analyzer) loadSeverity(id string) {
+	severity, ok, err := gosec.config.GetRuleSeverity(id)
+	if err != nil {
+		gosec.logger.Printf("Keeping the severity of the rule %s: %v", id, err)
 	}
+	if ok {
+		gosec.severities[id] = severity
+	}
+}
+
+// worker returns an analyzer sharing the configuration of this one, with its
+// own instances of the loaded rules as the rules are not safe for concurrent use
+func (gosec *Analyzer) worker() *Analyzer {
+	worker := gosec.withRules(gosec.config)
+	worker.ignoreNosec = gosec.ignoreNosec
+	worker.disabled = gosec.disabled
+	return worker
+}
+
+// withRules returns an analyzer with its own instances of the loaded rules,
+// built with conf
+func (gosec *Analyzer) withRules(conf Config) *Analyzer {
+	analyzer := NewAnalyzer(conf, gosec.tests, gosec.logger)
+	analyzer.testFiles = gosec.testFiles
+	analyzer.generated = gosec.generated
+	analyzer.onIssue = gosec.onIssue
+	analyzer.onFile = gosec.onFile
+	analyzer.cache = gosec.cache
+	ids := make([]string, 0, len(gosec.builders))
+	for id := range gosec.builders {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		analyzer.builders[id] = gosec.builders[id]
+		r, nodes := gosec.builders[id](id, conf)
+		analyzer.ruleset.Register(r, nodes...)
+		analyzer.loadSeverity(id)
+	}
+	return analyzer
+}
+
+// packageAnalyzer returns an analyzer of the package with its own
+// configuration when the package is vendored or a configuration file of its
+// directory applies to it, nil otherwise
+func (gosec *Analyzer) packageAnalyzer(pkg *packages.Package) *Analyzer {
+	if (gosec.dirConfigs == nil && gosec.vendor == nil) || len(pkg.Syntax) == 0 {
+		return nil
+	}
+	dir := filepath.Dir(pkg.Fset.File(pkg.Syntax[0].Pos()).Name())
+	base := gosec.config
+	var conf Config
+	if gosec.vendor != nil && underVendorDir(dir) {
+		base, conf = gosec.vendor, gosec.vendor
+	}
+	if gosec.dirConfigs != nil {
+		resolved, err := gosec.dirConfigs.Resolve(base, dir)
+		if err != nil {
+			gosec.logger.Printf("Checking the package %s without the configuration files of its directory: %v", pkg.Name, err)
+			gosec.AppendError(dir, err)
+		} else if resolved != nil {
+			conf = resolved
+		}
+	}
+	if conf == nil {
+		return nil
+	}
+
+	analyzer := gosec.withRules(conf)
+	analyzer.jobs = gosec.jobs
+	for _, id := range conf.GetDisabledRules() {
+		if _, ok := gosec.builders[id]; ok {
+			analyzer.disabled[id] = true
+		}
+	}
+	return analyzer
+}
+
+// CheckRequiredRules verifies that all the rules listed as required in the
+// configuration have been loaded, and reports the ones which are missing
+func (gosec *Analyzer) CheckRequiredRules() error {
+	loaded := make(map[string]bool)
+	for _, rules := range gosec.ruleset {
+		for _, rule := range rules {
+			if !gosec.disabled[rule.ID()] {
+				loaded[rule.ID()] = true
+			}
+		}
+	}
+	var missing []string
+	for _, id := range gosec.config.GetRequiredRules() {
+		if !loaded[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required rules are not enabled: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 // Process kicks off the analysis process for a given package
@@ -159,11 +388,28 @@ func (gosec *Analyzer) Process(buildTags []string, packagePaths ...string) error
 	return nil
 }
 
+// AnalyzeFile runs the rules of the rule set on a file which is already parsed
+// and type checked, e.g. by a tool loading the packages itself, and returns the
+// issues found, sorted by line. The #nosec directives of the file are honored,
+// and the rules looking at the other files of the package only see this one.
+func AnalyzeFile(fset *token.FileSet, file *ast.File, info *types.Info, pkg *types.Package, ruleset RuleSet) []*Issue {
+	gosec := NewAnalyzer(nil, true, log.New(ioutil.Discard, "", 0))
+	gosec.ruleset = ruleset
+	gosec.checkFile(&packages.Package{
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		TypesInfo: info,
+		Types:     pkg,
+	}, file, "")
+	sortIssues(gosec.issues)
+	return gosec.issues
+}
+
 const sep = os.PathSeparator
 
 var reTestsPath = regexp.MustCompile(fmt.Sprintf("(^\\s*tests%c?)|%c\\s*tests\\s*%c|%c\\s*tests\\s*$", sep, sep, sep, sep))
 
-func allowedFiles(fullPaths ...string) (filtered []string) {
+func allowedFiles(includeGenerated bool, fullPaths ...string) (filtered []string) {
 	for _, fullPath := range fullPaths {
 		// Skip over "/tests/" files as they are generating lots of noise.
 		// Please see https://github.com/cosmos/gosec/issues/60
@@ -171,10 +417,33 @@ func allowedFiles(fullPaths ...string) (filtered []string) {
 			filtered = append(filtered, fullPath)
 		}
 	}
+	if includeGenerated {
+		return filtered
+	}
 	return filterOutGeneratedGoFiles(filtered)
 }
 
-var reGeneratedGoFile = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.`)
+// reGeneratedGoFile matches the line marking a generated Go file, as defined by
+// https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+var reGeneratedGoFile = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// isGeneratedGoFile returns true if the marker of the generated files is one
+// of the lines before the package clause, e.g. after a license header or the
+// build constraints.
+func isGeneratedGoFile(blob []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(blob))
+	scanner.Buffer(nil, len(blob)+1)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+		if reGeneratedGoFile.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
 
 // filterOutGeneratedGoFiles parallelizes the proocess of checking the contents
 // of the files in fullPaths for the presence of generated Go headers to avoid
@@ -189,7 +458,7 @@ func filterOutGeneratedGoFiles(fullPaths []string) (filtered []string) {
 		if err != nil {
 			panic(err)
 		}
-		if !reGeneratedGoFile.Match(blob) {
+		if !isGeneratedGoFile(blob) {
 			filtered = append(filtered, fullPaths[0])
 		}
 		return
@@ -224,7 +493,7 @@ func filterOutGeneratedGoFiles(fullPaths []string) (filtered []string) {
 				if err != nil {
 					panic(err)
 				}
-				if !reGeneratedGoFile.Match(blob) {
+				if !isGeneratedGoFile(blob) {
 					filteredCh <- pi
 				}
 			}
@@ -255,8 +524,13 @@ func filterOutGeneratedGoFiles(fullPaths []string) (filtered []string) {
 func (gosec *Analyzer) load(pkgPath string, conf *packages.Config) ([]*packages.Package, error) {
 	abspath, err := GetPkgAbsPath(pkgPath)
 	if err != nil {
-		gosec.logger.Printf("Skipping: %s. Path doesn't exist.", abspath)
-		return []*packages.Package{}, nil
+		// Not a directory, resolve the package by its import path instead.
+		dirs, importErr := ImportPathDirs(pkgPath, conf.BuildFlags)
+		if importErr != nil || len(dirs) != 1 {
+			gosec.logger.Printf("Skipping: %s. Path doesn't exist.", pkgPath)
+			return []*packages.Package{}, nil
+		}
+		abspath = dirs[0]
 	}
 
 	gosec.logger.Println("Import directory:", abspath)
@@ -316,6 +590,16 @@ func (gosec *Analyzer) load(pkgPath string, conf *packages.Config) ([]*packages.
 	return pkgs, nil
 }
 
+// underVendorDir returns true if the path is in a vendor directory
+func underVendorDir(path string) bool {
+	for _, split := range strings.Split(path, string(filepath.Separator)) {
+		if split == "vendor" {
+			return true
+		}
+	}
+	return false
+}
+
 func underTestUtilDirOrPath(path string) bool {
 	splits := strings.Split(path, string(filepath.Separator))
 	for _, split := range splits {
@@ -328,8 +612,24 @@ func underTestUtilDirOrPath(path string) bool {
 
 // Check runs analysis on the given package
 func (gosec *Analyzer) Check(pkg *packages.Package) {
+	if analyzer := gosec.packageAnalyzer(pkg); analyzer != nil {
+		analyzer.Check(pkg)
+		gosec.issues = append(gosec.issues, analyzer.issues...)
+		for file, errors := range analyzer.errors {
+			gosec.errors[file] = append(gosec.errors[file], errors...)
+		}
+		gosec.stats.NumFiles += analyzer.stats.NumFiles
+		gosec.stats.NumLines += analyzer.stats.NumLines
+		gosec.stats.NumNosec += analyzer.stats.NumNosec
+		gosec.stats.NumFound += analyzer.stats.NumFound
+		sortIssues(gosec.issues)
+		return
+	}
+
 	gosec.logger.Println("Checking package:", pkg.Name)
 
+	var files []*ast.File
+	var pkgFiles []string
 	for _, file := range pkg.Syntax {
 		checkedFile := pkg.Fset.File(file.Pos()).Name()
 		// Skip the no-Go file from analysis (e.g. a Cgo files is expanded in 3 different files
@@ -337,34 +637,174 @@ func (gosec *Analyzer) Check(pkg *packages.Package) {
 		if filepath.Ext(checkedFile) != ".go" {
 			continue
 		}
+		pkgFiles = append(pkgFiles, checkedFile)
 
 		// Skip over analyzing files in */testutil/* as they are causing spurious failures yet don't return
 		// much value in vulnerability reports. Please see https://github.com/cosmos/gosec/issues/52
 		if underTestUtilDirOrPath(checkedFile) {
 			continue
 		}
+		files = append(files, file)
+	}
+
+	var pkgKey string
+	if gosec.cache != nil {
+		key, err := gosec.cache.packageKey(gosec, pkgFiles)
+		if err != nil {
+			gosec.logger.Printf("Not caching the package %s: %v", pkg.Name, err)
+		}
+		pkgKey = key
+	}
+
+	if gosec.jobs <= 1 || len(files) <= 1 {
+		for _, file := range files {
+			gosec.checkFile(pkg, file, pkgKey)
+		}
+	} else {
+		gosec.checkFiles(pkg, files, pkgKey)
+	}
+	sortIssues(gosec.issues)
+}
 
-		gosec.logger.Println("Checking file:", checkedFile)
-		gosec.context.FileSet = pkg.Fset
-		gosec.context.Config = gosec.config
-		gosec.context.Comments = ast.NewCommentMap(gosec.context.FileSet, file, file.Comments)
-		gosec.context.Root = file
-		gosec.context.Info = pkg.TypesInfo
-		gosec.context.Pkg = pkg.Types
-		gosec.context.PkgFiles = pkg.Syntax
-		gosec.context.Imports = NewImportTracker()
-		gosec.context.Imports.TrackFile(file)
-		gosec.context.PassedValues = make(map[string]interface{})
+// checkFiles spreads the files over a pool of workers and collects their
+// issues and metrics once they are done.
+func (gosec *Analyzer) checkFiles(pkg *packages.Package, files []*ast.File, pkgKey string) {
+	jobs := gosec.jobs
+	if jobs > len(files) {
+		jobs = len(files)
+	}
 
-		// Only walk non-generated Go files as we definitely don't
-		// want to report on generated code, which is out of our direct control.
-		// Please see: https://github.com/cosmos/gosec/issues/30
-		if filtered := allowedFiles(checkedFile); len(filtered) > 0 {
-			ast.Walk(gosec, file)
+	fileCh := make(chan *ast.File)
+	go func() {
+		defer close(fileCh)
+		for _, file := range files {
+			fileCh <- file
 		}
-		gosec.stats.NumFiles++
-		gosec.stats.NumLines += pkg.Fset.File(file.Pos()).LineCount()
+	}()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker := gosec.worker()
+			if gosec.onIssue != nil {
+				worker.onIssue = func(issue *Issue) {
+					mu.Lock()
+					defer mu.Unlock()
+					gosec.onIssue(issue)
+				}
+			}
+			if gosec.onFile != nil {
+				worker.onFile = func(file string, numIssues int) {
+					mu.Lock()
+					defer mu.Unlock()
+					gosec.onFile(file, numIssues)
+				}
+			}
+			for file := range fileCh {
+				worker.checkFile(pkg, file, pkgKey)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			gosec.issues = append(gosec.issues, worker.issues...)
+			gosec.stats.NumFiles += worker.stats.NumFiles
+			gosec.stats.NumLines += worker.stats.NumLines
+			gosec.stats.NumNosec += worker.stats.NumNosec
+			gosec.stats.NumFound += worker.stats.NumFound
+		}()
 	}
+	wg.Wait()
+}
+
+// checkFile analyzes a file, or reports the findings cached for it when the
+// package key of its previous scan is known
+func (gosec *Analyzer) checkFile(pkg *packages.Package, file *ast.File, pkgKey string) {
+	checkedFile := pkg.Fset.File(file.Pos()).Name()
+	var key string
+	if pkgKey != "" {
+		key = fileKey(pkgKey, checkedFile)
+		if entry, ok := gosec.cache.get(key); ok {
+			gosec.logger.Println("Cached file:", checkedFile)
+			for _, issue := range entry.Issues {
+				gosec.report(issue)
+			}
+			gosec.stats.NumFiles++
+			gosec.stats.NumLines += entry.NumLines
+			gosec.stats.NumNosec += entry.NumNosec
+			if gosec.onFile != nil {
+				gosec.onFile(checkedFile, len(entry.Issues))
+			}
+			return
+		}
+	}
+
+	gosec.logger.Println("Checking file:", checkedFile)
+	gosec.context.FileSet = pkg.Fset
+	gosec.context.Filename = checkedFile
+	gosec.context.IsTestFile = strings.HasSuffix(checkedFile, "_test.go")
+	gosec.context.TestFiles = gosec.testFiles
+	gosec.context.Config = gosec.config
+	gosec.context.Comments = ast.NewCommentMap(gosec.context.FileSet, file, file.Comments)
+	gosec.context.Root = file
+	gosec.context.Info = pkg.TypesInfo
+	gosec.context.Pkg = pkg.Types
+	gosec.context.PkgFiles = pkg.Syntax
+	gosec.context.PkgImports = pkg.Imports
+	gosec.context.Imports = NewImportTracker()
+	gosec.context.Imports.TrackFile(file)
+	gosec.context.PassedValues = make(map[string]interface{})
+
+	// Only walk non-generated Go files as we definitely don't
+	// want to report on generated code, which is out of our direct control.
+	// Please see: https://github.com/cosmos/gosec/issues/30
+	numIssues, numNosec := len(gosec.issues), gosec.stats.NumNosec
+	if filtered := allowedFiles(gosec.generated, checkedFile); len(filtered) > 0 {
+		ast.Walk(gosec, file)
+	}
+	numLines := pkg.Fset.File(file.Pos()).LineCount()
+	gosec.stats.NumFiles++
+	gosec.stats.NumLines += numLines
+
+	if key != "" {
+		entry := &cacheEntry{
+			Issues:   gosec.issues[numIssues:],
+			NumLines: numLines,
+			NumNosec: gosec.stats.NumNosec - numNosec,
+		}
+		if err := gosec.cache.put(key, entry); err != nil {
+			gosec.logger.Printf("Failed to cache the issues of %s: %v", checkedFile, err)
+		}
+	}
+	if gosec.onFile != nil {
+		gosec.onFile(checkedFile, len(gosec.issues)-numIssues)
+	}
+}
+
+// issueLine returns the first line of the issue, which spans a range of lines
+// such as "12-14" for multi-line nodes
+func issueLine(line string) int {
+	n, _ := strconv.Atoi(strings.Split(line, "-")[0])
+	return n
+}
+
+// sortIssues sorts the issues by file, line and rule so that the report does
+// not depend on the order in which the files were analyzed
+func sortIssues(issues []*Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if la, lb := issueLine(a.Line), issueLine(b.Line); la != lb {
+			return la < lb
+		}
+		if ca, cb := issueLine(a.Col), issueLine(b.Col); ca != cb {
+			return ca < cb
+		}
+		return a.RuleID < b.RuleID
+	})
 }
 
 // ParseErrors parses the errors from given package
@@ -416,12 +856,15 @@ func (gosec *Analyzer) AppendError(file string, err error) {
 	gosec.errors[file] = errors
 }
 
+// NosecJustificationID is the ID of the issues reported in strict nosec mode for
+// the #nosec directives lacking a justification
+const NosecJustificationID = "NOSEC"
+
 // ignore a node (and sub-tree) if it is tagged with a nosec tag comment
 func (gosec *Analyzer) ignore(n ast.Node) ([]string, bool) {
 	if groups, ok := gosec.context.Comments[n]; ok && !gosec.ignoreNosec {
 
 		// Checks if an alternative for #nosec is set and, if not, uses the default.
-		noSecDefaultTag := "#nosec"
 		noSecAlternativeTag, err := gosec.config.GetGlobal(NoSecAlternative)
 		if err != nil {
 			noSecAlternativeTag = noSecDefaultTag
@@ -429,26 +872,22 @@ func (gosec *Analyzer) ignore(n ast.Node) ([]string, bool) {
 
 		for _, group := range groups {
 
-			foundDefaultTag := strings.Contains(group.Text(), noSecDefaultTag)
-			foundAlternativeTag := strings.Contains(group.Text(), noSecAlternativeTag)
+			ignores, justification, found := parseNosec(group.Text(), noSecDefaultTag)
+			if !found {
+				ignores, justification, found = parseNosec(group.Text(), noSecAlternativeTag)
+			}
 
-			if foundDefaultTag || foundAlternativeTag {
+			if found {
 				gosec.stats.NumNosec++
 
-				// Pull out the specific rules that are listed to be ignored.
-				re := regexp.MustCompile(`(G\d{3})`)
-				matches := re.FindAllStringSubmatch(group.Text(), -1)
+				if strict, err := gosec.config.IsGlobalEnabled(StrictNosec); err == nil && strict && justification == "" {
+					gosec.report(NewIssue(gosec.context, group, NosecJustificationID, "#nosec directive without a justification, explain why the issue is suppressed", Low, High))
+				}
 
 				// If no specific rules were given, ignore everything.
-				if len(matches) == 0 {
+				if len(ignores) == 0 {
 					return nil, true
 				}
-
-				// Find the rule IDs to ignore.
-				var ignores []string
-				for _, v := range matches {
-					ignores = append(ignores, v[1])
-				}
 				return ignores, false
 			}
 		}
@@ -456,6 +895,34 @@ func (gosec *Analyzer) ignore(n ast.Node) ([]string, bool) {
 	return nil, false
 }
 
+// EnableDirective re-enables the rules listed after it, which are disabled by the
+// configuration, on the annotated node, e.g. "#gosec:enable G705". A #nosec
+// directive on the same node or on an enclosing one takes precedence.
+const EnableDirective = "#gosec:enable"
+
+var (
+	reEnabledRules = regexp.MustCompile(`^(\s*[,:]?\s*G\d{3})+`)
+	reRuleID       = regexp.MustCompile(`G\d{3}`)
+)
+
+// enable returns the rules re-enabled by the #gosec:enable directives of the node
+func (gosec *Analyzer) enable(n ast.Node) []string {
+	groups, ok := gosec.context.Comments[n]
+	if !ok || len(gosec.disabled) == 0 {
+		return nil
+	}
+	var enables []string
+	for _, group := range groups {
+		text := group.Text()
+		for i := strings.Index(text, EnableDirective); i >= 0; i = strings.Index(text, EnableDirective) {
+			text = text[i+len(EnableDirective):]
+			list := reEnabledRules.FindString(text)
+			enables = append(enables, reRuleID.FindAllString(list, -1)...)
+		}
+	}
+	return enables
+}
+
 // Visit runs the gosec visitor logic over an AST created by parsing go code.
 // Rule methods added with AddRule will be invoked as necessary.
 func (gosec *Analyzer) Visit(n ast.Node) ast.Visitor {
@@ -464,6 +931,9 @@ func (gosec *Analyzer) Visit(n ast.Node) ast.Visitor {
 		if len(gosec.context.Ignores) > 0 {
 			gosec.context.Ignores = gosec.context.Ignores[1:]
 		}
+		if len(gosec.context.Enables) > 0 {
+			gosec.context.Enables = gosec.context.Enables[1:]
+		}
 		return gosec
 	}
 
@@ -488,6 +958,18 @@ func (gosec *Analyzer) Visit(n ast.Node) ast.Visitor {
 	// Push the new set onto the stack.
 	gosec.context.Ignores = append([]map[string]bool{ignores}, gosec.context.Ignores...)
 
+	// Same for the disabled rules enabled again on this branch.
+	enables := map[string]bool{}
+	if len(gosec.context.Enables) > 0 {
+		for k, v := range gosec.context.Enables[0] {
+			enables[k] = v
+		}
+	}
+	for _, v := range gosec.enable(n) {
+		enables[v] = true
+	}
+	gosec.context.Enables = append([]map[string]bool{enables}, gosec.context.Enables...)
+
 	// Track aliased and initialization imports
 	gosec.context.Imports.TrackImport(n)
 
@@ -495,6 +977,9 @@ func (gosec *Analyzer) Visit(n ast.Node) ast.Visitor {
 		if _, ok := ignores[rule.ID()]; ok {
 			continue
 		}
+		if gosec.disabled[rule.ID()] && !enables[rule.ID()] {
+			continue
+		}
 		issue, err := rule.Match(n, gosec.context)
 		if err != nil {
 			file, line := GetLocation(n, gosec.context)
@@ -502,22 +987,47 @@ func (gosec *Analyzer) Visit(n ast.Node) ast.Visitor {
 			gosec.logger.Printf("Rule error: %T => %s (%s:%d)\n", rule, err, file, line)
 		}
 		if issue != nil {
-			gosec.issues = append(gosec.issues, issue)
-			gosec.stats.NumFound++
+			if meta, ok := RuleMetaData(rule); ok && issue.HelpURL == "" {
+				issue.HelpURL = meta.HelpURL
+			}
+			gosec.report(issue)
 		}
 	}
 	return gosec
 }
 
+// report records an issue, with the severity set by the configuration for its
+// rule if any, and hands it over to the issue handler, if any
+func (gosec *Analyzer) report(issue *Issue) {
+	if severity, ok := gosec.severities[issue.RuleID]; ok {
+		issue.Severity = severity
+	}
+	gosec.issues = append(gosec.issues, issue)
+	gosec.stats.NumFound++
+	if gosec.onIssue != nil {
+		gosec.onIssue(issue)
+	}
+}
+
 // Report returns the current issues discovered and the metrics about the scan
 func (gosec *Analyzer) Report() ([]*Issue, *Metrics, map[string][]Error) {
 	return gosec.issues, gosec.stats, gosec.errors
 }
 
-// Reset clears state such as context, issues and metrics from the configured analyzer
+// Summary returns the metrics of the analysis along with the issue counts per
+// rule, severity and confidence
+func (gosec *Analyzer) Summary() *Summary {
+	return NewSummary(gosec.issues, gosec.stats)
+}
+
+// Reset clears state such as context, issues, errors and metrics from the configured analyzer
 func (gosec *Analyzer) Reset() {
 	gosec.context = &Context{}
 	gosec.issues = make([]*Issue, 0, 16)
 	gosec.stats = &Metrics{}
+	gosec.errors = make(map[string][]Error)
 	gosec.ruleset = NewRuleSet()
+	gosec.builders = make(map[string]RuleBuilder)
+	gosec.disabled = make(map[string]bool)
+	gosec.severities = make(map[string]Score)
 }
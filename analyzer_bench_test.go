@@ -0,0 +1,67 @@
+package gosec_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/rules"
+	"github.com/cosmos/gosec/v2/testutils"
+	"golang.org/x/tools/go/packages"
+)
+
+func benchmarkCheck(b *testing.B, jobs int) {
+	pkg := testutils.NewTestPackage()
+	defer pkg.Close()
+	pkg.AddFile("main.go", `
+		package main
+		func main(){
+		}`)
+	for i := 0; i < 64; i++ {
+		pkg.AddFile(fmt.Sprintf("hash_%d.go", i), fmt.Sprintf(`
+		package main
+		import (
+			"crypto/md5"
+			"math/rand"
+		)
+		func hash%d(entries map[string][]byte) []byte {
+			h := md5.New()
+			for key, value := range entries {
+				h.Write([]byte(key))
+				h.Write(value)
+			}
+			h.Write([]byte{byte(rand.Int())})
+			return h.Sum(nil)
+		}`, i))
+	}
+	if err := pkg.Build(); err != nil {
+		b.Fatal(err)
+	}
+
+	// Load the package once so that only the analysis of the files is measured.
+	pkgs, err := packages.Load(&packages.Config{Mode: gosec.LoadMode, Dir: pkg.Path}, ".")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	logger := log.New(ioutil.Discard, "", 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analyzer := gosec.NewAnalyzer(nil, false, logger)
+		analyzer.SetJobs(jobs)
+		analyzer.LoadRules(rules.Generate().Builders())
+		for _, p := range pkgs {
+			analyzer.Check(p)
+		}
+	}
+}
+
+func BenchmarkCheckSerial(b *testing.B) {
+	benchmarkCheck(b, 1)
+}
+
+func BenchmarkCheckParallel(b *testing.B) {
+	benchmarkCheck(b, 4)
+}
@@ -34,6 +34,7 @@ func TestUnitFilterOutGeneratedGoFiles(t *testing.T) {
 		"testdata/without_generated_header.go",
 		"testdata/with_cgo_import_no_generated_code.go",
 		"testdata/with_regular_code_comment_about_generated.go",
+		"testdata/with_generated_marker_after_package.go",
 	}
 	sort.Strings(filtered)
 	sort.Strings(want)
@@ -41,3 +42,13 @@ func TestUnitFilterOutGeneratedGoFiles(t *testing.T) {
 		t.Fatalf("Result mismatch: got - want +\n%s", diff)
 	}
 }
+
+func TestUnitAllowedFilesIncludeGenerated(t *testing.T) {
+	files := []string{"testdata/with_generated_header.go", "testdata/without_generated_header.go"}
+	if diff := cmp.Diff(allowedFiles(false, files...), files[1:]); diff != "" {
+		t.Fatalf("Result mismatch: got - want +\n%s", diff)
+	}
+	if diff := cmp.Diff(allowedFiles(true, files...), files); diff != "" {
+		t.Fatalf("Result mismatch: got - want +\n%s", diff)
+	}
+}
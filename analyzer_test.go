@@ -1,10 +1,18 @@
 package gosec_test
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/cosmos/gosec/v2"
@@ -104,6 +112,170 @@ var _ = Describe("Analyzer", func() {
 			Expect(metrics.NumFiles).To(Equal(2))
 		})
 
+		It("should load a multi-file package by its import path", func() {
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G723")).Builders())
+			err := analyzer.Process(buildTags, "github.com/cosmos/gosec/v2/testdata/keeper")
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, metrics, errors := analyzer.Report()
+			Expect(errors).Should(BeEmpty())
+			Expect(metrics.NumFiles).To(Equal(2))
+			// The call of the method declared in the other file needs the type info of the whole package.
+			Expect(issues).Should(HaveLen(4))
+			files := map[string]bool{}
+			for _, issue := range issues {
+				files[filepath.Base(issue.File)] = true
+			}
+			Expect(files).Should(Equal(map[string]bool{"keeper.go": true, "inflation.go": true}))
+		})
+
+		It("should resolve the directories of the import paths", func() {
+			dirs, err := gosec.ImportPathDirs("github.com/cosmos/gosec/v2/testdata/keeper", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			abspath, err := filepath.Abs("testdata/keeper")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(dirs).Should(Equal([]string{abspath}))
+
+			dirs, err = gosec.ImportPathDirs("github.com/cosmos/gosec/v2/output/...", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(dirs).ShouldNot(BeEmpty())
+
+			_, err = gosec.ImportPathDirs("github.com/cosmos/gosec/v2/missing", nil)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should not analyze the files under the excluded dirs", func() {
+			analyzer.LoadRules(rules.Generate().Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("main.go", `
+				package main
+				func main(){
+				}`)
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			generated := filepath.Join(pkg.Path, "types_pb")
+			Expect(os.Mkdir(generated, 0755)).To(Succeed())
+			err = ioutil.WriteFile(filepath.Join(generated, "gen.go"), []byte(testutils.SampleCodeG101[0].Code[0]), 0644)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			paths, err := gosec.PackagePathsGlob(pkg.Path+"/...", []string{"*_pb"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(paths).Should(Equal([]string{pkg.Path}))
+			err = analyzer.Process(buildTags, paths...)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, metrics, _ := analyzer.Report()
+			Expect(issues).Should(BeEmpty())
+			Expect(metrics.NumFiles).To(Equal(1))
+
+			analyzer.Reset()
+			analyzer.LoadRules(rules.Generate().Builders())
+			paths, err = gosec.PackagePathsGlob(pkg.Path+"/...", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			err = analyzer.Process(buildTags, paths...)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, metrics, _ = analyzer.Report()
+			Expect(issues).Should(HaveLen(1))
+			Expect(metrics.NumFiles).To(Equal(2))
+		})
+
+		It("should report the same results when analyzing the files in parallel", func() {
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("main.go", `
+				package main
+				func main(){
+				}`)
+			for i := 0; i < 8; i++ {
+				pkg.AddFile(fmt.Sprintf("hash_%d.go", i), fmt.Sprintf(`
+				package main
+				import (
+					"crypto/md5"
+					"math/rand"
+				)
+				func hash%d() []byte {
+					h := md5.New()
+					h.Write([]byte{byte(rand.Int())})
+					return h.Sum(nil)
+				}`, i))
+			}
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			analyzer.LoadRules(rules.Generate().Builders())
+			err = analyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			serialIssues, serialMetrics, _ := analyzer.Report()
+			Expect(serialIssues).ShouldNot(BeEmpty())
+
+			parallelAnalyzer := gosec.NewAnalyzer(nil, tests, logger)
+			parallelAnalyzer.SetJobs(4)
+			parallelAnalyzer.LoadRules(rules.Generate().Builders())
+			err = parallelAnalyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			parallelIssues, parallelMetrics, _ := parallelAnalyzer.Report()
+			Expect(parallelIssues).Should(Equal(serialIssues))
+			Expect(parallelMetrics).Should(Equal(serialMetrics))
+		})
+
+		It("should hand over each issue as soon as it is found", func() {
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			for i := 0; i < 4; i++ {
+				pkg.AddFile(fmt.Sprintf("md5_%d.go", i), fmt.Sprintf(`
+				package main
+				import "crypto/md5"
+				func hash%d() []byte {
+					return md5.New().Sum(nil)
+				}`, i))
+			}
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			for _, jobs := range []int{1, 4} {
+				streamAnalyzer := gosec.NewAnalyzer(nil, tests, logger)
+				streamAnalyzer.SetJobs(jobs)
+				var streamed []*gosec.Issue
+				streamAnalyzer.SetIssueHandler(func(issue *gosec.Issue) {
+					streamed = append(streamed, issue)
+				})
+				streamAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G401")).Builders())
+				err = streamAnalyzer.Process(buildTags, pkg.Path)
+				Expect(err).ShouldNot(HaveOccurred())
+				issues, _, _ := streamAnalyzer.Report()
+				Expect(issues).Should(HaveLen(4))
+				Expect(streamed).Should(ConsistOf(issues))
+			}
+		})
+
+		It("should hand over each file checked with the number of its issues", func() {
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("md5.go", `
+				package main
+				import "crypto/md5"
+				func hash() []byte {
+					return md5.New().Sum(nil)
+				}`)
+			pkg.AddFile("main.go", `
+				package main
+				func main() {}`)
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			for _, jobs := range []int{1, 4} {
+				progressAnalyzer := gosec.NewAnalyzer(nil, tests, logger)
+				progressAnalyzer.SetJobs(jobs)
+				checked := make(map[string]int)
+				progressAnalyzer.SetFileHandler(func(file string, numIssues int) {
+					checked[filepath.Base(file)] += numIssues
+				})
+				progressAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G401")).Builders())
+				err = progressAnalyzer.Process(buildTags, pkg.Path)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(checked).Should(Equal(map[string]int{"md5.go": 1, "main.go": 0}))
+			}
+		})
+
 		It("should find errors when nosec is not in use", func() {
 			sample := testutils.SampleCodeG401[0]
 			source := sample.Code[0]
@@ -214,6 +386,114 @@ var _ = Describe("Analyzer", func() {
 			Expect(nosecIssues).Should(BeEmpty())
 		})
 
+		Context("when requiring nosec justifications", func() {
+			var strictAnalyzer *gosec.Analyzer
+
+			BeforeEach(func() {
+				config := gosec.NewConfig()
+				config.SetGlobal(gosec.StrictNosec, "true")
+				strictAnalyzer = gosec.NewAnalyzer(config, tests, logger)
+				strictAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G401")).Builders())
+			})
+
+			analyze := func(comment string) []*gosec.Issue {
+				source := testutils.SampleCodeG401[0].Code[0]
+				nosecPackage := testutils.NewTestPackage()
+				defer nosecPackage.Close()
+				nosecPackage.AddFile("md5.go", strings.Replace(source, "h := md5.New()", "h := md5.New() // "+comment, 1))
+				err := nosecPackage.Build()
+				Expect(err).ShouldNot(HaveOccurred())
+				err = strictAnalyzer.Process(buildTags, nosecPackage.Path)
+				Expect(err).ShouldNot(HaveOccurred())
+				issues, _, _ := strictAnalyzer.Report()
+				return issues
+			}
+
+			It("should accept a justified nosec comment", func() {
+				Expect(analyze("#nosec -- md5 is only used for the cache keys")).Should(BeEmpty())
+			})
+
+			It("should accept a justified nosec comment scoped to rules", func() {
+				Expect(analyze("#nosec G301 G401 md5 is only used for the cache keys")).Should(BeEmpty())
+			})
+
+			It("should report a bare nosec comment", func() {
+				issues := analyze("#nosec")
+				Expect(issues).Should(HaveLen(1))
+				Expect(issues[0].RuleID).Should(Equal(gosec.NosecJustificationID))
+			})
+
+			It("should report a nosec comment scoped to rules without a justification", func() {
+				issues := analyze("#nosec G401")
+				Expect(issues).Should(HaveLen(1))
+				Expect(issues[0].RuleID).Should(Equal(gosec.NosecJustificationID))
+			})
+		})
+
+		Context("when enabling disabled rules on a node", func() {
+			var disabledAnalyzer *gosec.Analyzer
+
+			BeforeEach(func() {
+				config := gosec.NewConfig()
+				config.Set(gosec.DisabledRules, []string{"G401"})
+				disabledAnalyzer = gosec.NewAnalyzer(config, tests, logger)
+				disabledAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G401")).Builders())
+			})
+
+			analyze := func(comment string) []*gosec.Issue {
+				source := `
+package main
+
+import (
+	"crypto/md5"
+	"fmt"
+)
+
+func cacheKey(data []byte) string {
+	return fmt.Sprintf("%x", md5.Sum(data))
+}
+
+` + comment + `
+func checksum(data []byte) string {
+	return fmt.Sprintf("%x", md5.Sum(data))
+}
+
+func main() {
+	fmt.Println(cacheKey(nil), checksum(nil))
+}
+`
+				pkg := testutils.NewTestPackage()
+				defer pkg.Close()
+				pkg.AddFile("md5.go", source)
+				err := pkg.Build()
+				Expect(err).ShouldNot(HaveOccurred())
+				err = disabledAnalyzer.Process(buildTags, pkg.Path)
+				Expect(err).ShouldNot(HaveOccurred())
+				issues, _, _ := disabledAnalyzer.Report()
+				return issues
+			}
+
+			It("should not report the disabled rule without the directive", func() {
+				Expect(analyze("")).Should(BeEmpty())
+				Expect(disabledAnalyzer.CheckRequiredRules()).ShouldNot(HaveOccurred())
+			})
+
+			It("should only report the disabled rule on the annotated node", func() {
+				issues := analyze("// #gosec:enable G401 -- the checksums are persisted")
+				Expect(issues).Should(HaveLen(1))
+				Expect(issues[0].RuleID).Should(Equal("G401"))
+				Expect(issues[0].Line).Should(Equal("15"))
+			})
+
+			It("should ignore the directive for the other rules", func() {
+				Expect(analyze("// #gosec:enable G101, G501")).Should(BeEmpty())
+			})
+
+			It("should give precedence to a nosec directive", func() {
+				Expect(analyze("// #gosec:enable G401\n// #nosec G401 -- the checksums are not persisted")).Should(BeEmpty())
+			})
+		})
+
 		It("should pass the build tags", func() {
 			sample := testutils.SampleCodeBuildTag[0]
 			source := sample.Code[0]
@@ -339,6 +619,412 @@ var _ = Describe("Analyzer", func() {
 			Expect(issues).Should(HaveLen(1))
 		})
 	})
+	Context("when analyzing a parsed file", func() {
+		It("should find the same issues as the scan of the package", func() {
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("md5.go", `
+				package main
+				import (
+					"crypto/md5"
+					"fmt"
+				)
+				func main() {
+					fmt.Println(md5.New().Sum(nil))
+					md5.Sum(nil) // #nosec G401
+				}`)
+			Expect(pkg.Build()).Should(Succeed())
+			builders := rules.Generate(rules.NewRuleFilter(false, "G401", "G501")).Builders()
+			analyzer.LoadRules(builders)
+			Expect(analyzer.Process(buildTags, pkg.Path)).Should(Succeed())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).Should(HaveLen(2))
+
+			filename := filepath.Join(pkg.Path, "md5.go")
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+			Expect(err).ShouldNot(HaveOccurred())
+			info := &types.Info{
+				Types:      make(map[ast.Expr]types.TypeAndValue),
+				Defs:       make(map[*ast.Ident]types.Object),
+				Uses:       make(map[*ast.Ident]types.Object),
+				Selections: make(map[*ast.SelectorExpr]*types.Selection),
+				Implicits:  make(map[ast.Node]types.Object),
+				Scopes:     make(map[ast.Node]*types.Scope),
+			}
+			conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+			typesPkg, err := conf.Check("main", fset, []*ast.File{file}, info)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			ruleset := gosec.NewRuleSet()
+			for id, build := range builders {
+				rule, nodes := build(id, gosec.NewConfig())
+				ruleset.Register(rule, nodes...)
+			}
+			Expect(gosec.AnalyzeFile(fset, file, info, typesPkg, ruleset)).Should(Equal(issues))
+		})
+	})
+
+	Context("when caching the issues", func() {
+		var (
+			pkg      *testutils.TestPackage
+			cacheDir string
+		)
+		BeforeEach(func() {
+			pkg = testutils.NewTestPackage()
+			pkg.AddFile("md5.go", `
+				package main
+				import "crypto/md5"
+				func main() {
+					md5.New() // #nosec G104
+				}`)
+			pkg.AddFile("sha1.go", `
+				package main
+				import "crypto/sha1"
+				func hash() []byte {
+					return sha1.New().Sum(nil)
+				}`)
+			Expect(pkg.Build()).Should(Succeed())
+			var err error
+			cacheDir, err = ioutil.TempDir("", "gosec-cache")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		AfterEach(func() {
+			pkg.Close()
+			os.RemoveAll(cacheDir)
+		})
+
+		scan := func(version string) ([]*gosec.Issue, *gosec.Metrics, string) {
+			cache, err := gosec.NewCache(cacheDir, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			cachedLogger, logs := testutils.NewLogger()
+			cachedAnalyzer := gosec.NewAnalyzer(nil, tests, cachedLogger)
+			cachedAnalyzer.SetCache(cache)
+			cachedAnalyzer.LoadRules(rules.Generate().Builders())
+			Expect(cachedAnalyzer.Process(buildTags, pkg.Path)).Should(Succeed())
+			issues, metrics, _ := cachedAnalyzer.Report()
+			return issues, metrics, logs.String()
+		}
+
+		It("should reproduce the prior findings on a cache hit", func() {
+			issues, metrics, logs := scan("1.0")
+			Expect(issues).ShouldNot(BeEmpty())
+			Expect(logs).ShouldNot(ContainSubstring("Cached file:"))
+
+			cachedIssues, cachedMetrics, logs := scan("1.0")
+			Expect(logs).Should(ContainSubstring("Cached file:"))
+			Expect(logs).ShouldNot(ContainSubstring("Checking file:"))
+			Expect(cachedIssues).Should(Equal(issues))
+			Expect(cachedMetrics).Should(Equal(metrics))
+		})
+
+		It("should invalidate the entries when a file is edited", func() {
+			issues, _, _ := scan("1.0")
+			sha1File := filepath.Join(pkg.Path, "sha1.go")
+			err := ioutil.WriteFile(sha1File, []byte(`
+				package main
+				func hash() []byte {
+					return nil
+				}`), 0o600)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			editedIssues, _, logs := scan("1.0")
+			Expect(logs).ShouldNot(ContainSubstring("Cached file:"))
+			Expect(len(editedIssues)).Should(BeNumerically("<", len(issues)))
+			for _, issue := range editedIssues {
+				Expect(issue.File).ShouldNot(Equal(sha1File))
+			}
+		})
+
+		It("should invalidate the entries when the gosec version changes", func() {
+			scan("1.0")
+			_, _, logs := scan("1.1")
+			Expect(logs).ShouldNot(ContainSubstring("Cached file:"))
+		})
+	})
+
+	Context("when scanning the generated files", func() {
+		var pkg *testutils.TestPackage
+		BeforeEach(func() {
+			pkg = testutils.NewTestPackage()
+			pkg.AddFile("md5.go", `
+				package main
+				import "crypto/md5"
+				func main() {
+					md5.New()
+				}`)
+			pkg.AddFile("md5.pb.go", `// Code generated by protoc-gen-gogo. DO NOT EDIT.
+
+				package main
+				import "crypto/md5"
+				func hash() {
+					md5.New()
+				}`)
+			Expect(pkg.Build()).Should(Succeed())
+		})
+		AfterEach(func() {
+			pkg.Close()
+		})
+
+		files := func() []string {
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G401")).Builders())
+			Expect(analyzer.Process(buildTags, pkg.Path)).Should(Succeed())
+			issues, _, _ := analyzer.Report()
+			var files []string
+			for _, issue := range issues {
+				files = append(files, filepath.Base(issue.File))
+			}
+			return files
+		}
+
+		It("should skip the generated files by default", func() {
+			Expect(files()).Should(Equal([]string{"md5.go"}))
+		})
+
+		It("should scan the generated files when they are included", func() {
+			analyzer.SetIncludeGenerated(true)
+			Expect(files()).Should(Equal([]string{"md5.go", "md5.pb.go"}))
+		})
+	})
+
+	Context("when discovering the config files of the directories", func() {
+		var root string
+		BeforeEach(func() {
+			var err error
+			root, err = ioutil.TempDir("", "gosec-dir-config")
+			Expect(err).ShouldNot(HaveOccurred())
+			source := `
+				package main
+				import "crypto/md5"
+				func main() {
+					md5.New()
+				}`
+			Expect(os.MkdirAll(filepath.Join(root, "sub"), 0o750)).Should(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(root, "md5.go"), []byte(source), 0o600)).Should(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(root, "sub", "md5.go"), []byte(source), 0o600)).Should(Succeed())
+		})
+		AfterEach(func() {
+			os.RemoveAll(root)
+		})
+
+		writeConfig := func(dir, content string) {
+			Expect(ioutil.WriteFile(filepath.Join(root, dir, gosec.DirConfigName), []byte(content), 0o600)).Should(Succeed())
+		}
+
+		// scan returns the IDs of the rules reported in the root directory and
+		// in its sub directory
+		scan := func(overrides gosec.Config) (map[string]bool, map[string]bool, map[string][]gosec.Error) {
+			analyzer.SetDirConfigs(gosec.NewDirConfigs(overrides))
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G401", "G501")).Builders())
+			Expect(analyzer.Process(buildTags, root, filepath.Join(root, "sub"))).Should(Succeed())
+			issues, _, errors := analyzer.Report()
+			inRoot, inSub := make(map[string]bool), make(map[string]bool)
+			for _, issue := range issues {
+				if filepath.Dir(issue.File) == filepath.Join(root, "sub") {
+					inSub[issue.RuleID] = true
+				} else {
+					inRoot[issue.RuleID] = true
+				}
+			}
+			return inRoot, inSub, errors
+		}
+
+		It("should disable a rule only within the subtree of the config file", func() {
+			writeConfig("sub", "disabled_rules: [G401]\n")
+			inRoot, inSub, errors := scan(nil)
+			Expect(errors).Should(BeEmpty())
+			Expect(inRoot).Should(Equal(map[string]bool{"G401": true, "G501": true}))
+			Expect(inSub).Should(Equal(map[string]bool{"G501": true}))
+		})
+
+		It("should override the settings of the farther files with the nearer ones", func() {
+			writeConfig("", "disabled_rules: [G501]\n")
+			writeConfig("sub", "disabled_rules: [G401]\n")
+			inRoot, inSub, _ := scan(nil)
+			Expect(inRoot).Should(Equal(map[string]bool{"G401": true}))
+			Expect(inSub).Should(Equal(map[string]bool{"G501": true}))
+		})
+
+		It("should override the settings of the files with the given overrides", func() {
+			writeConfig("sub", "disabled_rules: [G401]\n")
+			_, inSub, _ := scan(gosec.Config{gosec.DisabledRules: []interface{}{}})
+			Expect(inSub).Should(Equal(map[string]bool{"G401": true, "G501": true}))
+		})
+
+		It("should report an invalid config file and use the configuration of the scan", func() {
+			writeConfig("sub", "disabled_rules: [G401\n")
+			_, inSub, errors := scan(nil)
+			Expect(errors).Should(HaveKey(filepath.Join(root, "sub")))
+			Expect(inSub).Should(Equal(map[string]bool{"G401": true, "G501": true}))
+		})
+
+		It("should report the errors of the packages checked with the config file of their directory", func() {
+			writeConfig("sub", "disabled_rules: [G401]\n")
+			broken := filepath.Join(root, "sub", "broken.go")
+			Expect(ioutil.WriteFile(broken, []byte("package main\n\nvar x int = \"x\"\n"), 0o600)).Should(Succeed())
+			_, inSub, errors := scan(nil)
+			Expect(errors).Should(HaveKey(broken))
+			Expect(inSub).Should(Equal(map[string]bool{"G501": true}))
+		})
+	})
+
+	Context("when scanning the vendored packages", func() {
+		var root, dep string
+		BeforeEach(func() {
+			var err error
+			root, err = ioutil.TempDir("", "gosec-vendor")
+			Expect(err).ShouldNot(HaveOccurred())
+			dep = filepath.Join(root, "vendor", "example.com", "dep")
+			Expect(os.MkdirAll(dep, 0o750)).Should(Succeed())
+			source := `
+				package dep
+				import "crypto/md5"
+				func Sum() {
+					md5.New()
+				}`
+			Expect(ioutil.WriteFile(filepath.Join(root, "md5.go"), []byte(source), 0o600)).Should(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dep, "md5.go"), []byte(source), 0o600)).Should(Succeed())
+		})
+		AfterEach(func() {
+			os.RemoveAll(root)
+		})
+
+		// scan returns the IDs of the rules reported outside of the vendor
+		// directory and in the vendored package
+		scan := func() (map[string]bool, map[string]bool) {
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G401", "G501")).Builders())
+			Expect(analyzer.Process(buildTags, root, dep)).Should(Succeed())
+			issues, metrics, _ := analyzer.Report()
+			Expect(metrics.NumFiles).Should(Equal(2))
+			inRoot, inVendor := make(map[string]bool), make(map[string]bool)
+			for _, issue := range issues {
+				if filepath.Dir(issue.File) == dep {
+					inVendor[issue.RuleID] = true
+				} else {
+					inRoot[issue.RuleID] = true
+				}
+			}
+			return inRoot, inVendor
+		}
+
+		It("should check the vendored packages with the rules of the scan by default", func() {
+			inRoot, inVendor := scan()
+			Expect(inRoot).Should(Equal(map[string]bool{"G401": true, "G501": true}))
+			Expect(inVendor).Should(Equal(map[string]bool{"G401": true, "G501": true}))
+		})
+
+		It("should check the vendored packages with the reduced set of rules of the vendor configuration", func() {
+			vendor := gosec.NewConfig()
+			vendor.Set(gosec.DisabledRules, []string{"G401"})
+			analyzer.SetVendorConfig(vendor)
+			inRoot, inVendor := scan()
+			Expect(inRoot).Should(Equal(map[string]bool{"G401": true, "G501": true}))
+			Expect(inVendor).Should(Equal(map[string]bool{"G501": true}))
+		})
+
+		It("should apply the config files of the directories over the vendor configuration", func() {
+			vendor := gosec.NewConfig()
+			vendor.Set(gosec.DisabledRules, []string{"G401"})
+			analyzer.SetVendorConfig(vendor)
+			analyzer.SetDirConfigs(gosec.NewDirConfigs(nil))
+			Expect(ioutil.WriteFile(filepath.Join(dep, gosec.DirConfigName), []byte("global:\n  nosec: enabled\n"), 0o600)).Should(Succeed())
+			_, inVendor := scan()
+			Expect(inVendor).Should(Equal(map[string]bool{"G501": true}))
+		})
+	})
+
+	Context("when summarizing the issues", func() {
+		It("should count the issues per rule, severity and confidence", func() {
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G401", "G501")).Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("md5.go", testutils.SampleCodeG401[0].Code[0])
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = analyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			summary := analyzer.Summary()
+			Expect(summary.NumFiles).To(Equal(1))
+			Expect(summary.NumFound).To(Equal(2))
+			Expect(summary.ByRule).To(Equal(map[string]int{"G401": 1, "G501": 1}))
+			Expect(summary.BySeverity).To(Equal(map[string]int{"MEDIUM": 2}))
+			Expect(summary.ByConfidence).To(Equal(map[string]int{"HIGH": 2}))
+
+			raw, err := json.Marshal(summary)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(raw)).To(ContainSubstring(`"files":1`))
+			Expect(string(raw)).To(ContainSubstring(`"by_rule":{"G401":1,"G501":1}`))
+		})
+	})
+
+	It("should report the issues of a rule with the severity of the configuration", func() {
+		config := gosec.NewConfig()
+		config.Set("G705", map[string]interface{}{gosec.RuleSeverity: "low"})
+		customAnalyzer := gosec.NewAnalyzer(config, tests, logger)
+		customAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G705")).Builders())
+		pkg := testutils.NewTestPackage()
+		defer pkg.Close()
+		pkg.AddFile("md.go", testutils.SampleCodeMapRangingNonDeterministic[0].Code[0])
+		err := pkg.Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = customAnalyzer.Process(buildTags, pkg.Path)
+		Expect(err).ShouldNot(HaveOccurred())
+		issues, _, _ := customAnalyzer.Report()
+		Expect(issues).ShouldNot(BeEmpty())
+		for _, issue := range issues {
+			Expect(issue.Severity).Should(Equal(gosec.Low))
+			Expect(issue.Confidence).Should(Equal(gosec.Medium))
+		}
+	})
+
+	It("should link the documentation of the rule from its issues", func() {
+		analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G702")).Builders())
+		pkg := testutils.NewTestPackage()
+		defer pkg.Close()
+		pkg.AddFile("unsafe.go", testutils.SampleCodeUnsafeImport[0].Code[0])
+		err := pkg.Build()
+		Expect(err).ShouldNot(HaveOccurred())
+		err = analyzer.Process(buildTags, pkg.Path)
+		Expect(err).ShouldNot(HaveOccurred())
+		issues, _, _ := analyzer.Report()
+		Expect(issues).ShouldNot(BeEmpty())
+		for _, issue := range issues {
+			Expect(issue.HelpURL).Should(Equal("https://github.com/cosmos/gosec/blob/master/rules/sdk/README.md#determinism-guidelines"))
+		}
+	})
+
+	Context("when verifying required rules", func() {
+		It("should not report an error when all required rules are loaded", func() {
+			config := gosec.NewConfig()
+			config.Set(gosec.RequiredRules, []string{"G702"})
+			customAnalyzer := gosec.NewAnalyzer(config, tests, logger)
+			customAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G702", "G705")).Builders())
+			Expect(customAnalyzer.CheckRequiredRules()).ShouldNot(HaveOccurred())
+		})
+
+		It("should report the required rules which are disabled", func() {
+			config := gosec.NewConfig()
+			config.Set(gosec.RequiredRules, []string{"G702", "G705"})
+			customAnalyzer := gosec.NewAnalyzer(config, tests, logger)
+			customAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(true, "G702", "G705")).Builders())
+			err := customAnalyzer.CheckRequiredRules()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(Equal("required rules are not enabled: G702, G705"))
+		})
+
+		It("should report the required rules which are disabled by the configuration", func() {
+			config := gosec.NewConfig()
+			config.Set(gosec.RequiredRules, []string{"G702", "G705"})
+			config.Set(gosec.DisabledRules, []string{"G705"})
+			customAnalyzer := gosec.NewAnalyzer(config, tests, logger)
+			customAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G702", "G705")).Builders())
+			err := customAnalyzer.CheckRequiredRules()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(Equal("required rules are not enabled: G705"))
+		})
+	})
+
 	It("should be able to analyze Cgo files", func() {
 		analyzer.LoadRules(rules.Generate().Builders())
 		sample := testutils.SampleCodeCgo[0]
@@ -0,0 +1,142 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gosec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Cache stores on disk the issues found in each file, so that the files which
+// did not change since a previous scan are not analyzed again. The entries are
+// keyed by a hash of the contents of the file and of the other files of its
+// package, of the loaded rules, of the configuration and of the gosec version,
+// any change of them invalidates the entries.
+type Cache struct {
+	dir     string
+	version string
+}
+
+// cacheEntry holds the findings of a file
+type cacheEntry struct {
+	Issues   []*Issue `json:"issues"`
+	NumLines int      `json:"lines"`
+	NumNosec int      `json:"nosec"`
+}
+
+// DefaultCacheDir returns the directory of the cache under the user cache
+// directory
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gosec"), nil
+}
+
+// NewCache creates a cache storing its entries in dir for the given gosec
+// version
+func NewCache(dir, version string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("creating the cache directory: %v", err)
+	}
+	return &Cache{dir: dir, version: version}, nil
+}
+
+// packageKey hashes what the findings in the files of pkgFiles depend on,
+// which is combined with the file name into the key of each entry
+func (c *Cache) packageKey(gosec *Analyzer, pkgFiles []string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "version %s\ntests %t %t\ngenerated %t\nnosec %t\n", c.version, gosec.tests, gosec.testFiles, gosec.generated, gosec.ignoreNosec)
+
+	ids := make([]string, 0, len(gosec.builders))
+	for id := range gosec.builders {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		fmt.Fprintf(h, "rule %s %t\n", id, gosec.disabled[id])
+	}
+	config, err := json.Marshal(gosec.config)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "config %s\n", config)
+
+	files := append([]string{}, pkgFiles...)
+	sort.Strings(files)
+	for _, file := range files {
+		// #nosec
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "file %s %x\n", file, sha256.Sum256(content))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fileKey returns the key of the entry of a file in a package
+func fileKey(pkgKey, filename string) string {
+	sum := sha256.Sum256([]byte(pkgKey + "\n" + filename))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// get returns the entry stored with key, if any
+func (c *Cache) get(key string) (*cacheEntry, bool) {
+	// #nosec
+	content, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	entry := &cacheEntry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+// put stores the entry with key, through a temporary file renamed into place
+// so that concurrent scans never read a partial entry
+func (c *Cache) put(key string, entry *cacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()           // #nosec G104
+		os.Remove(tmp.Name()) // #nosec G104
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name()) // #nosec G104
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
@@ -0,0 +1,132 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// uncommitted is the hash git blame reports for the lines which are not
+// committed yet
+const uncommitted = "0000000000000000000000000000000000000000"
+
+// blamer annotates the issues with the last commit which changed their line.
+// git blame runs once per file, the first time an issue of the file is
+// annotated. It is not safe for concurrent use.
+type blamer struct {
+	logger *log.Logger
+	files  map[string]map[int]*gosec.Blame
+}
+
+func newBlamer(logger *log.Logger) *blamer {
+	return &blamer{logger: logger, files: make(map[string]map[int]*gosec.Blame)}
+}
+
+// annotate returns a copy of the issue with the blame of its first line, if
+// the line is committed. The issue itself is left untouched, the analyzer owns
+// it and caches it as found.
+func (b *blamer) annotate(issue *gosec.Issue) *gosec.Issue {
+	lines, ok := b.files[issue.File]
+	if !ok {
+		var err error
+		lines, err = blameFile(issue.File)
+		if err != nil {
+			b.logger.Printf("Failed to blame %s: %v", issue.File, err)
+		}
+		b.files[issue.File] = lines
+	}
+	annotated := *issue
+	if line, err := strconv.Atoi(strings.Split(issue.Line, "-")[0]); err == nil {
+		annotated.Blame = lines[line]
+	}
+	return &annotated
+}
+
+// annotateAll returns the copies of the issues annotated with their blame
+func (b *blamer) annotateAll(issues []*gosec.Issue) []*gosec.Issue {
+	annotated := make([]*gosec.Issue, 0, len(issues))
+	for _, issue := range issues {
+		annotated = append(annotated, b.annotate(issue))
+	}
+	return annotated
+}
+
+// blameFile runs git blame on the file from its directory, so that the file is
+// blamed in its own repository
+func blameFile(file string) (map[int]*gosec.Blame, error) {
+	// #nosec G204
+	cmd := exec.Command("git", "blame", "--line-porcelain", "--", filepath.Base(file))
+	cmd.Dir = filepath.Dir(file)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseBlame(bytes.NewReader(out))
+}
+
+// parseBlame parses the output of git blame --line-porcelain into the blame of
+// each line of the file, the uncommitted lines being left out
+func parseBlame(r io.Reader) (map[int]*gosec.Blame, error) {
+	lines := make(map[int]*gosec.Blame)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var (
+		current *gosec.Blame
+		line    int
+	)
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case current == nil:
+			// The header of a line: <commit> <original line> <final line> [<lines in group>]
+			fields := strings.Fields(text)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("invalid blame header %q", text)
+			}
+			final, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid line number in the blame header %q", text)
+			}
+			current, line = &gosec.Blame{Commit: fields[0]}, final
+		case strings.HasPrefix(text, "\t"):
+			// The content of the line ends its entry
+			if current.Commit != uncommitted {
+				lines[line] = current
+			}
+			current = nil
+		case strings.HasPrefix(text, "author "):
+			current.Author = strings.TrimPrefix(text, "author ")
+		case strings.HasPrefix(text, "author-mail "):
+			current.Email = strings.Trim(strings.TrimPrefix(text, "author-mail "), "<>")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return nil, fmt.Errorf("truncated blame of line %d", line)
+	}
+	return lines, nil
+}
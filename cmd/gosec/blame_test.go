@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Blaming the issues", func() {
+	alice := &gosec.Blame{Commit: "3f1c2d4e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d", Author: "Alice Martin", Email: "alice@example.com"}
+	bob := &gosec.Blame{Commit: "9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b", Author: "Bob Chen", Email: "bob@example.com"}
+
+	It("parses the blame of each committed line", func() {
+		file, err := os.Open("testdata/blame/keeper.porcelain")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer file.Close()
+
+		lines, err := parseBlame(file)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(lines).To(Equal(map[int]*gosec.Blame{1: alice, 2: alice, 3: bob, 4: alice}))
+	})
+
+	It("rejects a truncated blame", func() {
+		_, err := parseBlame(strings.NewReader("3f1c2d4e5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d 1 1 1\nauthor Alice Martin\n"))
+		Expect(err).Should(HaveOccurred())
+
+		_, err = parseBlame(strings.NewReader("author Alice Martin\n"))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("annotates the issues with the blame of their first line", func() {
+		logger, _ := testutils.NewLogger()
+		b := newBlamer(logger)
+		b.files["/src/x/bank/keeper/keeper.go"] = map[int]*gosec.Blame{3: bob, 4: alice}
+
+		hash := &gosec.Issue{File: "/src/x/bank/keeper/keeper.go", Line: "3-4"}
+		uncommitted := &gosec.Issue{File: "/src/x/bank/keeper/keeper.go", Line: "5"}
+		Expect(b.annotate(hash).Blame).To(Equal(bob))
+		Expect(b.annotate(uncommitted).Blame).To(BeNil())
+	})
+
+	It("leaves the issues of the analyzer untouched", func() {
+		logger, _ := testutils.NewLogger()
+		b := newBlamer(logger)
+		b.files["/src/x/bank/keeper/keeper.go"] = map[int]*gosec.Blame{3: bob}
+
+		found := []*gosec.Issue{{File: "/src/x/bank/keeper/keeper.go", Line: "3", RuleID: "G705"}}
+		annotated := b.annotateAll(found)
+		Expect(annotated).To(HaveLen(1))
+		Expect(annotated[0].Blame).To(Equal(bob))
+		Expect(annotated[0].RuleID).To(Equal("G705"))
+		Expect(found[0].Blame).To(BeNil())
+	})
+
+	It("blames each file once, even when git fails", func() {
+		logger, logs := testutils.NewLogger()
+		b := newBlamer(logger)
+		dir := GinkgoT().TempDir()
+		for _, line := range []string{"1", "2"} {
+			issue := &gosec.Issue{File: dir + "/keeper.go", Line: line}
+			Expect(b.annotate(issue).Blame).To(BeNil())
+		}
+		Expect(b.files).To(HaveKey(dir + "/keeper.go"))
+		Expect(strings.Count(logs.String(), "Failed to blame")).To(Equal(1))
+	})
+})
@@ -0,0 +1,104 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// changedGoFiles returns the absolute paths of the Go files changed since the
+// git ref, as listed by git diff relative to the working directory.
+func changedGoFiles(ref string) (map[string]bool, error) {
+	// #nosec G204
+	out, err := exec.Command("git", "diff", "--name-only", "--relative", ref, "--").Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing the files changed since %s: %v", ref, err)
+	}
+	return goFilesFromDiff(strings.Split(string(out), "\n"))
+}
+
+// listedGoFiles returns the absolute paths of the Go files listed on r, one per
+// line or separated by NUL bytes as printed by git diff -z, e.g. the staged
+// files piped by a pre-commit hook.
+func listedGoFiles(r io.Reader) (map[string]bool, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading the list of files: %v", err)
+	}
+	names := strings.FieldsFunc(string(content), func(c rune) bool {
+		return c == 0 || c == '\n' || c == '\r'
+	})
+	return goFilesFromDiff(names)
+}
+
+// goFilesFromDiff returns the absolute paths of the Go files of the diff list
+// which still exist, as the deleted files cannot be analyzed.
+func goFilesFromDiff(names []string) (map[string]bool, error) {
+	files := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if filepath.Ext(name) != ".go" {
+			continue
+		}
+		if _, err := os.Stat(name); err != nil {
+			continue
+		}
+		path, err := filepath.Abs(name)
+		if err != nil {
+			return nil, err
+		}
+		files[path] = true
+	}
+	return files, nil
+}
+
+// changedPackages keeps the packages holding at least one of the changed files,
+// all the files of those packages being needed for the type checking.
+func changedPackages(packages []string, changed map[string]bool) ([]string, error) {
+	dirs := make(map[string]bool)
+	for file := range changed {
+		dirs[filepath.Dir(file)] = true
+	}
+	var result []string
+	for _, pkg := range packages {
+		dir, err := filepath.Abs(pkg)
+		if err != nil {
+			return nil, err
+		}
+		if dirs[dir] {
+			result = append(result, pkg)
+		}
+	}
+	return result, nil
+}
+
+// filterChangedIssues keeps the issues found in the changed files.
+func filterChangedIssues(issues []*gosec.Issue, changed map[string]bool) []*gosec.Issue {
+	result := []*gosec.Issue{}
+	for _, issue := range issues {
+		if changed[issue.File] {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/rules"
+	"github.com/cosmos/gosec/v2/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Selecting the changed files", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gosec")
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		for _, name := range []string{"keeper/keeper.go", "keeper/msgs.go", "types/types.go", "docs/README.md"} {
+			path := filepath.Join(dir, name)
+			Expect(os.MkdirAll(filepath.Dir(path), 0o700)).To(Succeed())
+			Expect(ioutil.WriteFile(path, []byte("package x\n"), 0o600)).To(Succeed())
+		}
+	})
+
+	It("keeps the Go files of the diff which still exist", func() {
+		changed, err := goFilesFromDiff([]string{
+			filepath.Join(dir, "keeper/keeper.go"),
+			filepath.Join(dir, "keeper/deleted.go"),
+			filepath.Join(dir, "docs/README.md"),
+			"",
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changed).To(Equal(map[string]bool{filepath.Join(dir, "keeper/keeper.go"): true}))
+	})
+
+	It("keeps the packages of the changed files", func() {
+		changed := map[string]bool{filepath.Join(dir, "keeper/keeper.go"): true}
+		packages, err := changedPackages([]string{filepath.Join(dir, "keeper"), filepath.Join(dir, "types")}, changed)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(packages).To(Equal([]string{filepath.Join(dir, "keeper")}))
+	})
+
+	It("keeps the issues of the changed files only", func() {
+		changed := map[string]bool{filepath.Join(dir, "keeper/keeper.go"): true}
+		inChanged := createIssue()
+		inChanged.File = filepath.Join(dir, "keeper/keeper.go")
+		inSibling := createIssue()
+		inSibling.File = filepath.Join(dir, "keeper/msgs.go")
+		issues := filterChangedIssues([]*gosec.Issue{&inChanged, &inSibling}, changed)
+		Expect(issues).To(Equal([]*gosec.Issue{&inChanged}))
+	})
+
+	It("reads the Go files listed on stdin separated by NUL bytes or newlines", func() {
+		keeper, types := filepath.Join(dir, "keeper/keeper.go"), filepath.Join(dir, "types/types.go")
+		expected := map[string]bool{keeper: true, types: true}
+		for _, list := range []string{
+			keeper + "\x00" + types + "\x00" + filepath.Join(dir, "docs/README.md") + "\x00",
+			keeper + "\n" + types + "\r\n\n",
+		} {
+			files, err := listedGoFiles(strings.NewReader(list))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(files).To(Equal(expected))
+		}
+	})
+
+	It("only reports the issues of the listed files", func() {
+		pkg := testutils.NewTestPackage()
+		defer pkg.Close()
+		for _, name := range []string{"staged.go", "sibling.go"} {
+			pkg.AddFile(name, "package keeper\n\nimport \"crypto/md5\"\n\nfunc "+strings.TrimSuffix(name, ".go")+"(data []byte) [16]byte {\n\treturn md5.Sum(data)\n}\n")
+		}
+		Expect(pkg.Build()).To(Succeed())
+		staged := filepath.Join(pkg.Path, "staged.go")
+
+		changed, err := listedGoFiles(strings.NewReader(staged + "\x00"))
+		Expect(err).ShouldNot(HaveOccurred())
+		packages, err := changedPackages([]string{pkg.Path, dir}, changed)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(packages).To(Equal([]string{pkg.Path}))
+
+		logger, _ := testutils.NewLogger()
+		analyzer := gosec.NewAnalyzer(gosec.NewConfig(), false, logger)
+		analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G401")).Builders())
+		Expect(analyzer.Process(nil, packages...)).To(Succeed())
+		issues, _, _ := analyzer.Report()
+		Expect(issues).To(HaveLen(2))
+
+		issues = filterChangedIssues(issues, changed)
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0].File).To(Equal(staged))
+	})
+})
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// scanDiff holds the issues of a scan compared with the issues of a previous
+// scan, matched by their fingerprints
+type scanDiff struct {
+	New        []*gosec.Issue `json:"new"`
+	Fixed      []*gosec.Issue `json:"fixed"`
+	Persisting []*gosec.Issue `json:"persisting"`
+}
+
+// readScanResults reads the issues of a report written with -fmt=json
+func readScanResults(path string) ([]*gosec.Issue, error) {
+	// #nosec
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading the results %q: %v", path, err)
+	}
+	var results struct {
+		Issues []*gosec.Issue
+	}
+	if err := json.Unmarshal(content, &results); err != nil {
+		return nil, fmt.Errorf("parsing the results %q: %v", path, err)
+	}
+	return results.Issues, nil
+}
+
+// relativePath strips the first matching root path from the file of the issue
+func relativePath(file string, rootPaths []string) string {
+	for _, rootPath := range rootPaths {
+		if strings.HasPrefix(file, rootPath+"/") {
+			return strings.TrimPrefix(file, rootPath+"/")
+		}
+	}
+	return file
+}
+
+// diffScans compares the issues of two scans. The issues sharing a fingerprint
+// are matched one to one, so that a copy of an issue added next to an old one
+// is still reported as new.
+func diffScans(before, after []*gosec.Issue, rootPaths []string) *scanDiff {
+	remaining := make(map[string][]*gosec.Issue)
+	for _, issue := range before {
+		key := issue.Fingerprint(relativePath(issue.File, rootPaths))
+		remaining[key] = append(remaining[key], issue)
+	}
+
+	diff := &scanDiff{New: []*gosec.Issue{}, Fixed: []*gosec.Issue{}, Persisting: []*gosec.Issue{}}
+	for _, issue := range after {
+		key := issue.Fingerprint(relativePath(issue.File, rootPaths))
+		if len(remaining[key]) > 0 {
+			remaining[key] = remaining[key][1:]
+			diff.Persisting = append(diff.Persisting, issue)
+			continue
+		}
+		diff.New = append(diff.New, issue)
+	}
+	for _, issue := range before {
+		key := issue.Fingerprint(relativePath(issue.File, rootPaths))
+		for _, left := range remaining[key] {
+			if left == issue {
+				diff.Fixed = append(diff.Fixed, issue)
+			}
+		}
+	}
+	return diff
+}
+
+// writeScanDiff writes the diff as text, or as json
+func writeScanDiff(w io.Writer, format string, diff *scanDiff) error {
+	if format == "json" {
+		raw, err := json.MarshalIndent(diff, "", "\t")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(raw))
+		return err
+	}
+	for _, section := range []struct {
+		title  string
+		issues []*gosec.Issue
+	}{
+		{"New", diff.New},
+		{"Fixed", diff.Fixed},
+		{"Persisting", diff.Persisting},
+	} {
+		if _, err := fmt.Fprintf(w, "%s issues: %d\n", section.title, len(section.issues)); err != nil {
+			return err
+		}
+		for _, issue := range section.issues {
+			if _, err := fmt.Fprintf(w, "  [%s] - %s: %s (Confidence: %s, Severity: %s)\n",
+				issue.FileLocation(), issue.RuleID, issue.What, issue.Confidence, issue.Severity); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runDiff runs the diff subcommand comparing the results of two scans, and
+// returns the exit code
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("gosec diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("fmt", "text", "Set output format. Valid options are: json or text")
+	failOnNew := flags.Bool("fail-on-new", false, "Exit with a non-zero code when the newer scan has new issues")
+	var rootPaths arrayFlags
+	flags.Var(&rootPaths, "root", "Root path stripped from the files of the issues before matching them, e.g. the checkouts of the scans (can be specified multiple times)")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, "USAGE:\n\n\t$ gosec diff [OPTIONS] <previous results> <new results>\n\nThe results are the reports of the scans written with -fmt=json.\n\nOPTIONS:\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	before, err := readScanResults(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	after, err := readScanResults(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	for i, rootPath := range rootPaths {
+		rootPaths[i] = strings.TrimSuffix(rootPath, "/")
+	}
+
+	diff := diffScans(before, after, rootPaths)
+	if err := writeScanDiff(stdout, *format, diff); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if *failOnNew && len(diff.New) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/cosmos/gosec/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff of the scans", func() {
+	var before, after []*gosec.Issue
+
+	BeforeEach(func() {
+		var err error
+		before, err = readScanResults("testdata/diff/previous.json")
+		Expect(err).ShouldNot(HaveOccurred())
+		after, err = readScanResults("testdata/diff/current.json")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	ruleIDs := func(issues []*gosec.Issue) []string {
+		ids := []string{}
+		for _, issue := range issues {
+			ids = append(ids, issue.RuleID)
+		}
+		return ids
+	}
+
+	It("sorts the issues into new, fixed and persisting", func() {
+		diff := diffScans(before, after, []string{"/home/ci/release-v1", "/home/ci/release-v2"})
+		Expect(ruleIDs(diff.New)).To(Equal([]string{"G705"}))
+		Expect(ruleIDs(diff.Fixed)).To(Equal([]string{"G104"}))
+		Expect(ruleIDs(diff.Persisting)).To(Equal([]string{"G101", "G401"}))
+		Expect(diff.Persisting[0].Line).To(Equal("7"))
+	})
+
+	It("does not match the issues of other files without the root paths", func() {
+		diff := diffScans(before, after, nil)
+		Expect(diff.New).To(HaveLen(3))
+		Expect(diff.Fixed).To(HaveLen(3))
+		Expect(diff.Persisting).To(BeEmpty())
+	})
+
+	It("reports a copy of an issue as new", func() {
+		copied := *after[0]
+		copied.Line = "9"
+		copied.Code = "8: func init() {\n9: \tpassword := \"f62e5bcda4fae4f82370da0c6f20697b8f8447ef\"\n10: }\n"
+		diff := diffScans(before, append(after, &copied), []string{"/home/ci/release-v1", "/home/ci/release-v2"})
+		Expect(ruleIDs(diff.New)).To(Equal([]string{"G705", "G101"}))
+		Expect(ruleIDs(diff.Persisting)).To(Equal([]string{"G101", "G401"}))
+	})
+
+	It("prints the diff as text", func() {
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		code := runDiff([]string{"-root=/home/ci/release-v1", "-root=/home/ci/release-v2/", "testdata/diff/previous.json", "testdata/diff/current.json"}, stdout, stderr)
+		Expect(code).To(Equal(0))
+		Expect(stdout.String()).To(Equal(`New issues: 1
+  [/home/ci/release-v2/x/auth/genesis.go:21-23] - G705: Non-determinism from ranging over maps (Confidence: MEDIUM, Severity: MEDIUM)
+Fixed issues: 1
+  [/home/ci/release-v1/x/auth/crypto.go:11] - G104: Errors unhandled. (Confidence: HIGH, Severity: LOW)
+Persisting issues: 2
+  [/home/ci/release-v2/x/auth/keeper.go:7] - G101: Potential hardcoded credentials (Confidence: LOW, Severity: HIGH)
+  [/home/ci/release-v2/x/auth/crypto.go:10] - G401: Use of weak cryptographic primitive (Confidence: HIGH, Severity: MEDIUM)
+`))
+	})
+
+	It("emits the diff as json", func() {
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		code := runDiff([]string{"-fmt=json", "-root=/home/ci/release-v1", "-root=/home/ci/release-v2", "testdata/diff/previous.json", "testdata/diff/current.json"}, stdout, stderr)
+		Expect(code).To(Equal(0))
+		diff := &scanDiff{}
+		Expect(json.Unmarshal(stdout.Bytes(), diff)).To(Succeed())
+		Expect(ruleIDs(diff.New)).To(Equal([]string{"G705"}))
+		Expect(ruleIDs(diff.Fixed)).To(Equal([]string{"G104"}))
+		Expect(ruleIDs(diff.Persisting)).To(Equal([]string{"G101", "G401"}))
+	})
+
+	It("fails on the new issues when asked to", func() {
+		args := []string{"-fail-on-new", "-root=/home/ci/release-v1", "-root=/home/ci/release-v2", "testdata/diff/previous.json", "testdata/diff/current.json"}
+		Expect(runDiff(args, new(bytes.Buffer), new(bytes.Buffer))).To(Equal(1))
+
+		args = []string{"-fail-on-new", "testdata/diff/current.json", "testdata/diff/current.json"}
+		Expect(runDiff(args, new(bytes.Buffer), new(bytes.Buffer))).To(Equal(0))
+	})
+
+	It("requires two result files", func() {
+		stderr := new(bytes.Buffer)
+		Expect(runDiff([]string{"testdata/diff/previous.json"}, new(bytes.Buffer), stderr)).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring("gosec diff"))
+	})
+})
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// failPolicy decides whether the issues found make the scan fail. Without any
+// threshold every issue fails the scan.
+type failPolicy struct {
+	// maxIssues is the number of issues tolerated per severity, the
+	// severities which are not listed tolerate no issue.
+	maxIssues map[gosec.Score]int
+	// hardFail, when set, is the severity from which a single issue fails
+	// the scan whatever the thresholds.
+	hardFail *gosec.Score
+}
+
+// parseMaxIssues parses the thresholds given as a comma separated list of
+// severity:count pairs, e.g. "low:10,medium:2".
+func parseMaxIssues(value string) (map[gosec.Score]int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	maxIssues := make(map[gosec.Score]int)
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.Split(pair, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid threshold %q, expected severity:count", pair)
+		}
+		severity, err := convertToScore(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, err
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("invalid count %q for severity %s", parts[1], parts[0])
+		}
+		maxIssues[severity] = count
+	}
+	return maxIssues, nil
+}
+
+// exitCode computes the exit code of the scan: the errors always fail it, then
+// an issue at or above the hard-fail severity takes precedence over the
+// thresholds, which otherwise fail the scan once exceeded for any severity.
+func exitCode(issues []*gosec.Issue, errors map[string][]gosec.Error, policy failPolicy, noFail bool) int {
+	if noFail {
+		return 0
+	}
+	if len(errors) > 0 {
+		return 1
+	}
+	if policy.hardFail != nil {
+		for _, issue := range issues {
+			if issue.Severity >= *policy.hardFail {
+				return 1
+			}
+		}
+	}
+	if policy.maxIssues == nil {
+		if len(issues) > 0 {
+			return 1
+		}
+		return 0
+	}
+	counts := make(map[gosec.Score]int)
+	for _, issue := range issues {
+		counts[issue.Severity]++
+	}
+	for severity, count := range counts {
+		if count > policy.maxIssues[severity] {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"github.com/cosmos/gosec/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func issuesWithSeverities(severities ...gosec.Score) []*gosec.Issue {
+	issues := make([]*gosec.Issue, 0, len(severities))
+	for _, severity := range severities {
+		issue := createIssue()
+		issue.Severity = severity
+		issues = append(issues, &issue)
+	}
+	return issues
+}
+
+var _ = Describe("Computing the exit code", func() {
+	noErrors := map[string][]gosec.Error{}
+	high := gosec.High
+
+	It("fails on any issue without thresholds", func() {
+		Expect(exitCode(issuesWithSeverities(gosec.Low), noErrors, failPolicy{}, false)).To(Equal(1))
+		Expect(exitCode(issuesWithSeverities(), noErrors, failPolicy{}, false)).To(Equal(0))
+	})
+
+	It("tolerates the issues up to the thresholds", func() {
+		policy := failPolicy{maxIssues: map[gosec.Score]int{gosec.Low: 2, gosec.Medium: 1}}
+		Expect(exitCode(issuesWithSeverities(gosec.Low, gosec.Low, gosec.Medium), noErrors, policy, false)).To(Equal(0))
+		Expect(exitCode(issuesWithSeverities(gosec.Low, gosec.Low, gosec.Low), noErrors, policy, false)).To(Equal(1))
+		Expect(exitCode(issuesWithSeverities(gosec.Medium, gosec.Medium), noErrors, policy, false)).To(Equal(1))
+	})
+
+	It("tolerates no issue for the severities without threshold", func() {
+		policy := failPolicy{maxIssues: map[gosec.Score]int{gosec.Low: 5}}
+		Expect(exitCode(issuesWithSeverities(gosec.Low, gosec.High), noErrors, policy, false)).To(Equal(1))
+	})
+
+	It("fails on a hard-fail issue whatever the thresholds", func() {
+		policy := failPolicy{maxIssues: map[gosec.Score]int{gosec.Low: 5, gosec.High: 5}, hardFail: &high}
+		Expect(exitCode(issuesWithSeverities(gosec.Low, gosec.Low), noErrors, policy, false)).To(Equal(0))
+		Expect(exitCode(issuesWithSeverities(gosec.Low, gosec.High), noErrors, policy, false)).To(Equal(1))
+	})
+
+	It("fails on errors and never fails with no-fail", func() {
+		policy := failPolicy{maxIssues: map[gosec.Score]int{gosec.Low: 5}}
+		errors := map[string][]gosec.Error{"file.go": {*gosec.NewError(1, 1, "error")}}
+		Expect(exitCode(issuesWithSeverities(), errors, policy, false)).To(Equal(1))
+		Expect(exitCode(issuesWithSeverities(gosec.High), errors, failPolicy{hardFail: &high}, true)).To(Equal(0))
+	})
+
+	It("parses the thresholds", func() {
+		maxIssues, err := parseMaxIssues("low:10, medium:2")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(maxIssues).To(Equal(map[gosec.Score]int{gosec.Low: 10, gosec.Medium: 2}))
+
+		_, err = parseMaxIssues("low=10")
+		Expect(err).Should(HaveOccurred())
+		_, err = parseMaxIssues("critical:1")
+		Expect(err).Should(HaveOccurred())
+	})
+})
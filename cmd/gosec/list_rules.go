@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/rules"
+)
+
+// ruleInfo is the metadata of a registered rule printed by -list-rules
+type ruleInfo struct {
+	ID          string      `json:"id"`
+	Description string      `json:"description"`
+	Severity    gosec.Score `json:"severity"`
+	Confidence  gosec.Score `json:"confidence"`
+	Cwe         string      `json:"cwe,omitempty"`
+	OptIn       bool        `json:"opt_in"`
+	HelpURL     string      `json:"help_url,omitempty"`
+}
+
+// registeredRules registers the rules of the definitions in a rule set and
+// collects the metadata of the registered rules, sorted by ID
+func registeredRules(definitions rules.RuleList, conf gosec.Config) []ruleInfo {
+	ruleset := gosec.NewRuleSet()
+	for id, build := range definitions.Builders() {
+		rule, nodes := build(id, conf)
+		ruleset.Register(rule, nodes...)
+	}
+
+	var infos []ruleInfo
+	for _, rule := range ruleset.Rules() {
+		meta, _ := gosec.RuleMetaData(rule)
+		infos = append(infos, ruleInfo{
+			ID:          rule.ID(),
+			Description: definitions[rule.ID()].Description,
+			Severity:    meta.Severity,
+			Confidence:  meta.Confidence,
+			Cwe:         gosec.IssueToCWE[rule.ID()].ID,
+			OptIn:       rules.IsOptIn(rule.ID()),
+			HelpURL:     meta.HelpURL,
+		})
+	}
+	return infos
+}
+
+// listRules prints the metadata of the rules as a table, or as JSON with the
+// json format
+func listRules(w io.Writer, format string, infos []ruleInfo) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "\t")
+		return encoder.Encode(infos)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSEVERITY\tCONFIDENCE\tCWE\tDESCRIPTION")
+	for _, info := range infos {
+		cwe := "-"
+		if info.Cwe != "" {
+			cwe = "CWE-" + info.Cwe
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", info.ID, info.Severity, info.Confidence, cwe, info.Description)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/rules"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Listing the rules", func() {
+	unsafeImport := ruleInfo{
+		ID:          "G702",
+		Description: "Import blocklist for SDK modules",
+		Severity:    gosec.Medium,
+		Confidence:  gosec.High,
+		HelpURL:     "https://github.com/cosmos/gosec/blob/master/rules/sdk/README.md#determinism-guidelines",
+	}
+
+	It("collects the metadata of every registered rule", func() {
+		definitions := rules.Generate()
+		infos := registeredRules(definitions, gosec.NewConfig())
+		Expect(infos).To(HaveLen(len(definitions)))
+		Expect(infos).To(ContainElement(unsafeImport))
+		Expect(infos).To(ContainElement(ruleInfo{
+			ID:          "G401",
+			Description: "Detect the usage of DES, RC4, MD5 or SHA1",
+			Severity:    gosec.Medium,
+			Confidence:  gosec.High,
+			Cwe:         "326",
+		}))
+		Expect(infos).To(ContainElement(HaveField("OptIn", true)))
+	})
+
+	It("links the documentation of the SDK rules", func() {
+		for _, info := range registeredRules(rules.Generate(), gosec.NewConfig()) {
+			if !strings.HasPrefix(info.ID, "G7") {
+				continue
+			}
+			Expect(info.HelpURL).To(HavePrefix("https://github.com/cosmos/gosec/blob/master/rules/sdk/README.md#"), info.ID)
+		}
+	})
+
+	It("prints the rules as a table", func() {
+		buf := new(bytes.Buffer)
+		Expect(listRules(buf, "text", registeredRules(rules.Generate(), gosec.NewConfig()))).To(Succeed())
+		Expect(buf.String()).To(HavePrefix("ID    SEVERITY  CONFIDENCE  CWE"))
+		Expect(buf.String()).To(MatchRegexp(`(?m)^G702\s+MEDIUM\s+HIGH\s+-\s+Import blocklist for SDK modules$`))
+	})
+
+	It("prints the rules as JSON", func() {
+		buf := new(bytes.Buffer)
+		Expect(listRules(buf, "json", registeredRules(rules.Generate(), gosec.NewConfig()))).To(Succeed())
+		var infos []ruleInfo
+		Expect(json.Unmarshal(buf.Bytes(), &infos)).To(Succeed())
+		Expect(infos).To(ContainElement(unsafeImport))
+	})
+})
@@ -15,13 +15,19 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
+	"go/build"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/cosmos/gosec/v2"
 	"github.com/cosmos/gosec/v2/output"
@@ -54,6 +60,12 @@ USAGE:
 	# Run all rules except the provided
 	$ gosec -exclude=G101 $GOPATH/src/github.com/example/project/...
 
+	# Opt-in rules only run when they are included
+	$ gosec -include=G710 ./...
+
+	# Compare the json results of two scans
+	$ gosec diff -fail-on-new previous.json results.json
+
 `
 )
 
@@ -72,20 +84,23 @@ var (
 	// #nosec flag
 	flagIgnoreNoSec = flag.Bool("nosec", false, "Ignores #nosec comments when set")
 
+	// require a justification after each #nosec
+	flagStrictNoSec = flag.Bool("strict-nosec", false, "Report the #nosec comments which are not followed by a justification")
+
 	// format output
-	flagFormat = flag.String("fmt", "text", "Set output format. Valid options are: json, yaml, csv, junit-xml, html, sonarqube, golint, sarif or text")
+	flagFormat = flag.String("fmt", "text", "Set output format. Valid options are: json, ndjson, yaml, csv, junit-xml, html, sonarqube, golint, sarif, codeclimate, github-actions, template or text")
+
+	// template of the template output format
+	flagTemplate = flag.String("template", "", "Path to the text/template file used by the template output format, requires -fmt=template")
 
 	// #nosec alternative tag
 	flagAlternativeNoSec = flag.String("nosec-tag", "", "Set an alternative string for #nosec. Some examples: #dontanalyze, #falsepositive")
 
 	// output file
-	flagOutput = flag.String("out", "", "Set output file for results")
-
-	// config file
-	flagConfig = flag.String("conf", "", "Path to optional config file")
+	flagOutput = flag.String("out", "", "Set output file for results, a summary of the scan being written to stderr")
 
 	// quiet
-	flagQuiet = flag.Bool("quiet", false, "Only show output when errors are found")
+	flagQuiet = flag.Bool("quiet", false, "Only show the issues at or above the -severity and -confidence thresholds, without the logs and the summary, and no output when none is found")
 
 	// rules to explicitly include
 	flagRulesInclude = flag.String("include", "", "Comma separated list of rules IDs to include. (see rule list)")
@@ -96,8 +111,8 @@ var (
 	// log to file or stderr
 	flagLogfile = flag.String("log", "", "Log messages to file rather than stderr")
 
-	// sort the issues by severity
-	flagSortIssues = flag.Bool("sort", true, "Sort issues by severity")
+	// order of the issues
+	flagSortIssues = sortOrder(sortByFile)
 
 	// go build tags
 	flagBuildTags = flag.String("tags", "", "Comma separated list of build tags")
@@ -108,18 +123,93 @@ var (
 	// fail by confidence
 	flagConfidence = flag.String("confidence", "low", "Filter out the issues with a lower confidence than the given value. Valid options are: low, medium, high")
 
+	// fail by confidence, spelled after the threshold
+	flagMinConfidence = flag.String("min-confidence", "", "Same as -confidence, the issues must meet both the -severity and the confidence thresholds. Valid options are: low, medium, high")
+
 	// do not fail
 	flagNoFail = flag.Bool("no-fail", false, "Do not fail the scanning, even if issues were found")
 
+	// number of issues tolerated per severity
+	flagMaxIssues = flag.String("max-issues", "", "Comma separated list of the number of issues tolerated per severity before failing the scanning, e.g. low:10,medium:2")
+
+	// fail on any issue from a severity
+	flagHardFail = flag.String("hard-fail", "", "Fail the scanning on any issue with the given severity or higher, whatever -max-issues. Valid options are: low, medium, high")
+
+	// number of files analyzed in parallel
+	flagJobs = flag.Int("jobs", runtime.GOMAXPROCS(0), "Number of files analyzed in parallel")
+
+	// only scan the files listed on stdin
+	flagStdinFiles = flag.Bool("stdin-files", false, "Only report the issues of the Go files listed on stdin, separated by newlines or NUL bytes, e.g. the files staged for a commit")
+
+	// annotate the issues with the last commit of their line
+	flagBlame = flag.Bool("blame", false, "Annotate each issue with the last commit and author which changed its line, as reported by git blame")
+
+	// only scan the files changed since a git ref
+	flagSince = flag.String("since", "", "Only report the issues of the Go files changed since the given git ref, e.g. origin/main")
+
 	// scan tests files
-	flagScanTests = flag.Bool("tests", false, "Scan tests files")
+	flagScanTests = testsMode(testsSkip)
+
+	// scan the generated files
+	flagIncludeGenerated = flag.Bool("include-generated", false, "Scan the generated files as well, the files marked with a \"// Code generated ... DO NOT EDIT.\" comment are skipped by default")
+
+	// lines of context around the code snippets
+	flagContext = flag.Int("context", gosec.SnippetOffset, "Number of lines of context shown before and after each finding")
+
+	// analyze all the files again
+	flagNoCache = flag.Bool("no-cache", false, "Analyze all the files instead of reusing the issues cached for the unchanged files")
+
+	// directory of the cache
+	flagCacheDir = flag.String("cache-dir", "", "Directory of the cache of the issues, defaults to gosec under the user cache directory")
 
 	// print version and quit with exit code 0
 	flagVersion = flag.Bool("version", false, "Print version and quit with exit code 0")
 
+	// print the rules and quit with exit code 0
+	flagListRules = flag.Bool("list-rules", false, "Print the rules with their severity, confidence and CWE and quit, as JSON with -fmt=json")
+
+	// file with the folders excluded from scan
+	flagDirsExcludeFile = flag.String("exclude-dir-file", "", "Read the excluded folders from a file, one gitignore-style pattern per line")
+
 	// exlude the folders from scan
 	flagDirsExclude arrayFlags
 
+	// SARIF reports of other tools merged into the gosec SARIF report
+	flagMergeSarif arrayFlags
+
+	// config files, merged in order
+	flagConfig arrayFlags
+
+	// plugins defining more rules
+	flagPlugins arrayFlags
+
+	// ignore the config files of the scanned directories
+	flagNoDirConfig = flag.Bool("no-dir-conf", false, "Ignore the .gosec.yaml files found in the directories of the scanned packages and in their parents")
+
+	// re-scan the packages of the changed files
+	flagWatch = flag.Bool("watch", false, "Re-scan the packages of the Go files changed after the scan until interrupted, or only scan once when the files cannot be watched")
+
+	// stream of the progress of the scan
+	flagProgress = flag.String("progress", "", "Write the progress of the scan to stderr as newline delimited events of the given format while the report goes to the output. Valid options are: json")
+
+	// paths of the files in the reports
+	flagPathMode = flag.String("path-mode", "relative", "Write the paths of the files in the reports relative to the -path-base directory or absolute. Valid options are: relative, absolute")
+
+	// base directory of the relative paths
+	flagPathBase = flag.String("path-base", "", "Directory the relative paths of the files in the reports start from, defaults to the working directory")
+
+	// preset configuration of the rules
+	flagProfile = flag.String("profile", rules.ProfileStandard, "Preset configuration of the rules, overridden by the config files. Valid options are: strict, standard, relaxed, critical")
+
+	// scan the vendored packages as well
+	flagScanVendor = flag.Bool("scan-vendor", false, "Scan the vendor directories as well, with the rules of the -vendor-profile")
+
+	// preset configuration of the rules of the vendored packages
+	flagVendorProfile = flag.String("vendor-profile", rules.ProfileCritical, "Preset configuration of the rules of the vendored packages with -scan-vendor, overriding the config files. Valid options are: strict, standard, relaxed, critical")
+
+	// parsed template of the template output format
+	reportTemplate *template.Template
+
 	logger *log.Logger
 )
 
@@ -140,34 +230,128 @@ func usage() {
 	sort.Strings(keys)
 	for _, k := range keys {
 		v := rl[k]
+		if rules.IsOptIn(k) {
+			fmt.Fprintf(os.Stderr, "\t%s: %s (opt-in)\n", k, v.Description)
+			continue
+		}
 		fmt.Fprintf(os.Stderr, "\t%s: %s\n", k, v.Description)
 	}
 	fmt.Fprint(os.Stderr, "\n")
 }
 
-func loadConfig(configFile string) (gosec.Config, error) {
+// loadConfig reads the config files one after the other over the base, e.g.
+// the preset of the profile, the settings of the later files overriding the
+// ones of the earlier files, and applies the overrides of the flags
+func loadConfig(base gosec.Config, configFiles []string, overrides gosec.Config) (gosec.Config, error) {
 	config := gosec.NewConfig()
-	if configFile != "" {
-		// #nosec
-		file, err := os.Open(configFile)
-		if err != nil {
-			return nil, err
+	config.Merge(base)
+	for _, configFile := range configFiles {
+		if configFile == "" {
+			continue
 		}
-		defer file.Close() // #nosec G307
-		if _, err := config.ReadFrom(file); err != nil {
+		if err := readConfigFile(config, configFile); err != nil {
 			return nil, err
 		}
 	}
+	config.Merge(overrides)
+	return config, nil
+}
+
+// flagsConfig returns the settings given by the flags, which override the
+// ones of the config files
+func flagsConfig() (gosec.Config, error) {
+	config := gosec.NewConfig()
 	if *flagIgnoreNoSec {
 		config.SetGlobal(gosec.Nosec, "true")
 	}
+	if *flagStrictNoSec {
+		config.SetGlobal(gosec.StrictNosec, "true")
+	}
 	if *flagAlternativeNoSec != "" {
 		config.SetGlobal(gosec.NoSecAlternative, *flagAlternativeNoSec)
 	}
+	if isFlagPassed("context") {
+		if *flagContext < 0 {
+			return nil, fmt.Errorf("invalid context value %d, it must not be negative", *flagContext)
+		}
+		config.SetGlobal(gosec.SnippetContext, strconv.Itoa(*flagContext))
+	}
 	return config, nil
 }
 
-func loadRules(include, exclude string) rules.RuleList {
+func readConfigFile(config gosec.Config, configFile string) error {
+	// #nosec
+	file, err := os.Open(configFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close() // #nosec G307
+	if _, err := config.ReadFrom(file); err != nil {
+		return fmt.Errorf("reading the config file %s: %v", configFile, err)
+	}
+	return nil
+}
+
+// loadExcludedDirs merges the excluded folders given on the command line with the
+// ones read from the exclude file.
+func loadExcludedDirs(dirs []string, excludeFile string) ([]string, error) {
+	patterns := append([]string{}, dirs...)
+	if excludeFile != "" {
+		fromFile, err := gosec.ReadExcludedDirs(excludeFile)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, fromFile...)
+	}
+	return gosec.ExcludedDirsGlob(patterns)
+}
+
+// withoutPattern returns the excluded folders but the pattern, e.g. to scan
+// the vendor directories excluded by default
+func withoutPattern(patterns []string, pattern string) []string {
+	var kept []string
+	for _, p := range patterns {
+		if p != pattern {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// vendorConfig returns the configuration of the vendored packages, the preset
+// of the profile overriding the configuration of the scan
+func vendorConfig(config gosec.Config, profile string, definitions rules.RuleList) (gosec.Config, error) {
+	preset, err := rules.Profile(profile, definitions)
+	if err != nil {
+		return nil, err
+	}
+	vendor := gosec.NewConfig()
+	vendor.Merge(config)
+	vendor.Merge(preset)
+	return vendor, nil
+}
+
+// packagePaths lists the packages of a path given on the command line, which is
+// either a directory or an import path, both optionally ending with "/...".
+func packagePaths(path string, excludedDirs, buildTags []string) ([]string, error) {
+	if _, err := os.Stat(strings.TrimSuffix(path, "...")); err == nil || build.IsLocalImport(path) {
+		return gosec.PackagePathsGlob(path, excludedDirs)
+	}
+	return gosec.ImportPathDirs(path, buildTags)
+}
+
+// isFlagPassed returns true if the flag was set on the command line.
+func isFlagPassed(name string) bool {
+	passed := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			passed = true
+		}
+	})
+	return passed
+}
+
+func loadRules(include, exclude string, enabled []string, pluginRules []rules.RuleDefinition) rules.RuleList {
 	var filters []rules.RuleFilter
 	if include != "" {
 		logger.Printf("Including rules: %s", include)
@@ -175,6 +359,7 @@ func loadRules(include, exclude string) rules.RuleList {
 		filters = append(filters, rules.NewRuleFilter(false, including...))
 	} else {
 		logger.Println("Including rules: default")
+		filters = append(filters, rules.NewOptInFilter(enabled...))
 	}
 
 	if exclude != "" {
@@ -184,30 +369,34 @@ func loadRules(include, exclude string) rules.RuleList {
 	} else {
 		logger.Println("Excluding rules: default")
 	}
-	return rules.Generate(filters...)
+	ruleList := rules.Generate(filters...)
+	for id, def := range rules.NewRuleList(pluginRules, filters...) {
+		ruleList[id] = def
+	}
+	return ruleList
 }
 
-func saveOutput(filename, format string, color bool, paths []string, issues []*gosec.Issue, metrics *gosec.Metrics, errors map[string][]gosec.Error) error {
-	rootPaths := []string{}
-	for _, path := range paths {
-		rootPath, err := gosec.RootPath(path)
-		if err != nil {
-			return fmt.Errorf("failed to get the root path of the projects: %s", err)
-		}
-		rootPaths = append(rootPaths, rootPath)
-	}
+// saveOutput writes the report to the file, or to stdout without a file, with
+// the paths of the files written in the given mode, or as they are when nil.
+// When the report goes to a file a brief summary of the scan is written to
+// console, unless console is nil.
+func saveOutput(filename, format string, color, quiet bool, paths *pathMode, issues []*gosec.Issue, metrics *gosec.Metrics, errors map[string][]gosec.Error, console io.Writer) error {
+	issues, errors = paths.apply(issues, errors)
 	if filename != "" {
 		outfile, err := os.Create(filename)
 		if err != nil {
 			return err
 		}
 		defer outfile.Close() // #nosec G307
-		err = output.CreateReport(outfile, format, color, rootPaths, issues, metrics, errors)
+		err = writeReport(outfile, format, color, quiet, nil, issues, metrics, errors)
 		if err != nil {
 			return err
 		}
+		if console != nil {
+			return writeSummary(console, filename, issues, metrics)
+		}
 	} else {
-		err := output.CreateReport(os.Stdout, format, color, rootPaths, issues, metrics, errors)
+		err := writeReport(os.Stdout, format, color, quiet, nil, issues, metrics, errors)
 		if err != nil {
 			return err
 		}
@@ -215,6 +404,74 @@ func saveOutput(filename, format string, color bool, paths []string, issues []*g
 	return nil
 }
 
+// writeSummary writes where the report was written and the number of issues
+// per severity, for the console when the report goes to a file
+func writeSummary(w io.Writer, filename string, issues []*gosec.Issue, metrics *gosec.Metrics) error {
+	summary := gosec.NewSummary(issues, metrics)
+	_, err := fmt.Fprintf(w, "Results written to %s\nSummary:\n   Files: %d\n   Lines: %d\n   Nosec: %d\n  Issues: %d (HIGH: %d, MEDIUM: %d, LOW: %d)\n",
+		filename, summary.NumFiles, summary.NumLines, summary.NumNosec, len(issues),
+		summary.BySeverity[gosec.High.String()], summary.BySeverity[gosec.Medium.String()], summary.BySeverity[gosec.Low.String()])
+	return err
+}
+
+// writeReport writes the report of the issues. In quiet mode nothing is written
+// when no issue is left, and the text report only lists the issues and the
+// Golang errors, without the summary of the scan.
+func writeReport(w io.Writer, format string, color, quiet bool, rootPaths []string, issues []*gosec.Issue, metrics *gosec.Metrics, errors map[string][]gosec.Error) error {
+	if quiet {
+		if len(issues) == 0 {
+			return nil
+		}
+		if format == "text" {
+			metrics = nil
+		}
+	}
+	return createReport(w, format, color, rootPaths, issues, metrics, errors)
+}
+
+// createReport writes the report and, when requested, merges the SARIF reports of
+// other tools with it, so that gosec becomes one run among the others.
+func createReport(w io.Writer, format string, color bool, rootPaths []string, issues []*gosec.Issue, metrics *gosec.Metrics, errors map[string][]gosec.Error) error {
+	if format == "template" {
+		return output.CreateTemplateReport(w, reportTemplate, issues, metrics, errors)
+	}
+	if len(flagMergeSarif) == 0 {
+		return output.CreateReport(w, format, color, rootPaths, issues, metrics, errors)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := output.CreateReport(buf, format, color, rootPaths, issues, metrics, errors); err != nil {
+		return err
+	}
+	names := []string{"gosec"}
+	reports := []io.Reader{buf}
+	for _, filename := range flagMergeSarif {
+		// #nosec
+		file, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close() // #nosec G307
+		names = append(names, filename)
+		reports = append(reports, file)
+	}
+	return output.MergeSarifReports(w, names, reports...)
+}
+
+// loadCache opens the cache of the issues in dir, or in the user cache directory
+// by default. The build information is part of the version so that the entries
+// of other gosec builds are not reused.
+func loadCache(dir string) (*gosec.Cache, error) {
+	if dir == "" {
+		defaultDir, err := gosec.DefaultCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = defaultDir
+	}
+	return gosec.NewCache(dir, fmt.Sprintf("%s %s %s", Version, GitTag, BuildDate))
+}
+
 func convertToScore(severity string) (gosec.Score, error) {
 	severity = strings.ToLower(severity)
 	switch severity {
@@ -229,6 +486,24 @@ func convertToScore(severity string) (gosec.Score, error) {
 	}
 }
 
+// confidenceThreshold returns the confidence threshold given by -confidence or
+// by its -min-confidence spelling, which must agree when both are given
+func confidenceThreshold(confidence string, confidenceSet bool, minConfidence string) (gosec.Score, error) {
+	if minConfidence == "" {
+		return convertToScore(confidence)
+	}
+	threshold, err := convertToScore(minConfidence)
+	if err != nil {
+		return threshold, err
+	}
+	if confidenceSet {
+		if other, err := convertToScore(confidence); err != nil || other != threshold {
+			return threshold, fmt.Errorf("-confidence=%s and -min-confidence=%s do not agree", confidence, minConfidence)
+		}
+	}
+	return threshold, nil
+}
+
 func filterIssues(issues []*gosec.Issue, severity gosec.Score, confidence gosec.Score) []*gosec.Issue {
 	result := []*gosec.Issue{}
 	for _, issue := range issues {
@@ -239,15 +514,43 @@ func filterIssues(issues []*gosec.Issue, severity gosec.Score, confidence gosec.
 	return result
 }
 
+// collectIssues returns the issues found by the analyzer to report, the issues
+// of the changed files only when changed is not nil, deduplicated and sorted,
+// which meet the severity and confidence thresholds
+func collectIssues(analyzer *gosec.Analyzer, changed map[string]bool, severity, confidence gosec.Score) ([]*gosec.Issue, *gosec.Metrics, map[string][]gosec.Error) {
+	issues, metrics, errors := analyzer.Report()
+	if changed != nil {
+		issues = filterChangedIssues(issues, changed)
+	}
+
+	// Collapse the issues found several times, e.g. across build tags
+	issues = gosec.DeduplicateIssues(issues)
+
+	// Sort the issues in the requested order
+	sortIssues(issues, flagSortIssues)
+
+	// Filter the issues by severity and confidence
+	issues = filterIssues(issues, severity, confidence)
+	if metrics.NumFound != len(issues) {
+		metrics.NumFound = len(issues)
+	}
+	return issues, metrics, errors
+}
+
 func main() {
 	// Makes sure some version information is set
 	prepareVersionInfo()
 
+	// Compare the results of two scans instead of scanning
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(runDiff(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	// Setup usage description
 	flag.Usage = usage
 
 	// Setup the excluded folders from scan
-	flag.Var(&flagDirsExclude, "exclude-dir", "Exclude folder from scan (can be specified multiple times)")
+	flag.Var(&flagDirsExclude, "exclude-dir", "Exclude the folders matching a gitignore-style glob pattern from scan (can be specified multiple times)")
 	err := flag.Set("exclude-dir", "vendor")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "\nError: failed to exclude the %q directory from scan", "vendor")
@@ -257,6 +560,21 @@ func main() {
 		fmt.Fprintf(os.Stderr, "\nError: failed to exclude the %q directory from scan", ".git")
 	}
 
+	// Setup the scan of the test files
+	flag.Var(&flagScanTests, "tests", "Scan tests files, all also checks them with the rules allowing the test files by design")
+
+	// Setup the order of the issues
+	flag.Var(&flagSortIssues, "sort", "Sort the issues by severity, file or rule, the ties being sorted by file and line")
+
+	// Setup the config files
+	flag.Var(&flagConfig, "conf", "Path to optional config file, the settings of the later files override the ones of the earlier files (can be specified multiple times)")
+
+	// Setup the plugins providing more rules
+	flag.Var(&flagPlugins, "plugin", "Path to a Go plugin whose NewRules function returns the definitions of more rules (can be specified multiple times)")
+
+	// Setup the SARIF reports merged into the output
+	flag.Var(&flagMergeSarif, "merge-sarif", "Merge the SARIF report of another tool into the output, requires -fmt=sarif (can be specified multiple times)")
+
 	// Parse command line arguments
 	flag.Parse()
 
@@ -265,6 +583,26 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Load the rules of the plugins
+	pluginRules, err := loadPlugins(flagPlugins)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nError: %v\n", err) // #nosec
+		os.Exit(1)
+	}
+
+	// Print the registered rules and quit
+	if *flagListRules {
+		definitions := rules.Generate()
+		for id, def := range rules.NewRuleList(pluginRules) {
+			definitions[id] = def
+		}
+		if err := listRules(os.Stdout, *flagFormat, registeredRules(definitions, gosec.NewConfig())); err != nil {
+			fmt.Fprintf(os.Stderr, "\nError: %v\n", err) // #nosec
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Ensure at least one file was specified
 	if flag.NArg() == 0 {
 		fmt.Fprintf(os.Stderr, "\nError: FILE [FILE...] or './...' expected\n") // #nosec
@@ -295,36 +633,117 @@ func main() {
 		color = true
 	}
 
+	if len(flagMergeSarif) > 0 && *flagFormat != "sarif" {
+		logger.Fatal("The -merge-sarif flag requires the sarif output format")
+	}
+
+	// Validate the report template before the scan rather than after it
+	if *flagFormat == "template" {
+		if *flagTemplate == "" {
+			logger.Fatal("The template output format requires the -template flag")
+		}
+		reportTemplate, err = output.ParseTemplate(*flagTemplate)
+		if err != nil {
+			logger.Fatalf("Invalid report template: %v", err)
+		}
+	} else if *flagTemplate != "" {
+		logger.Fatal("The -template flag requires the template output format")
+	}
+
+	paths, err := newPathMode(*flagPathMode, *flagPathBase)
+	if err != nil {
+		logger.Fatalf("Invalid path mode: %v", err)
+	}
+
 	failSeverity, err := convertToScore(*flagSeverity)
 	if err != nil {
 		logger.Fatalf("Invalid severity value: %v", err)
 	}
 
-	failConfidence, err := convertToScore(*flagConfidence)
+	failConfidence, err := confidenceThreshold(*flagConfidence, isFlagPassed("confidence"), *flagMinConfidence)
 	if err != nil {
 		logger.Fatalf("Invalid confidence value: %v", err)
 	}
 
+	maxIssues, err := parseMaxIssues(*flagMaxIssues)
+	if err != nil {
+		logger.Fatalf("Invalid max issues value: %v", err)
+	}
+	policy := failPolicy{maxIssues: maxIssues}
+	if *flagHardFail != "" {
+		hardFail, err := convertToScore(*flagHardFail)
+		if err != nil {
+			logger.Fatalf("Invalid hard fail value: %v", err)
+		}
+		policy.hardFail = &hardFail
+	}
+
 	// Load the analyzer configuration
-	config, err := loadConfig(*flagConfig)
+	overrides, err := flagsConfig()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	definitions := rules.Generate()
+	for id, def := range rules.NewRuleList(pluginRules) {
+		definitions[id] = def
+	}
+	profile, err := rules.Profile(*flagProfile, definitions)
+	if err != nil {
+		logger.Fatalf("Invalid profile: %v", err)
+	}
+	config, err := loadConfig(profile, flagConfig, overrides)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
 	// Load enabled rule definitions
-	ruleDefinitions := loadRules(*flagRulesInclude, *flagRulesExclude)
+	ruleDefinitions := loadRules(*flagRulesInclude, *flagRulesExclude, config.GetEnabledRules(), pluginRules)
 	if len(ruleDefinitions) == 0 {
 		logger.Fatal("No rules are configured")
 	}
 
 	// Create the analyzer
-	analyzer := gosec.NewAnalyzer(config, *flagScanTests, logger)
+	analyzer := gosec.NewAnalyzer(config, flagScanTests.scan(), logger)
+	analyzer.SetCheckTestFiles(flagScanTests.checkAll())
+	analyzer.SetIncludeGenerated(*flagIncludeGenerated)
+	analyzer.SetJobs(*flagJobs)
 	analyzer.LoadRules(ruleDefinitions.Builders())
+	if !*flagNoDirConfig {
+		analyzer.SetDirConfigs(gosec.NewDirConfigs(overrides))
+	}
+	excludedPatterns := flagDirsExclude
+	if *flagScanVendor {
+		vendorConfig, err := vendorConfig(config, *flagVendorProfile, definitions)
+		if err != nil {
+			logger.Fatalf("Invalid vendor profile: %v", err)
+		}
+		analyzer.SetVendorConfig(vendorConfig)
+		excludedPatterns = withoutPattern(flagDirsExclude, "vendor")
+	}
+	if !*flagNoCache {
+		cache, err := loadCache(*flagCacheDir)
+		if err != nil {
+			logger.Printf("Scanning without cache: %v", err)
+		} else {
+			analyzer.SetCache(cache)
+		}
+	}
+	if err := analyzer.CheckRequiredRules(); err != nil {
+		logger.Fatal(err)
+	}
 
-	excludedDirs := gosec.ExcludedDirsRegExp(flagDirsExclude)
+	var buildTags []string
+	if *flagBuildTags != "" {
+		buildTags = strings.Split(*flagBuildTags, ",")
+	}
+
+	excludedDirs, err := loadExcludedDirs(excludedPatterns, *flagDirsExcludeFile)
+	if err != nil {
+		logger.Fatalf("Invalid excluded folders: %v", err)
+	}
 	var packages []string
 	for _, path := range flag.Args() {
-		pcks, err := gosec.PackagePaths(path, excludedDirs)
+		pcks, err := packagePaths(path, excludedDirs, buildTags)
 		if err != nil {
 			logger.Fatal(err)
 		}
@@ -334,44 +753,162 @@ func main() {
 		logger.Fatal("No packages found")
 	}
 
-	var buildTags []string
-	if *flagBuildTags != "" {
-		buildTags = strings.Split(*flagBuildTags, ",")
+	// Restrict the scan to the packages of the changed files, or scan everything when git is not available
+	var changed map[string]bool
+	if *flagSince != "" {
+		changed, err = changedGoFiles(*flagSince)
+		if err != nil {
+			logger.Printf("Scanning all the packages: %v", err)
+		} else {
+			packages, err = changedPackages(packages, changed)
+			if err != nil {
+				logger.Fatal(err)
+			}
+			logger.Printf("Scanning the %d packages of the %d Go files changed since %s", len(packages), len(changed), *flagSince)
+		}
 	}
 
-	if err := analyzer.Process(buildTags, packages...); err != nil {
-		logger.Fatal(err)
+	if *flagWatch && (*flagStdinFiles || *flagFormat == "ndjson") {
+		logger.Fatal("The -watch flag cannot be combined with -stdin-files or the ndjson output format")
 	}
 
-	// Collect the results
-	issues, metrics, errors := analyzer.Report()
+	// Restrict the scan to the packages of the files listed on stdin
+	if *flagStdinFiles {
+		if *flagSince != "" {
+			logger.Fatal("The -since and -stdin-files flags cannot be combined")
+		}
+		changed, err = listedGoFiles(os.Stdin)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		packages, err = changedPackages(packages, changed)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Printf("Scanning the %d packages of the %d Go files read from stdin", len(packages), len(changed))
+	}
 
-	// Sort the issue by severity
-	if *flagSortIssues {
-		sortIssues(issues)
+	// Blame the files of the issues once each
+	var blame *blamer
+	if *flagBlame {
+		blame = newBlamer(logger)
 	}
 
-	// Filter the issues by severity and confidence
-	issues = filterIssues(issues, failSeverity, failConfidence)
-	if metrics.NumFound != len(issues) {
-		metrics.NumFound = len(issues)
+	// Stream the issues out as soon as they are found with the ndjson format
+	var stream *output.NDJSONWriter
+	if *flagFormat == "ndjson" {
+		out := io.Writer(os.Stdout)
+		if *flagOutput != "" {
+			outfile, err := os.Create(*flagOutput)
+			if err != nil {
+				logger.Fatal(err)
+			}
+			defer outfile.Close() // #nosec G307
+			out = outfile
+		}
+		stream = output.NewNDJSONWriter(out)
+		analyzer.SetIssueHandler(func(issue *gosec.Issue) {
+			if issue.Severity < failSeverity || issue.Confidence < failConfidence {
+				return
+			}
+			if changed != nil && !changed[issue.File] {
+				return
+			}
+			if blame != nil {
+				issue = blame.annotate(issue)
+			}
+			if err := stream.WriteIssue(paths.issue(issue)); err != nil {
+				logger.Printf("Failed to write the issue: %v", err)
+			}
+		})
 	}
 
-	// Exit quietly if nothing was found
-	if len(issues) == 0 && *flagQuiet {
+	// Write the progress of the scan as each file is checked
+	var progress *progressWriter
+	if *flagProgress != "" {
+		progress, err = newProgressWriter(os.Stderr, *flagProgress)
+		if err != nil {
+			logger.Fatalf("Invalid progress value: %v", err)
+		}
+		analyzer.SetFileHandler(progress.fileChecked)
+	}
+
+	if len(packages) > 0 {
+		if err := analyzer.Process(buildTags, packages...); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	if progress != nil {
+		progress.done()
+	}
+
+	// Collect the results
+	issues, metrics, errors := collectIssues(analyzer, changed, failSeverity, failConfidence)
+
+	// Annotate the reported issues, the streamed ones being annotated as they are found
+	if blame != nil && stream == nil {
+		issues = blame.annotateAll(issues)
+	}
+
+	// Exit quietly if nothing was found, unless watching the changes
+	if len(issues) == 0 && *flagQuiet && !*flagWatch {
 		os.Exit(0)
 	}
 
-	// Create output report
-	if err := saveOutput(*flagOutput, *flagFormat, color, flag.Args(), issues, metrics, errors); err != nil {
-		logger.Fatal(err)
+	// The summary on the console is left out in quiet mode
+	var console io.Writer = os.Stderr
+	if *flagQuiet {
+		console = nil
+	}
+
+	// Create output report, or end the streamed one with its summary
+	if stream != nil {
+		_, streamErrors := paths.apply(nil, errors)
+		if err := stream.WriteSummary(metrics, streamErrors); err != nil {
+			logger.Fatal(err)
+		}
+		if *flagOutput != "" && console != nil {
+			if err := writeSummary(console, *flagOutput, issues, metrics); err != nil {
+				logger.Fatal(err)
+			}
+		}
+	} else if len(issues) > 0 || !*flagQuiet {
+		if err := saveOutput(*flagOutput, *flagFormat, color, *flagQuiet, paths, issues, metrics, errors, console); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	// Report the issues of the packages of the changed files after each change, the last report setting the exit code
+	if *flagWatch {
+		err := watchPackages(packages, func(changedPkgs []string) {
+			logger.Printf("Re-scanning the %d packages of the changed files", len(changedPkgs))
+			analyzer.Reset()
+			analyzer.LoadRules(ruleDefinitions.Builders())
+			if err := analyzer.Process(buildTags, changedPkgs...); err != nil {
+				logger.Printf("Failed to re-scan the packages: %v", err)
+				return
+			}
+			issues, metrics, errors = collectIssues(analyzer, nil, failSeverity, failConfidence)
+			if blame != nil {
+				issues = blame.annotateAll(issues)
+			}
+			if len(issues) == 0 && *flagQuiet {
+				return
+			}
+			if err := saveOutput(*flagOutput, *flagFormat, color, *flagQuiet, paths, issues, metrics, errors, console); err != nil {
+				logger.Printf("Failed to write the report: %v", err)
+			}
+		})
+		if err != nil {
+			logger.Printf("Not watching the files, scanned once: %v", err)
+		}
 	}
 
 	// Finalize logging
 	logWriter.Close() // #nosec
 
-	// Do we have an issue? If so exit 1 unless NoFail is set
-	if (len(issues) > 0 || len(errors) > 0) && !*flagNoFail {
-		os.Exit(1)
+	// Do we have an issue? If so exit 1 unless NoFail is set or the issues are tolerated
+	if code := exitCode(issues, errors, policy, *flagNoFail); code != 0 {
+		os.Exit(code)
 	}
 }
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/rules"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Writing the report in quiet mode", func() {
+	noErrors := map[string][]gosec.Error{}
+	metrics := &gosec.Metrics{NumFiles: 1, NumLines: 10, NumFound: 2}
+
+	It("writes nothing and succeeds when only sub-threshold issues exist", func() {
+		issues := filterIssues(issuesWithSeverities(gosec.Low, gosec.Medium), gosec.High, gosec.Low)
+		for _, format := range []string{"text", "json", "sarif"} {
+			buf := new(bytes.Buffer)
+			Expect(writeReport(buf, format, false, true, []string{}, issues, metrics, noErrors)).To(Succeed())
+			Expect(buf.String()).To(BeEmpty())
+		}
+		Expect(exitCode(issues, noErrors, failPolicy{}, false)).To(Equal(0))
+	})
+
+	It("only lists the blocking issues in the text report", func() {
+		issues := filterIssues(issuesWithSeverities(gosec.Low, gosec.High), gosec.High, gosec.Low)
+		buf := new(bytes.Buffer)
+		Expect(writeReport(buf, "text", false, true, []string{}, issues, metrics, noErrors)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("[/home/src/project/test.go:1] - ruleID"))
+		Expect(buf.String()).NotTo(ContainSubstring("Results:"))
+		Expect(buf.String()).NotTo(ContainSubstring("Summary:"))
+		Expect(exitCode(issues, noErrors, failPolicy{}, false)).To(Equal(1))
+	})
+
+	It("keeps the whole document of the other formats", func() {
+		issues := issuesWithSeverities(gosec.High)
+		buf := new(bytes.Buffer)
+		Expect(writeReport(buf, "json", false, true, []string{}, issues, metrics, noErrors)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`"Stats"`))
+		Expect(buf.String()).To(ContainSubstring(`"rule_id": "ruleID"`))
+	})
+
+	It("writes the summary of the text report without the quiet mode", func() {
+		buf := new(bytes.Buffer)
+		Expect(writeReport(buf, "text", false, false, []string{}, nil, metrics, noErrors)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("Summary:"))
+	})
+})
+
+var _ = Describe("Writing the report to a file", func() {
+	noErrors := map[string][]gosec.Error{}
+	metrics := &gosec.Metrics{NumFiles: 3, NumLines: 120, NumNosec: 1, NumFound: 3}
+
+	It("writes the full report to the file and only the summary to the console", func() {
+		issues := issuesWithSeverities(gosec.High, gosec.Medium, gosec.Medium)
+		filename := filepath.Join(GinkgoT().TempDir(), "results.json")
+		console := new(bytes.Buffer)
+		Expect(saveOutput(filename, "json", false, false, nil, issues, metrics, noErrors, console)).To(Succeed())
+
+		content, err := ioutil.ReadFile(filename)
+		Expect(err).ShouldNot(HaveOccurred())
+		var report struct {
+			Issues []*gosec.Issue
+			Stats  *gosec.Metrics
+		}
+		Expect(json.Unmarshal(content, &report)).To(Succeed())
+		Expect(report.Issues).To(HaveLen(3))
+		Expect(report.Stats).To(Equal(metrics))
+
+		Expect(console.String()).To(Equal("Results written to " + filename + "\nSummary:\n   Files: 3\n   Lines: 120\n   Nosec: 1\n  Issues: 3 (HIGH: 1, MEDIUM: 2, LOW: 0)\n"))
+	})
+
+	It("writes no summary without a console", func() {
+		filename := filepath.Join(GinkgoT().TempDir(), "results.json")
+		Expect(saveOutput(filename, "json", false, true, nil, issuesWithSeverities(gosec.High), metrics, noErrors, nil)).To(Succeed())
+		content, err := ioutil.ReadFile(filename)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring(`"rule_id": "ruleID"`))
+	})
+})
+
+var _ = Describe("Filtering the issues by confidence", func() {
+	issuesWithConfidences := func(confidences ...gosec.Score) []*gosec.Issue {
+		issues := make([]*gosec.Issue, 0, len(confidences))
+		for _, confidence := range confidences {
+			issue := createIssue()
+			issue.Severity = gosec.High
+			issue.Confidence = confidence
+			issues = append(issues, &issue)
+		}
+		return issues
+	}
+	confidences := func(issues []*gosec.Issue) []gosec.Score {
+		scores := []gosec.Score{}
+		for _, issue := range issues {
+			scores = append(scores, issue.Confidence)
+		}
+		return scores
+	}
+	all := issuesWithConfidences(gosec.Low, gosec.Medium, gosec.High)
+
+	It("keeps the issues at or above each confidence threshold", func() {
+		Expect(confidences(filterIssues(all, gosec.Low, gosec.Low))).To(Equal([]gosec.Score{gosec.Low, gosec.Medium, gosec.High}))
+		Expect(confidences(filterIssues(all, gosec.Low, gosec.Medium))).To(Equal([]gosec.Score{gosec.Medium, gosec.High}))
+		Expect(confidences(filterIssues(all, gosec.Low, gosec.High))).To(Equal([]gosec.Score{gosec.High}))
+	})
+
+	It("requires both the severity and the confidence thresholds", func() {
+		issues := issuesWithConfidences(gosec.High, gosec.Medium)
+		issues[0].Severity = gosec.Low
+		Expect(filterIssues(issues, gosec.Medium, gosec.Medium)).To(Equal(issues[1:]))
+		Expect(filterIssues(issues, gosec.Medium, gosec.High)).To(BeEmpty())
+	})
+
+	It("reads the threshold from -min-confidence or -confidence", func() {
+		Expect(confidenceThreshold("low", false, "")).To(Equal(gosec.Low))
+		Expect(confidenceThreshold("medium", true, "")).To(Equal(gosec.Medium))
+		Expect(confidenceThreshold("low", false, "high")).To(Equal(gosec.High))
+		Expect(confidenceThreshold("high", true, "HIGH")).To(Equal(gosec.High))
+	})
+
+	It("rejects the invalid or conflicting thresholds", func() {
+		_, err := confidenceThreshold("low", false, "certain")
+		Expect(err).To(HaveOccurred())
+		_, err = confidenceThreshold("low", true, "high")
+		Expect(err).To(MatchError("-confidence=low and -min-confidence=high do not agree"))
+	})
+})
+
+var _ = Describe("Scanning the vendored packages", func() {
+	var root string
+
+	BeforeEach(func() {
+		root = GinkgoT().TempDir()
+		dep := filepath.Join(root, "vendor", "example.com", "dep")
+		Expect(os.MkdirAll(dep, 0o750)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o600)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dep, "dep.go"), []byte("package dep\n"), 0o600)).To(Succeed())
+	})
+
+	It("skips the vendor directories by default", func() {
+		excluded, err := loadExcludedDirs([]string{"vendor", ".git"}, "")
+		Expect(err).ShouldNot(HaveOccurred())
+		paths, err := packagePaths(root+"/...", excluded, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(paths).To(Equal([]string{root}))
+	})
+
+	It("lists the vendored packages without the vendor pattern", func() {
+		excluded, err := loadExcludedDirs(withoutPattern([]string{"vendor", ".git"}, "vendor"), "")
+		Expect(err).ShouldNot(HaveOccurred())
+		paths, err := packagePaths(root+"/...", excluded, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(paths).To(ConsistOf(root, filepath.Join(root, "vendor", "example.com", "dep")))
+	})
+
+	It("overrides the configuration of the scan with the vendor profile", func() {
+		config := gosec.NewConfig()
+		config.Set(gosec.DisabledRules, []string{"G101"})
+		config.Set("G104", map[string]interface{}{"io/ioutil": []string{"WriteFile"}})
+		definitions := rules.Generate(rules.NewRuleFilter(false, "G104", "G401", "G708"))
+		vendor, err := vendorConfig(config, rules.ProfileCritical, definitions)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(vendor.GetDisabledRules()).To(Equal([]string{"G104", "G401"}))
+		Expect(vendor["G104"]).To(Equal(config["G104"]))
+		Expect(config.GetDisabledRules()).To(Equal([]string{"G101"}))
+
+		_, err = vendorConfig(config, "paranoid", definitions)
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,97 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// pathMode sets how the files of the issues and of the errors are written in
+// the reports, whatever the format: relative to a base directory, with slashes
+// as separators, or absolute.
+type pathMode struct {
+	absolute bool
+	base     string
+}
+
+// newPathMode parses the mode of the paths, relative or absolute. The relative
+// paths are relative to base, or to the working directory when base is empty.
+func newPathMode(mode, base string) (*pathMode, error) {
+	switch mode {
+	case "absolute":
+		return &pathMode{absolute: true}, nil
+	case "relative":
+	default:
+		return nil, fmt.Errorf("unsupported path mode %q, valid options are: relative, absolute", mode)
+	}
+	if base == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		base = wd
+	}
+	abs, err := filepath.Abs(base)
+	if err != nil {
+		return nil, err
+	}
+	return &pathMode{base: abs}, nil
+}
+
+// path returns the path of the file in the reports. The files which are not
+// absolute, e.g. the package patterns of the loading errors, are left as they
+// are, and so are the files which cannot be made relative to the base.
+func (m *pathMode) path(file string) string {
+	if m == nil || m.absolute || !filepath.IsAbs(file) {
+		return file
+	}
+	rel, err := filepath.Rel(m.base, file)
+	if err != nil {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
+
+// issue returns the issue with its file written in the mode, the issue itself
+// being left unchanged for the other uses, such as the cache.
+func (m *pathMode) issue(issue *gosec.Issue) *gosec.Issue {
+	if m == nil {
+		return issue
+	}
+	reported := *issue
+	reported.File = m.path(issue.File)
+	return &reported
+}
+
+// apply returns the issues and the errors with their files written in the mode.
+func (m *pathMode) apply(issues []*gosec.Issue, errors map[string][]gosec.Error) ([]*gosec.Issue, map[string][]gosec.Error) {
+	if m == nil {
+		return issues, errors
+	}
+	reported := make([]*gosec.Issue, 0, len(issues))
+	for _, issue := range issues {
+		reported = append(reported, m.issue(issue))
+	}
+	reportedErrors := make(map[string][]gosec.Error, len(errors))
+	for file, errs := range errors {
+		path := m.path(file)
+		reportedErrors[path] = append(reportedErrors[path], errs...)
+	}
+	return reported, reportedErrors
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/cosmos/gosec/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Writing the paths of the files", func() {
+	var (
+		base  string
+		issue *gosec.Issue
+	)
+
+	BeforeEach(func() {
+		var err error
+		base, err = filepath.Abs(filepath.Join("testdata", "project"))
+		Expect(err).ShouldNot(HaveOccurred())
+		issue = &gosec.Issue{
+			Severity:   gosec.High,
+			Confidence: gosec.High,
+			RuleID:     "G101",
+			What:       "Potential hardcoded credentials",
+			File:       filepath.Join(base, "x", "bank", "keeper.go"),
+			Line:       "12",
+			Col:        "2",
+			Code:       "12: password := \"secret\"",
+		}
+	})
+
+	// reported returns the files of the issue in a report of each format
+	reported := func(paths *pathMode) map[string]string {
+		files := make(map[string]string)
+		issues, _ := paths.apply([]*gosec.Issue{issue}, nil)
+
+		buf := new(bytes.Buffer)
+		Expect(createReport(buf, "json", false, nil, issues, &gosec.Metrics{}, nil)).To(Succeed())
+		jsonReport := struct {
+			Issues []struct {
+				File string `json:"file"`
+			}
+		}{}
+		Expect(json.Unmarshal(buf.Bytes(), &jsonReport)).To(Succeed())
+		files["json"] = jsonReport.Issues[0].File
+
+		buf.Reset()
+		Expect(createReport(buf, "sarif", false, nil, issues, &gosec.Metrics{}, nil)).To(Succeed())
+		sarifReport := struct {
+			Runs []struct {
+				Results []struct {
+					Locations []struct {
+						PhysicalLocation struct {
+							ArtifactLocation struct {
+								URI string `json:"uri"`
+							} `json:"artifactLocation"`
+						} `json:"physicalLocation"`
+					} `json:"locations"`
+				} `json:"results"`
+			} `json:"runs"`
+		}{}
+		Expect(json.Unmarshal(buf.Bytes(), &sarifReport)).To(Succeed())
+		files["sarif"] = sarifReport.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI
+
+		buf.Reset()
+		Expect(createReport(buf, "github-actions", false, nil, issues, &gosec.Metrics{}, nil)).To(Succeed())
+		Expect(buf.String()).To(HavePrefix("::error file="))
+		files["github-actions"] = buf.String()[len("::error file="):bytes.IndexByte(buf.Bytes(), ',')]
+		return files
+	}
+
+	It("writes the files relative to the base directory in every format", func() {
+		paths, err := newPathMode("relative", base)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(reported(paths)).To(Equal(map[string]string{
+			"json":           "x/bank/keeper.go",
+			"sarif":          "x/bank/keeper.go",
+			"github-actions": "x/bank/keeper.go",
+		}))
+	})
+
+	It("writes the files relative to the working directory by default", func() {
+		paths, err := newPathMode("relative", "")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(reported(paths)).To(Equal(map[string]string{
+			"json":           "testdata/project/x/bank/keeper.go",
+			"sarif":          "testdata/project/x/bank/keeper.go",
+			"github-actions": "testdata/project/x/bank/keeper.go",
+		}))
+	})
+
+	It("writes the absolute files in every format", func() {
+		paths, err := newPathMode("absolute", base)
+		Expect(err).ShouldNot(HaveOccurred())
+		absolute := filepath.Join(base, "x", "bank", "keeper.go")
+		Expect(reported(paths)).To(Equal(map[string]string{
+			"json":           absolute,
+			"sarif":          absolute,
+			"github-actions": absolute,
+		}))
+	})
+
+	It("writes the files of the errors in the mode, leaving the other paths as they are", func() {
+		paths, err := newPathMode("relative", base)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, errors := paths.apply(nil, map[string][]gosec.Error{
+			filepath.Join(base, "x", "bank", "keeper.go"): {*gosec.NewError(1, 2, "syntax error")},
+			"./...": {*gosec.NewError(0, 0, "no packages")},
+		})
+		Expect(errors).To(HaveKey("x/bank/keeper.go"))
+		Expect(errors).To(HaveKey("./..."))
+	})
+
+	It("leaves the issues reported otherwise unchanged", func() {
+		paths, err := newPathMode("relative", base)
+		Expect(err).ShouldNot(HaveOccurred())
+		reported := paths.issue(issue)
+		Expect(reported.File).To(Equal("x/bank/keeper.go"))
+		Expect(issue.File).To(Equal(filepath.Join(base, "x", "bank", "keeper.go")))
+	})
+
+	It("rejects the other modes", func() {
+		_, err := newPathMode("canonical", "")
+		Expect(err).Should(HaveOccurred())
+	})
+
+})
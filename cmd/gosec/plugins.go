@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/cosmos/gosec/v2/rules"
+)
+
+// pluginSymbol is the symbol looked up in the plugins, a function returning
+// the definitions of their rules. The plugins return the definitions rather
+// than the rules, as the rules are created again with the configuration of
+// each package and by each worker.
+const pluginSymbol = "NewRules"
+
+// newPluginRules is the signature of the symbol of the plugins
+type newPluginRules = func() []rules.RuleDefinition
+
+// loadPlugins opens the plugins one after the other and returns the
+// definitions of their rules, which must not reuse the ID of another rule
+func loadPlugins(paths []string) ([]rules.RuleDefinition, error) {
+	defined := make(map[string]string)
+	for id := range rules.Generate() {
+		defined[id] = "gosec"
+	}
+
+	var definitions []rules.RuleDefinition
+	for _, path := range paths {
+		loaded, err := loadPlugin(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, def := range loaded {
+			if def.ID == "" || def.Create == nil {
+				return nil, fmt.Errorf("the plugin %s defines a rule without an ID or a builder", path)
+			}
+			if origin, ok := defined[def.ID]; ok {
+				return nil, fmt.Errorf("the rule %s of the plugin %s is already defined by %s", def.ID, path, origin)
+			}
+			defined[def.ID] = path
+			definitions = append(definitions, def)
+		}
+	}
+	return definitions, nil
+}
+
+func loadPlugin(path string) ([]rules.RuleDefinition, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening the plugin %s: %v", path, err)
+	}
+	symbol, err := p.Lookup(pluginSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("the plugin %s does not define the %s symbol", path, pluginSymbol)
+	}
+	switch newRules := symbol.(type) {
+	case newPluginRules:
+		return newRules(), nil
+	case *newPluginRules:
+		// The symbol is a variable holding the function.
+		if *newRules == nil {
+			return nil, fmt.Errorf("the %s symbol of the plugin %s is nil", pluginSymbol, path)
+		}
+		return (*newRules)(), nil
+	default:
+		return nil, fmt.Errorf("the %s symbol of the plugin %s is a %T, it must be a func() []rules.RuleDefinition", pluginSymbol, path, symbol)
+	}
+}
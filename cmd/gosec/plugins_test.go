@@ -0,0 +1,103 @@
+//go:build cgo && (linux || darwin || freebsd)
+// +build cgo
+// +build linux darwin freebsd
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Loading the rules of plugins", Ordered, func() {
+	var (
+		dir   string
+		built map[string]string
+	)
+
+	BeforeAll(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gosec-plugin")
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		built = make(map[string]string)
+	})
+
+	BeforeEach(func() {
+		// loadRules logs the selected rules
+		previous := logger
+		logger, _ = testutils.NewLogger()
+		DeferCleanup(func() { logger = previous })
+	})
+
+	// buildPlugin builds the plugin of the testdata directory once, as a
+	// plugin cannot be loaded again from another path. The plugins are built on
+	// demand since they must be built by the same toolchain as the test.
+	buildPlugin := func(name string) string {
+		if path, ok := built[name]; ok {
+			return path
+		}
+		path := filepath.Join(dir, name+".so")
+		// #nosec G204
+		cmd := exec.Command("go", "build", "-buildmode=plugin", "-o", path, "./testdata/plugin/"+name)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			Skip("building a plugin is not supported: " + string(output))
+		}
+		built[name] = path
+		return path
+	}
+
+	It("merges the rules of the plugin into the active rules", func() {
+		definitions, err := loadPlugins([]string{buildPlugin("valid")})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(definitions).To(HaveLen(1))
+		Expect(definitions[0].ID).To(Equal("X001"))
+
+		ruleList := loadRules("X001", "", nil, definitions)
+		Expect(ruleList).To(HaveLen(1))
+		analyzer := gosec.NewAnalyzer(gosec.NewConfig(), false, logger)
+		analyzer.LoadRules(ruleList.Builders())
+
+		pkg := testutils.NewTestPackage()
+		defer pkg.Close()
+		pkg.AddFile("keeper.go", `
+package keeper
+
+func Withdraw(balance, amount uint64) uint64 {
+	if amount > balance {
+		panic("insufficient funds")
+	}
+	return balance - amount
+}
+`)
+		Expect(pkg.Build()).To(Succeed())
+		Expect(analyzer.Process(nil, pkg.Path)).To(Succeed())
+		issues, _, _ := analyzer.Report()
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0].RuleID).To(Equal("X001"))
+	})
+
+	It("reports a plugin defining a rule twice", func() {
+		path := buildPlugin("valid")
+		_, err := loadPlugins([]string{path, path})
+		Expect(err).To(MatchError(ContainSubstring("the rule X001 of the plugin " + path + " is already defined")))
+	})
+
+	It("reports a symbol whose signature does not match", func() {
+		_, err := loadPlugins([]string{buildPlugin("mismatch")})
+		Expect(err).To(MatchError(ContainSubstring("it must be a func() []rules.RuleDefinition")))
+	})
+
+	It("reports a plugin which cannot be opened", func() {
+		_, err := loadPlugins([]string{filepath.Join(dir, "missing.so")})
+		Expect(err).To(MatchError(ContainSubstring("opening the plugin")))
+	})
+})
@@ -0,0 +1,98 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// progressInterval is the minimum time between two progress events, the event
+// of the end of the scan being always written.
+const progressInterval = time.Second
+
+// progressEvent is a line of the JSON progress stream, the issues found being
+// counted before the severity and confidence thresholds apply.
+type progressEvent struct {
+	Event        string    `json:"event"`
+	Time         time.Time `json:"time"`
+	FilesScanned int       `json:"files_scanned"`
+	CurrentPath  string    `json:"current_path,omitempty"`
+	IssuesFound  int       `json:"issues_found"`
+}
+
+// progressWriter writes the progress of the scan as newline delimited JSON
+// events, at most one per interval while the files are checked.
+type progressWriter struct {
+	mu       sync.Mutex
+	encoder  *json.Encoder
+	interval time.Duration
+	now      func() time.Time
+	last     time.Time
+	files    int
+	issues   int
+	path     string
+}
+
+// newProgressWriter creates a progress stream of the given format writing to w,
+// the only format being json.
+func newProgressWriter(w io.Writer, format string) (*progressWriter, error) {
+	if format != "json" {
+		return nil, fmt.Errorf("unsupported progress format %q, the only valid option is json", format)
+	}
+	return &progressWriter{
+		encoder:  json.NewEncoder(w),
+		interval: progressInterval,
+		now:      time.Now,
+	}, nil
+}
+
+// fileChecked counts a file checked with its issues, and writes a progress
+// event when the interval elapsed since the previous one.
+func (p *progressWriter) fileChecked(file string, numIssues int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.files++
+	p.issues += numIssues
+	p.path = file
+	if now := p.now(); now.Sub(p.last) >= p.interval {
+		p.last = now
+		p.write("progress", now)
+	}
+}
+
+// done writes the event of the end of the scan.
+func (p *progressWriter) done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.path = ""
+	p.write("done", p.now())
+}
+
+func (p *progressWriter) write(event string, now time.Time) {
+	err := p.encoder.Encode(progressEvent{
+		Event:        event,
+		Time:         now.UTC(),
+		FilesScanned: p.files,
+		CurrentPath:  p.path,
+		IssuesFound:  p.issues,
+	})
+	if err != nil {
+		logger.Printf("Failed to write the progress: %v", err)
+	}
+}
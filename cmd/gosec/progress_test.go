@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Writing the progress of the scan", func() {
+	var (
+		out      *bytes.Buffer
+		progress *progressWriter
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		var err error
+		progress, err = newProgressWriter(out, "json")
+		Expect(err).ShouldNot(HaveOccurred())
+		now = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+		progress.now = func() time.Time { return now }
+	})
+
+	// events decodes the lines written, failing on any line which is not a
+	// JSON object with exactly the fields of an event
+	events := func() []map[string]interface{} {
+		var decoded []map[string]interface{}
+		scanner := bufio.NewScanner(out)
+		for scanner.Scan() {
+			event := make(map[string]interface{})
+			Expect(json.Unmarshal(scanner.Bytes(), &event)).To(Succeed(), scanner.Text())
+			Expect(event).Should(HaveKey("event"))
+			Expect(event).Should(HaveKey("time"))
+			Expect(event).Should(HaveKey("files_scanned"))
+			Expect(event).Should(HaveKey("issues_found"))
+			decoded = append(decoded, event)
+		}
+		return decoded
+	}
+
+	It("writes well-formed JSON events, one per line", func() {
+		progress.fileChecked("/src/x/keeper/keeper.go", 2)
+		now = now.Add(2 * time.Second)
+		progress.fileChecked("/src/x/keeper/msgs.go", 1)
+		progress.done()
+
+		decoded := events()
+		Expect(decoded).Should(HaveLen(3))
+		Expect(decoded[0]).Should(Equal(map[string]interface{}{
+			"event":         "progress",
+			"time":          "2022-10-01T12:00:00Z",
+			"files_scanned": float64(1),
+			"current_path":  "/src/x/keeper/keeper.go",
+			"issues_found":  float64(2),
+		}))
+		Expect(decoded[1]["files_scanned"]).Should(Equal(float64(2)))
+		Expect(decoded[1]["current_path"]).Should(Equal("/src/x/keeper/msgs.go"))
+		Expect(decoded[1]["issues_found"]).Should(Equal(float64(3)))
+		Expect(decoded[2]).Should(Equal(map[string]interface{}{
+			"event":         "done",
+			"time":          "2022-10-01T12:00:02Z",
+			"files_scanned": float64(2),
+			"issues_found":  float64(3),
+		}))
+	})
+
+	It("writes at most one event per interval while the files are checked", func() {
+		for i := 0; i < 10; i++ {
+			progress.fileChecked("/src/x/keeper/keeper.go", 0)
+			now = now.Add(100 * time.Millisecond)
+		}
+		progress.done()
+
+		decoded := events()
+		Expect(decoded).Should(HaveLen(2))
+		Expect(decoded[0]["files_scanned"]).Should(Equal(float64(1)))
+		Expect(decoded[1]["event"]).Should(Equal("done"))
+		Expect(decoded[1]["files_scanned"]).Should(Equal(float64(10)))
+	})
+
+	It("rejects the other formats", func() {
+		_, err := newProgressWriter(out, "text")
+		Expect(err).Should(HaveOccurred())
+	})
+})
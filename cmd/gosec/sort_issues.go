@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -8,6 +9,41 @@ import (
 	"github.com/cosmos/gosec/v2"
 )
 
+// The orders of the issues supported by the -sort flag
+const (
+	sortBySeverity = "severity"
+	sortByFile     = "file"
+	sortByRule     = "rule"
+)
+
+// sortOrder is the value of the -sort flag. It still accepts the former boolean
+// values, true sorting by severity and false keeping the file order, and a bare
+// -sort sorts by severity. The orders are hence given as -sort=order.
+type sortOrder string
+
+func (s *sortOrder) String() string {
+	return string(*s)
+}
+
+func (s *sortOrder) Set(value string) error {
+	switch value {
+	case sortBySeverity, sortByFile, sortByRule:
+		*s = sortOrder(value)
+	case "true":
+		*s = sortBySeverity
+	case "false":
+		*s = sortByFile
+	default:
+		return fmt.Errorf("invalid sort order %q, valid options are: %s, %s, %s", value, sortBySeverity, sortByFile, sortByRule)
+	}
+	return nil
+}
+
+// IsBoolFlag lets the flag be given without a value, as the former boolean flag
+func (s *sortOrder) IsBoolFlag() bool {
+	return true
+}
+
 // handle ranges
 func extractLineNumber(s string) int {
 	lineNumber, err := strconv.Atoi(strings.Split(s, "-")[0])
@@ -19,28 +55,35 @@ func extractLineNumber(s string) int {
 
 }
 
-type sortBySeverity []*gosec.Issue
-
-func (s sortBySeverity) Len() int { return len(s) }
-
-func (s sortBySeverity) Less(i, j int) bool {
-	if s[i].Severity != s[j].Severity {
-		return s[i].Severity > s[j].Severity
+// lessByLocation orders the issues by file, line, column and rule
+func lessByLocation(a, b *gosec.Issue) bool {
+	if a.File != b.File {
+		return a.File < b.File
 	}
-
-	if s[i].What != s[j].What {
-		return s[i].What > s[j].What
+	if la, lb := extractLineNumber(a.Line), extractLineNumber(b.Line); la != lb {
+		return la < lb
 	}
-
-	if s[i].File == s[j].File {
-		return extractLineNumber(s[i].Line) > extractLineNumber(s[j].Line)
+	if ca, cb := extractLineNumber(a.Col), extractLineNumber(b.Col); ca != cb {
+		return ca < cb
 	}
-	return s[i].File > s[j].File
+	return a.RuleID < b.RuleID
 }
 
-func (s sortBySeverity) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-
-// sortIssues sorts the issues by severity in descending order
-func sortIssues(issues []*gosec.Issue) {
-	sort.Sort(sortBySeverity(issues))
+// sortIssues sorts the issues in the given order: by severity in descending
+// order, by file or by rule, the ties being sorted by file and line.
+func sortIssues(issues []*gosec.Issue, order sortOrder) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		switch order {
+		case sortBySeverity:
+			if a.Severity != b.Severity {
+				return a.Severity > b.Severity
+			}
+		case sortByRule:
+			if a.RuleID != b.RuleID {
+				return a.RuleID < b.RuleID
+			}
+		}
+		return lessByLocation(a, b)
+	})
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"testing"
 
 	"github.com/cosmos/gosec/v2"
@@ -32,45 +33,44 @@ func TestRules(t *testing.T) {
 func firstIsGreater(less, greater *gosec.Issue) {
 	slice := []*gosec.Issue{less, greater}
 
-	sortIssues(slice)
+	sortIssues(slice, sortBySeverity)
 
 	ExpectWithOffset(0, slice[0]).To(Equal(greater))
 }
 
+func newIssue(ruleID string, severity gosec.Score, file, line string) *gosec.Issue {
+	issue := createIssue()
+	issue.RuleID = ruleID
+	issue.Severity = severity
+	issue.File = file
+	issue.Line = line
+	return &issue
+}
+
 var _ = Describe("Sorting by Severity", func() {
 	It("sortes by severity", func() {
 		less := createIssue()
 		less.Severity = gosec.Low
 		greater := createIssue()
-		less.Severity = gosec.High
+		greater.Severity = gosec.High
 		firstIsGreater(&less, &greater)
 	})
 
 	Context("Serverity is same", func() {
-		It("sortes by What", func() {
-			less := createIssue()
-			less.What = "test1"
-			greater := createIssue()
-			greater.What = "test2"
-			firstIsGreater(&less, &greater)
-		})
-	})
-
-	Context("Serverity and What is same", func() {
 		It("sortes by File", func() {
 			less := createIssue()
-			less.File = "test1"
+			less.File = "test2"
 			greater := createIssue()
-			greater.File = "test2"
+			greater.File = "test1"
 
 			firstIsGreater(&less, &greater)
 		})
 	})
 
-	Context("Serverity, What and File is same", func() {
+	Context("Serverity and File is same", func() {
 		It("sortes by line number", func() {
 			less := createIssue()
-			less.Line = "1"
+			less.Line = "10-12"
 			greater := createIssue()
 			greater.Line = "2"
 
@@ -78,3 +78,60 @@ var _ = Describe("Sorting by Severity", func() {
 		})
 	})
 })
+
+var _ = Describe("Sorting in the requested order", func() {
+	var (
+		keeperG705  = newIssue("G705", gosec.Medium, "/src/keeper.go", "10")
+		keeperG101  = newIssue("G101", gosec.High, "/src/keeper.go", "2")
+		genesisG705 = newIssue("G705", gosec.High, "/src/genesis.go", "30")
+		genesisG404 = newIssue("G404", gosec.Low, "/src/genesis.go", "4")
+		mixed       = func() []*gosec.Issue {
+			return []*gosec.Issue{keeperG705, genesisG404, keeperG101, genesisG705}
+		}
+	)
+
+	It("sorts by severity, then by file and line", func() {
+		issues := mixed()
+		sortIssues(issues, sortBySeverity)
+		Expect(issues).To(Equal([]*gosec.Issue{genesisG705, keeperG101, keeperG705, genesisG404}))
+	})
+
+	It("sorts by file and line", func() {
+		issues := mixed()
+		sortIssues(issues, sortByFile)
+		Expect(issues).To(Equal([]*gosec.Issue{genesisG404, genesisG705, keeperG101, keeperG705}))
+	})
+
+	It("sorts by rule, then by file and line", func() {
+		issues := mixed()
+		sortIssues(issues, sortByRule)
+		Expect(issues).To(Equal([]*gosec.Issue{keeperG101, genesisG404, genesisG705, keeperG705}))
+	})
+
+	It("parses the orders of the flag", func() {
+		var order sortOrder
+		for value, expected := range map[string]sortOrder{
+			"severity": sortBySeverity,
+			"file":     sortByFile,
+			"rule":     sortByRule,
+			"true":     sortBySeverity,
+			"false":    sortByFile,
+		} {
+			Expect(order.Set(value)).To(Succeed())
+			Expect(order).To(Equal(expected))
+		}
+		Expect(order.Set("confidence")).ShouldNot(Succeed())
+	})
+
+	It("sorts by severity when the flag is given without a value", func() {
+		order := sortOrder(sortByFile)
+		flags := flag.NewFlagSet("gosec", flag.ContinueOnError)
+		flags.Var(&order, "sort", "")
+		Expect(flags.Parse([]string{"-sort", "./..."})).To(Succeed())
+		Expect(order).To(Equal(sortOrder(sortBySeverity)))
+		Expect(flags.Args()).To(Equal([]string{"./..."}))
+
+		Expect(flags.Parse([]string{"-sort=rule", "./..."})).To(Succeed())
+		Expect(order).To(Equal(sortOrder(sortByRule)))
+	})
+})
@@ -0,0 +1,12 @@
+// Package main is a plugin whose NewRules symbol has the wrong signature.
+package main
+
+import (
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/examples/customrule"
+)
+
+// NewRules returns the builders of the rules instead of their definitions.
+func NewRules() map[string]gosec.RuleBuilder {
+	return map[string]gosec.RuleBuilder{"X001": customrule.NewPanicCheck}
+}
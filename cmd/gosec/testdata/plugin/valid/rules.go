@@ -0,0 +1,14 @@
+// Package main is a plugin defining the rule of the customrule example.
+package main
+
+import (
+	"github.com/cosmos/gosec/v2/examples/customrule"
+	"github.com/cosmos/gosec/v2/rules"
+)
+
+// NewRules returns the definitions of the rules of the plugin.
+func NewRules() []rules.RuleDefinition {
+	return []rules.RuleDefinition{
+		{ID: "X001", Description: "Calls of panic", Create: customrule.NewPanicCheck},
+	}
+}
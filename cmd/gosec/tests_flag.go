@@ -0,0 +1,44 @@
+package main
+
+import "fmt"
+
+// The values of the -tests flag
+const (
+	testsSkip = "false"
+	testsScan = "true"
+	testsAll  = "all"
+)
+
+// testsMode is the value of the -tests flag. The flag alone scans the test
+// files, which the rules allowing the test files by design still leave out,
+// while -tests=all has these rules check them as well.
+type testsMode string
+
+func (t *testsMode) String() string {
+	return string(*t)
+}
+
+func (t *testsMode) Set(value string) error {
+	switch value {
+	case testsSkip, testsScan, testsAll:
+		*t = testsMode(value)
+	default:
+		return fmt.Errorf("invalid tests mode %q, valid options are: %s, %s, %s", value, testsSkip, testsScan, testsAll)
+	}
+	return nil
+}
+
+// IsBoolFlag lets the flag be given without a value
+func (t *testsMode) IsBoolFlag() bool {
+	return true
+}
+
+// scan returns true if the test files are scanned
+func (t testsMode) scan() bool {
+	return t == testsScan || t == testsAll
+}
+
+// checkAll returns true if the rules allowing the test files check them too
+func (t testsMode) checkAll() bool {
+	return t == testsAll
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests flag", func() {
+	It("parses the modes of the flag", func() {
+		for value, expected := range map[string]testsMode{
+			"false": testsSkip,
+			"true":  testsScan,
+			"all":   testsAll,
+		} {
+			mode := testsMode(testsSkip)
+			Expect(mode.Set(value)).To(Succeed())
+			Expect(mode).To(Equal(expected))
+		}
+		mode := testsMode(testsSkip)
+		Expect(mode.Set("none")).ShouldNot(Succeed())
+	})
+
+	It("scans the test files when given without a value", func() {
+		mode := testsMode(testsSkip)
+		flags := flag.NewFlagSet("gosec", flag.ContinueOnError)
+		flags.Var(&mode, "tests", "")
+		Expect(flags.Parse([]string{"-tests", "./..."})).To(Succeed())
+		Expect(mode.scan()).To(BeTrue())
+		Expect(mode.checkAll()).To(BeFalse())
+	})
+
+	It("checks the test files with all the rules when set to all", func() {
+		mode := testsMode(testsSkip)
+		flags := flag.NewFlagSet("gosec", flag.ContinueOnError)
+		flags.Var(&mode, "tests", "")
+		Expect(flags.Parse([]string{"-tests=all", "./..."})).To(Succeed())
+		Expect(mode.scan()).To(BeTrue())
+		Expect(mode.checkAll()).To(BeTrue())
+	})
+
+	It("skips the test files by default", func() {
+		mode := testsMode(testsSkip)
+		Expect(mode.scan()).To(BeFalse())
+		Expect(mode.checkAll()).To(BeFalse())
+	})
+})
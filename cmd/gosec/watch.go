@@ -0,0 +1,154 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDelay is how long the events must stop before the changed files are
+// re-scanned, a save usually notifying several events, e.g. an editor writing
+// a temporary file and renaming it over the saved one.
+const watchDelay = 300 * time.Millisecond
+
+// fileWatcher collects the changes of the Go files notified on events and,
+// once the events stop for delay, calls rescan with the packages holding them.
+type fileWatcher struct {
+	packages []string
+	events   <-chan fsnotify.Event
+	errors   <-chan error
+	delay    time.Duration
+	rescan   func(packages []string)
+}
+
+// isGoFileChange returns true if the event changes the contents of a Go file,
+// the Go files removed or renamed away changing their package as well.
+func isGoFileChange(event fsnotify.Event) bool {
+	if filepath.Ext(event.Name) != ".go" {
+		return false
+	}
+	return event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename) != 0
+}
+
+// run re-scans the changed files until stop is closed or the events end.
+func (w *fileWatcher) run(stop <-chan struct{}) {
+	changed := make(map[string]bool)
+	var timer *time.Timer
+	var fire <-chan time.Time
+	for {
+		select {
+		case event, ok := <-w.events:
+			if !ok {
+				return
+			}
+			if !isGoFileChange(event) {
+				continue
+			}
+			path, err := filepath.Abs(event.Name)
+			if err != nil {
+				logger.Printf("Ignoring the change of %s: %v", event.Name, err)
+				continue
+			}
+			changed[path] = true
+			if timer == nil {
+				timer = time.NewTimer(w.delay)
+			} else {
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(w.delay)
+			}
+			fire = timer.C
+		case err, ok := <-w.errors:
+			if !ok {
+				return
+			}
+			logger.Printf("Watching the files: %v", err)
+		case <-fire:
+			timer, fire = nil, nil
+			packages, err := changedPackages(w.packages, changed)
+			changed = make(map[string]bool)
+			if err != nil {
+				logger.Printf("Ignoring the changed files: %v", err)
+				continue
+			}
+			if len(packages) > 0 {
+				w.rescan(packages)
+			}
+		case <-stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+	}
+}
+
+// watchDirs watches the Go files of the directories of the packages, the
+// subdirectories being packages of their own.
+func watchDirs(packages []string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range packages {
+		dir, err := filepath.Abs(pkg)
+		if err == nil {
+			err = watcher.Add(dir)
+		}
+		if err != nil {
+			watcher.Close() // #nosec G104
+			return nil, fmt.Errorf("watching %s: %v", pkg, err)
+		}
+	}
+	return watcher, nil
+}
+
+// watchPackages calls scan with the packages of the Go files changed until
+// interrupted, it returns an error without scanning when the files cannot be
+// watched. The packages created after the start are not watched.
+func watchPackages(packages []string, scan func(packages []string)) error {
+	watcher, err := watchDirs(packages)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close() // #nosec G307
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	stop := make(chan struct{})
+	go func() {
+		<-interrupt
+		close(stop)
+	}()
+
+	logger.Printf("Watching the Go files of %d packages, interrupt to stop", len(packages))
+	w := &fileWatcher{
+		packages: packages,
+		events:   watcher.Events,
+		errors:   watcher.Errors,
+		delay:    watchDelay,
+		rescan:   scan,
+	}
+	w.run(stop)
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cosmos/gosec/v2/testutils"
+	"github.com/fsnotify/fsnotify"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watching the changed files", func() {
+	var (
+		dir      string
+		keeper   string
+		types    string
+		events   chan fsnotify.Event
+		errs     chan error
+		rescans  chan []string
+		stop     chan struct{}
+		finished chan struct{}
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gosec")
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		keeper = filepath.Join(dir, "keeper")
+		types = filepath.Join(dir, "types")
+		Expect(os.MkdirAll(keeper, 0o700)).To(Succeed())
+		Expect(os.MkdirAll(types, 0o700)).To(Succeed())
+
+		previous := logger
+		logger, _ = testutils.NewLogger()
+		DeferCleanup(func() { logger = previous })
+
+		events = make(chan fsnotify.Event)
+		errs = make(chan error)
+		rescans = make(chan []string, 10)
+		stop = make(chan struct{})
+		finished = make(chan struct{})
+		w := &fileWatcher{
+			packages: []string{keeper, types},
+			events:   events,
+			errors:   errs,
+			delay:    50 * time.Millisecond,
+			rescan: func(packages []string) {
+				rescans <- packages
+			},
+		}
+		go func() {
+			defer close(finished)
+			w.run(stop)
+		}()
+		DeferCleanup(func() {
+			close(stop)
+			Eventually(finished).Should(BeClosed())
+		})
+	})
+
+	It("re-scans the package of a saved Go file once the events stop", func() {
+		events <- fsnotify.Event{Name: filepath.Join(keeper, "keeper.go"), Op: fsnotify.Create}
+		events <- fsnotify.Event{Name: filepath.Join(keeper, "keeper.go"), Op: fsnotify.Write}
+		events <- fsnotify.Event{Name: filepath.Join(keeper, "keeper.go"), Op: fsnotify.Write}
+		Eventually(rescans).Should(Receive(Equal([]string{keeper})))
+		Consistently(rescans, 200*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("re-scans the packages of the Go files changed together", func() {
+		events <- fsnotify.Event{Name: filepath.Join(types, "types.go"), Op: fsnotify.Write}
+		events <- fsnotify.Event{Name: filepath.Join(keeper, "msgs.go"), Op: fsnotify.Remove}
+		Eventually(rescans).Should(Receive(Equal([]string{keeper, types})))
+	})
+
+	It("re-scans again on the later changes", func() {
+		events <- fsnotify.Event{Name: filepath.Join(keeper, "keeper.go"), Op: fsnotify.Write}
+		Eventually(rescans).Should(Receive(Equal([]string{keeper})))
+		events <- fsnotify.Event{Name: filepath.Join(types, "types.go"), Op: fsnotify.Rename}
+		Eventually(rescans).Should(Receive(Equal([]string{types})))
+	})
+
+	It("ignores the other files and the changes of the permissions", func() {
+		events <- fsnotify.Event{Name: filepath.Join(keeper, "README.md"), Op: fsnotify.Write}
+		events <- fsnotify.Event{Name: filepath.Join(keeper, "keeper.go"), Op: fsnotify.Chmod}
+		events <- fsnotify.Event{Name: filepath.Join(dir, "other", "other.go"), Op: fsnotify.Write}
+		Consistently(rescans, 200*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("keeps watching after an error", func() {
+		errs <- errors.New("queue overflow")
+		events <- fsnotify.Event{Name: filepath.Join(keeper, "keeper.go"), Op: fsnotify.Write}
+		Eventually(rescans).Should(Receive(Equal([]string{keeper})))
+	})
+})
@@ -22,9 +22,16 @@ import (
 	"go/parser"
 	"go/token"
 	"go/types"
+	"io"
+	"io/ioutil"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
+	"text/tabwriter"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/rules"
 )
 
 type command func(args ...string)
@@ -41,8 +48,10 @@ func newUtils() *utilities {
 	utils["uses"] = dumpUses
 	utils["types"] = dumpTypes
 	utils["defs"] = dumpDefs
+	utils["selections"] = dumpSelections
 	utils["comments"] = dumpComments
 	utils["imports"] = dumpImports
+	utils["rules"] = dumpRules
 	return &utilities{utils, make([]string, 0)}
 }
 
@@ -72,7 +81,38 @@ func (u *utilities) run(args ...string) {
 	}
 }
 
+// stdinPath is the file argument which reads the source from the standard input.
+const stdinPath = "-"
+
+// stdinFilename is the synthetic filename of the source read from the standard
+// input, so that the reported positions remain meaningful.
+const stdinFilename = "stdin.go"
+
+var (
+	stdin       io.Reader = os.Stdin
+	stdinSource []byte
+)
+
+// parseFile parses the file at path, or the standard input when path is "-".
+// The standard input is read once so that it can be parsed by several tools.
+func parseFile(fileset *token.FileSet, path string, mode parser.Mode) (*ast.File, error) {
+	if path != stdinPath {
+		return parser.ParseFile(fileset, path, nil, mode)
+	}
+	if stdinSource == nil {
+		src, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return nil, err
+		}
+		stdinSource = src
+	}
+	return parser.ParseFile(fileset, stdinFilename, stdinSource, mode)
+}
+
 func shouldSkip(path string) bool {
+	if path == stdinPath {
+		return false
+	}
 	st, e := os.Stat(path)
 	if e != nil {
 		// #nosec
@@ -96,7 +136,7 @@ func dumpAst(files ...string) {
 
 		// Create the AST by parsing src.
 		fset := token.NewFileSet() // positions are relative to fset
-		f, err := parser.ParseFile(fset, arg, nil, 0)
+		f, err := parseFile(fset, arg, 0)
 		if err != nil {
 			// #nosec
 			fmt.Fprintf(os.Stderr, "Unable to parse file %s\n", err)
@@ -119,7 +159,7 @@ type context struct {
 
 func createContext(filename string) *context {
 	fileset := token.NewFileSet()
-	root, e := parser.ParseFile(fileset, filename, nil, parser.ParseComments)
+	root, e := parseFile(fileset, filename, parser.ParseComments)
 	if e != nil {
 		// #nosec
 		fmt.Fprintf(os.Stderr, "Unable to parse file: %s. Reason: %s\n", filename, e)
@@ -258,6 +298,41 @@ func dumpDefs(files ...string) {
 	}
 }
 
+func selectionKind(kind types.SelectionKind) string {
+	switch kind {
+	case types.FieldVal:
+		return "field"
+	case types.MethodVal:
+		return "method value"
+	case types.MethodExpr:
+		return "method expr"
+	default:
+		return "unknown"
+	}
+}
+
+func dumpSelections(files ...string) {
+	for _, file := range files {
+		if shouldSkip(file) {
+			continue
+		}
+		context := createContext(file)
+		if !checkContext(context, file) {
+			return
+		}
+		selectors := make([]*ast.SelectorExpr, 0, len(context.info.Selections))
+		for selector := range context.info.Selections {
+			selectors = append(selectors, selector)
+		}
+		sort.Slice(selectors, func(i, j int) bool { return selectors[i].Pos() < selectors[j].Pos() })
+		for _, selector := range selectors {
+			selection := context.info.Selections[selector]
+			fmt.Printf("SELECTOR: %s, KIND: %s, RECV: %v, OBJECT: %v\n",
+				types.ExprString(selector), selectionKind(selection.Kind()), selection.Recv(), selection.Obj())
+		}
+	}
+}
+
 func dumpComments(files ...string) {
 	for _, file := range files {
 		if shouldSkip(file) {
@@ -267,7 +342,9 @@ func dumpComments(files ...string) {
 		if !checkContext(context, file) {
 			return
 		}
-		for _, group := range context.comments.Comments() {
+		groups := context.comments.Comments()
+		sort.Slice(groups, func(i, j int) bool { return groups[i].Pos() < groups[j].Pos() })
+		for _, group := range groups {
 			fmt.Println(group.Text())
 		}
 	}
@@ -291,6 +368,54 @@ func dumpImports(files ...string) {
 	}
 }
 
+// dumpRules prints, for every registered rule, the AST node types the rule
+// subscribes to and whether the file contains any node of those types.
+func dumpRules(files ...string) {
+	for _, file := range files {
+		if shouldSkip(file) {
+			continue
+		}
+		fileset := token.NewFileSet()
+		root, err := parseFile(fileset, file, parser.ParseComments)
+		if err != nil {
+			// #nosec
+			fmt.Fprintf(os.Stderr, "Unable to parse file %s\n", err)
+			continue
+		}
+
+		present := make(map[reflect.Type]bool)
+		ast.Inspect(root, func(n ast.Node) bool {
+			if n != nil {
+				present[reflect.TypeOf(n)] = true
+			}
+			return true
+		})
+
+		ruleList := rules.Generate()
+		ids := make([]string, 0, len(ruleList))
+		for id := range ruleList {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		fmt.Println(file)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RULE\tNODES\tMATCHES")
+		for _, id := range ids {
+			_, nodes := ruleList[id].Create(id, gosec.NewConfig())
+			names := make([]string, 0, len(nodes))
+			matches := false
+			for _, node := range nodes {
+				typ := reflect.TypeOf(node)
+				names = append(names, typ.String())
+				matches = matches || present[typ]
+			}
+			fmt.Fprintf(w, "%s\t%s\t%t\n", id, strings.Join(names, ", "), matches)
+		}
+		w.Flush()
+	}
+}
+
 func main() {
 	tools := newUtils()
 	flag.Var(tools, "tool", "Utils to assist with rule development")
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTools(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gosecutil Suite")
+}
+
+// captureStdout returns what fn writes to the standard output.
+func captureStdout(fn func()) string {
+	r, w, err := os.Pipe()
+	Expect(err).ShouldNot(HaveOccurred())
+	original := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = original
+	Expect(w.Close()).ShouldNot(HaveOccurred())
+	out, err := ioutil.ReadAll(r)
+	Expect(err).ShouldNot(HaveOccurred())
+	return string(out)
+}
+
+var _ = Describe("Reading the source from stdin", func() {
+	BeforeEach(func() {
+		stdin = strings.NewReader("package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n")
+		stdinSource = nil
+	})
+
+	AfterEach(func() {
+		stdin = os.Stdin
+		stdinSource = nil
+	})
+
+	It("should dump the AST of the source", func() {
+		out := captureStdout(func() { dumpAst("-") })
+		Expect(out).Should(ContainSubstring(`Name: "main"`))
+		Expect(out).Should(ContainSubstring(`Value: "\"hello\""`))
+		Expect(out).Should(ContainSubstring(stdinFilename + ":4:"))
+	})
+
+	It("should read the source once for several tools", func() {
+		out := captureStdout(func() {
+			dumpAst("-")
+			dumpUses("-")
+		})
+		Expect(out).Should(ContainSubstring(`Name: "main"`))
+		Expect(out).Should(ContainSubstring("IDENT: println"))
+	})
+})
+
+var _ = Describe("Dumping the selections", func() {
+	AfterEach(func() {
+		stdin = os.Stdin
+		stdinSource = nil
+	})
+
+	It("should print the kind, receiver and object of the selectors", func() {
+		stdin = strings.NewReader(`package main
+
+type counter struct {
+	n int
+}
+
+func (c *counter) inc() {
+	c.n++
+}
+
+func main() {
+	c := &counter{}
+	c.inc()
+	println(c.n)
+}
+`)
+		out := captureStdout(func() { dumpSelections("-") })
+		Expect(out).Should(Equal(
+			"SELECTOR: c.n, KIND: field, RECV: *main.go.counter, OBJECT: field n int\n" +
+				"SELECTOR: c.inc, KIND: method value, RECV: *main.go.counter, OBJECT: func (*main.go.counter).inc()\n" +
+				"SELECTOR: c.n, KIND: field, RECV: *main.go.counter, OBJECT: field n int\n"))
+	})
+
+	It("should print the selectors whose receiver is not an identifier", func() {
+		stdin = strings.NewReader(`package main
+
+type counter struct {
+	n int
+}
+
+func (c *counter) inc() {
+	c.n++
+}
+
+func newCounter() *counter {
+	return &counter{}
+}
+
+func main() {
+	inc := (*counter).inc
+	inc(newCounter())
+	println(newCounter().n)
+}
+`)
+		out := captureStdout(func() { dumpSelections("-") })
+		Expect(out).Should(Equal(
+			"SELECTOR: c.n, KIND: field, RECV: *main.go.counter, OBJECT: field n int\n" +
+				"SELECTOR: (*counter).inc, KIND: method expr, RECV: *main.go.counter, OBJECT: func (*main.go.counter).inc()\n" +
+				"SELECTOR: newCounter().n, KIND: field, RECV: *main.go.counter, OBJECT: field n int\n"))
+	})
+})
+
+var _ = Describe("Dumping the comments", func() {
+	const source = `// Package main is a sample.
+package main
+
+// counter counts.
+type counter struct {
+	n int // the count
+}
+
+// inc increments the count.
+func (c *counter) inc() {
+	// no overflow check
+	c.n++
+}
+
+func main() {
+	c := &counter{}
+	c.inc() // once
+	println(c.n)
+}
+`
+
+	AfterEach(func() {
+		stdin = os.Stdin
+		stdinSource = nil
+	})
+
+	It("should print the comments in the order of the source", func() {
+		dump := func() string {
+			stdin = strings.NewReader(source)
+			stdinSource = nil
+			return captureStdout(func() { dumpComments("-") })
+		}
+		out := dump()
+		Expect(out).Should(Equal(
+			"Package main is a sample.\n\n" +
+				"counter counts.\n\n" +
+				"the count\n\n" +
+				"inc increments the count.\n\n" +
+				"no overflow check\n\n" +
+				"once\n\n"))
+		for i := 0; i < 10; i++ {
+			Expect(dump()).Should(Equal(out))
+		}
+	})
+})
@@ -7,12 +7,32 @@ import (
 	"io"
 	"io/ioutil"
 	"sort"
+
+	"gopkg.in/yaml.v2"
 )
 
 const (
 	// Globals are applicable to all rules and used for general
 	// configuration settings for gosec.
 	Globals = "global"
+
+	// RequiredRules is the configuration section listing the IDs of the
+	// rules which must be enabled for a scan to run.
+	RequiredRules = "required_rules"
+
+	// DisabledRules is the configuration section listing the IDs of the
+	// rules which are skipped. They are still loaded, and run on the nodes
+	// annotated with a #gosec:enable directive. The configuration of a
+	// directory overrides the list for its packages.
+	DisabledRules = "disabled_rules"
+
+	// EnabledRules is the configuration section listing the IDs of the
+	// opt-in rules which run without being included explicitly.
+	EnabledRules = "enabled_rules"
+
+	// RuleSeverity is the setting of a rule overriding the severity of its
+	// issues, e.g. {"G705": {"severity": "low"}}.
+	RuleSeverity = "severity"
 )
 
 // GlobalOption defines the name of the global options
@@ -25,6 +45,12 @@ const (
 	Audit GlobalOption = "audit"
 	// NoSecAlternative global option alternative for #nosec directive
 	NoSecAlternative GlobalOption = "#nosec"
+	// SnippetContext global option for the number of lines captured before
+	// and after the code snippet of an issue
+	SnippetContext GlobalOption = "context"
+	// StrictNosec global option which requires a justification after each
+	// #nosec directive
+	StrictNosec GlobalOption = "strict-nosec"
 )
 
 // Config is used to provide configuration and customization to each of the rules.
@@ -62,19 +88,105 @@ func (c Config) convertGlobals() {
 
 // ReadFrom implements the io.ReaderFrom interface. This
 // should be used with io.Reader to load configuration from
-// file or from string etc.
+// file or from string etc. The configuration read is merged
+// into the current one, see Merge, so that several files can
+// be read one after the other.
 func (c Config) ReadFrom(r io.Reader) (int64, error) {
 	data, err := ioutil.ReadAll(r)
 	if err != nil {
 		return int64(len(data)), err
 	}
-	if err = json.Unmarshal(data, &c); err != nil {
+	read := make(Config)
+	if err = json.Unmarshal(data, &read); err != nil {
 		return int64(len(data)), err
 	}
-	c.convertGlobals()
+	read.convertGlobals()
+	c.Merge(read)
 	return int64(len(data)), nil
 }
 
+// ReadYAMLFrom reads a configuration in the YAML format, with the same
+// sections and settings as the JSON one, and merges it into the current one
+// like ReadFrom.
+func (c Config) ReadYAMLFrom(r io.Reader) (int64, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return int64(len(data)), err
+	}
+	var read interface{}
+	if err = yaml.Unmarshal(data, &read); err != nil {
+		return int64(len(data)), err
+	}
+	// The settings are converted to the values decoded from JSON, e.g. the
+	// numbers to float64, which the rules expect.
+	content, err := json.Marshal(jsonValue(read))
+	if err != nil {
+		return int64(len(data)), err
+	}
+	if _, err = c.ReadFrom(bytes.NewReader(content)); err != nil {
+		return int64(len(data)), err
+	}
+	return int64(len(data)), nil
+}
+
+// jsonValue converts the maps decoded from YAML, whose keys are interfaces,
+// to maps which can be encoded to JSON.
+func jsonValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(value))
+		for key, v := range value {
+			converted[fmt.Sprintf("%v", key)] = jsonValue(v)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(value))
+		for i, v := range value {
+			converted[i] = jsonValue(v)
+		}
+		return converted
+	}
+	return value
+}
+
+// Merge merges the other configuration into this one, the settings of other
+// taking precedence. The maps, such as the global options and the settings
+// of the rules, are merged key by key, while the other values, including the
+// lists, are replaced.
+func (c Config) Merge(other Config) {
+	for section, value := range other {
+		c[section] = mergeSettings(c[section], value)
+	}
+}
+
+func mergeSettings(base, override interface{}) interface{} {
+	switch override := override.(type) {
+	case map[string]interface{}:
+		if base, ok := base.(map[string]interface{}); ok {
+			merged := make(map[string]interface{}, len(base)+len(override))
+			for key, value := range base {
+				merged[key] = value
+			}
+			for key, value := range override {
+				merged[key] = mergeSettings(base[key], value)
+			}
+			return merged
+		}
+	case map[GlobalOption]string:
+		if base, ok := base.(map[GlobalOption]string); ok {
+			merged := make(map[GlobalOption]string, len(base)+len(override))
+			for option, value := range base {
+				merged[option] = value
+			}
+			for option, value := range override {
+				merged[option] = value
+			}
+			return merged
+		}
+	}
+	return override
+}
+
 // WriteTo implements the io.WriteTo interface. This should
 // be used to save or print out the configuration information.
 func (c Config) WriteTo(w io.Writer) (int64, error) {
@@ -99,6 +211,60 @@ func (c Config) Set(section string, value interface{}) {
 	c[section] = value
 }
 
+// GetRequiredRules returns the IDs of the rules which are required to be enabled
+func (c Config) GetRequiredRules() []string {
+	return c.getStrings(RequiredRules)
+}
+
+// GetDisabledRules returns the IDs of the rules which are disabled
+func (c Config) GetDisabledRules() []string {
+	return c.getStrings(DisabledRules)
+}
+
+// GetEnabledRules returns the IDs of the opt-in rules which are enabled
+func (c Config) GetEnabledRules() []string {
+	return c.getStrings(EnabledRules)
+}
+
+// GetRuleSeverity returns the severity of the issues of the rule set in its
+// settings, false when it is not set
+func (c Config) GetRuleSeverity(ruleID string) (Score, bool, error) {
+	settings, ok := c[ruleID].(map[string]interface{})
+	if !ok {
+		return Low, false, nil
+	}
+	value, ok := settings[RuleSeverity]
+	if !ok {
+		return Low, false, nil
+	}
+	name, ok := value.(string)
+	if !ok {
+		return Low, false, fmt.Errorf("invalid severity %v of the rule %s", value, ruleID)
+	}
+	severity, err := ParseScore(name)
+	if err != nil {
+		return Low, false, fmt.Errorf("invalid severity of the rule %s: %v", ruleID, err)
+	}
+	return severity, true, nil
+}
+
+// getStrings returns the list of strings configured in the section, which is
+// a []interface{} when it is read from a file
+func (c Config) getStrings(section string) []string {
+	var values []string
+	switch list := c[section].(type) {
+	case []string:
+		values = append(values, list...)
+	case []interface{}:
+		for _, value := range list {
+			if value, ok := value.(string); ok {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
 // GetGlobal returns value associated with global configuration option
 func (c Config) GetGlobal(option GlobalOption) (string, error) {
 	if globals, ok := c[Globals]; ok {
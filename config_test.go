@@ -38,6 +38,87 @@ var _ = Describe("Configuration", func() {
 
 	})
 
+	Context("when loading from a YAML file", func() {
+		It("should read the same settings as from a JSON file", func() {
+			yaml := `
+global:
+  nosec: enabled
+G101:
+  pattern: (?i)secret
+  entropy: 80
+disabled_rules: [G710, G722]
+`
+			_, err := configuration.ReadYAMLFrom(strings.NewReader(yaml))
+			Expect(err).ShouldNot(HaveOccurred())
+			nosec, err := configuration.IsGlobalEnabled(gosec.Nosec)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(nosec).Should(BeTrue())
+			Expect(configuration.Get("G101")).Should(HaveKeyWithValue("pattern", "(?i)secret"))
+			Expect(configuration.Get("G101")).Should(HaveKeyWithValue("entropy", float64(80)))
+			Expect(configuration.GetDisabledRules()).Should(Equal([]string{"G710", "G722"}))
+		})
+
+		It("should accept an empty file", func() {
+			_, err := configuration.ReadYAMLFrom(strings.NewReader(""))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(configuration.GetDisabledRules()).Should(BeEmpty())
+		})
+
+		It("should return an error if the file is invalid", func() {
+			_, err := configuration.ReadYAMLFrom(strings.NewReader("disabled_rules: [G710"))
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("when merging several configurations", func() {
+		base := `{
+			"global": {"nosec": "enabled", "audit": "enabled"},
+			"G701": {"severity": "low", "packages": ["types"]},
+			"disabled_rules": ["G710", "G722"]
+		}`
+		repo := `{
+			"global": {"audit": "disabled"},
+			"G701": {"severity": "high"},
+			"G101": {"pattern": "(?i)secret"}
+		}`
+
+		It("should override the settings of the base with the later ones", func() {
+			_, err := configuration.ReadFrom(strings.NewReader(base))
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = configuration.ReadFrom(strings.NewReader(repo))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			settings, err := configuration.Get("G701")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(settings).Should(HaveKeyWithValue("severity", "high"))
+			audit, err := configuration.IsGlobalEnabled(gosec.Audit)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(audit).Should(BeFalse())
+		})
+
+		It("should inherit the settings the later ones do not mention", func() {
+			_, err := configuration.ReadFrom(strings.NewReader(base))
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = configuration.ReadFrom(strings.NewReader(repo))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			settings, err := configuration.Get("G701")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(settings).Should(HaveKeyWithValue("packages", []interface{}{"types"}))
+			nosec, err := configuration.IsGlobalEnabled(gosec.Nosec)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(nosec).Should(BeTrue())
+			Expect(configuration.GetDisabledRules()).Should(Equal([]string{"G710", "G722"}))
+			Expect(configuration.Get("G101")).Should(HaveKeyWithValue("pattern", "(?i)secret"))
+		})
+
+		It("should replace the lists instead of merging them", func() {
+			base := gosec.Config{"disabled_rules": []interface{}{"G710", "G722"}}
+			base.Merge(gosec.Config{"disabled_rules": []interface{}{"G705"}})
+			Expect(base.GetDisabledRules()).Should(Equal([]string{"G705"}))
+		})
+	})
+
 	Context("when saving to disk", func() {
 		It("should be possible to save an empty configuration to file", func() {
 			expected := `{"global":{}}`
@@ -78,6 +159,87 @@ var _ = Describe("Configuration", func() {
 		})
 	})
 
+	Context("when configuring required rules", func() {
+		It("should parse the required rules from file", func() {
+			config := `
+			{
+				"required_rules": ["G702", "G705"]
+			}`
+			cfg := gosec.NewConfig()
+			_, err := cfg.ReadFrom(strings.NewReader(config))
+			Expect(err).Should(BeNil())
+			Expect(cfg.GetRequiredRules()).Should(Equal([]string{"G702", "G705"}))
+		})
+
+		It("should return no required rules by default", func() {
+			Expect(configuration.GetRequiredRules()).Should(BeEmpty())
+		})
+	})
+
+	Context("when configuring disabled rules", func() {
+		It("should parse the disabled rules from file", func() {
+			config := `
+			{
+				"disabled_rules": ["G101", "G702"]
+			}`
+			cfg := gosec.NewConfig()
+			_, err := cfg.ReadFrom(strings.NewReader(config))
+			Expect(err).Should(BeNil())
+			Expect(cfg.GetDisabledRules()).Should(Equal([]string{"G101", "G702"}))
+		})
+
+		It("should return no disabled rules by default", func() {
+			Expect(configuration.GetDisabledRules()).Should(BeEmpty())
+		})
+	})
+
+	Context("when configuring enabled rules", func() {
+		It("should parse the enabled rules from file", func() {
+			config := `
+			{
+				"enabled_rules": ["G710", "G722"]
+			}`
+			cfg := gosec.NewConfig()
+			_, err := cfg.ReadFrom(strings.NewReader(config))
+			Expect(err).Should(BeNil())
+			Expect(cfg.GetEnabledRules()).Should(Equal([]string{"G710", "G722"}))
+		})
+
+		It("should return no enabled rules by default", func() {
+			Expect(configuration.GetEnabledRules()).Should(BeEmpty())
+		})
+	})
+
+	Context("when configuring the severity of a rule", func() {
+		It("should parse the severity from file", func() {
+			config := `
+			{
+				"G701": {"severity": "High"}
+			}`
+			cfg := gosec.NewConfig()
+			_, err := cfg.ReadFrom(strings.NewReader(config))
+			Expect(err).Should(BeNil())
+			severity, ok, err := cfg.GetRuleSeverity("G701")
+			Expect(err).Should(BeNil())
+			Expect(ok).Should(BeTrue())
+			Expect(severity).Should(Equal(gosec.High))
+		})
+
+		It("should return no severity by default", func() {
+			configuration.Set("G701", map[string]interface{}{"packages": []string{"types"}})
+			_, ok, err := configuration.GetRuleSeverity("G701")
+			Expect(err).Should(BeNil())
+			Expect(ok).Should(BeFalse())
+		})
+
+		It("should fail on an invalid severity", func() {
+			configuration.Set("G701", map[string]interface{}{"severity": "critical"})
+			_, ok, err := configuration.GetRuleSeverity("G701")
+			Expect(err).Should(HaveOccurred())
+			Expect(ok).Should(BeFalse())
+		})
+	})
+
 	Context("when using global configuration options", func() {
 		It("should have a default global section", func() {
 			settings, err := configuration.Get("global")
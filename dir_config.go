@@ -0,0 +1,122 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gosec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DirConfigName is the name of the configuration files discovered in the
+// directories of the scanned packages and in their parent directories
+const DirConfigName = ".gosec.yaml"
+
+// DirConfigs discovers the configuration files of the scanned directories, so
+// that a subtree can have its own policy. The settings of a package are, from
+// the lowest to the highest precedence: the base configuration of the scan,
+// the files found walking up from the directory of the package, the nearer
+// ones overriding the farther ones, and the overrides, e.g. the command line
+// flags. The files found are cached per directory.
+type DirConfigs struct {
+	overrides Config
+
+	mu   sync.Mutex
+	dirs map[string]dirConfig
+}
+
+// dirConfig holds the settings of the files found from the root down to a
+// directory, config is nil when there is none
+type dirConfig struct {
+	config Config
+	err    error
+}
+
+// NewDirConfigs creates the discovery of the configuration files, the
+// overrides take precedence over the settings of the files
+func NewDirConfigs(overrides Config) *DirConfigs {
+	return &DirConfigs{
+		overrides: overrides,
+		dirs:      make(map[string]dirConfig),
+	}
+}
+
+// Resolve returns the configuration of the packages of dir, or nil when no
+// configuration file applies to dir and they use base as it is
+func (d *DirConfigs) Resolve(base Config, dir string) (Config, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	found, err := d.lookup(abs)
+	d.mu.Unlock()
+	if err != nil || found == nil {
+		return nil, err
+	}
+	resolved := make(Config)
+	resolved.Merge(base)
+	resolved.Merge(found)
+	resolved.Merge(d.overrides)
+	return resolved, nil
+}
+
+// lookup returns the settings of the files found in dir and its parent
+// directories
+func (d *DirConfigs) lookup(dir string) (Config, error) {
+	if cached, ok := d.dirs[dir]; ok {
+		return cached.config, cached.err
+	}
+
+	var inherited Config
+	if parent := filepath.Dir(dir); parent != dir {
+		var err error
+		if inherited, err = d.lookup(parent); err != nil {
+			d.dirs[dir] = dirConfig{err: err}
+			return nil, err
+		}
+	}
+
+	config, err := readDirConfig(filepath.Join(dir, DirConfigName))
+	if err != nil || config == nil {
+		d.dirs[dir] = dirConfig{config: inherited, err: err}
+		return inherited, err
+	}
+	merged := make(Config)
+	merged.Merge(inherited)
+	merged.Merge(config)
+	d.dirs[dir] = dirConfig{config: merged}
+	return merged, nil
+}
+
+// readDirConfig reads a configuration file, it returns nil when the file does
+// not exist
+func readDirConfig(path string) (Config, error) {
+	// #nosec
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() // #nosec G307
+	config := make(Config)
+	if _, err := config.ReadYAMLFrom(file); err != nil {
+		return nil, fmt.Errorf("reading the config file %s: %v", path, err)
+	}
+	return config, nil
+}
@@ -0,0 +1,13 @@
+package customrule_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCustomRule(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Custom Rule Suite")
+}
@@ -0,0 +1,63 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package customrule is an example of a rule defined outside of gosec. It only
+// uses the exported API of the gosec package, so it can live in another module
+// and be loaded into the analyzer through its RuleBuilder:
+//
+//	analyzer := gosec.NewAnalyzer(gosec.NewConfig(), false, logger)
+//	analyzer.LoadRules(map[string]gosec.RuleBuilder{"X001": customrule.NewPanicCheck})
+package customrule
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// panicCall flags the calls of the panic builtin, which abort the processing
+// of a block instead of returning an error.
+type panicCall struct {
+	gosec.MetaData
+}
+
+func (r *panicCall) ID() string {
+	return r.MetaData.ID
+}
+
+func (r *panicCall) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || ctx.SkipTestFile() {
+		return nil, nil
+	}
+	fn, ok := call.Fun.(*ast.Ident)
+	if !ok || ctx.Info.Uses[fn] != types.Universe.Lookup("panic") {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, call, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewPanicCheck is the gosec.RuleBuilder of the rule, it creates the rule with
+// the given ID and returns the AST nodes the rule is matched against.
+func NewPanicCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &panicCall{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.High,
+			What:       "Call of panic, return an error instead",
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
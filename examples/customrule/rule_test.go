@@ -0,0 +1,71 @@
+package customrule_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/examples/customrule"
+	"github.com/cosmos/gosec/v2/rules"
+	"github.com/cosmos/gosec/v2/testutils"
+)
+
+const source = `
+package keeper
+
+import "errors"
+
+func MustPositive(amount int64) int64 {
+	if amount < 0 {
+		panic("negative amount")
+	}
+	return amount
+}
+
+func Positive(amount int64) (int64, error) {
+	if amount < 0 {
+		return 0, errors.New("negative amount")
+	}
+	return amount, nil
+}
+`
+
+var _ = Describe("Custom rule", func() {
+	var pkg *testutils.TestPackage
+
+	BeforeEach(func() {
+		pkg = testutils.NewTestPackage()
+		pkg.AddFile("keeper.go", source)
+		Expect(pkg.Build()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		pkg.Close()
+	})
+
+	It("runs when loaded through its builder", func() {
+		logger, _ := testutils.NewLogger()
+		analyzer := gosec.NewAnalyzer(gosec.NewConfig(), false, logger)
+		analyzer.LoadRules(map[string]gosec.RuleBuilder{"X001": customrule.NewPanicCheck})
+		Expect(analyzer.Process(nil, pkg.Path)).To(Succeed())
+
+		issues, _, _ := analyzer.Report()
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0].RuleID).To(Equal("X001"))
+		Expect(issues[0].Line).To(Equal("8"))
+	})
+
+	It("runs along the gosec rules when added to their list", func() {
+		definitions := rules.Generate(rules.NewRuleFilter(false, "G104"))
+		definitions["X001"] = rules.RuleDefinition{ID: "X001", Description: "Calls of panic", Create: customrule.NewPanicCheck}
+
+		logger, _ := testutils.NewLogger()
+		analyzer := gosec.NewAnalyzer(gosec.NewConfig(), false, logger)
+		analyzer.LoadRules(definitions.Builders())
+		Expect(analyzer.Process(nil, pkg.Path)).To(Succeed())
+
+		issues, _, _ := analyzer.Report()
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0].RuleID).To(Equal("X001"))
+	})
+})
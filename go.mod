@@ -3,6 +3,7 @@ go 1.17
 module github.com/cosmos/gosec/v2
 
 require (
+	github.com/fsnotify/fsnotify v1.6.0
 	github.com/google/go-cmp v0.5.8
 	github.com/gookit/color v1.5.2
 	github.com/mozilla/tls-observatory v0.0.0-20210609171429-7bc42856d2e5
@@ -20,14 +20,18 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"io/ioutil"
 	"os"
 	"os/user"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime" // #nosec G702
 	"sort"
 	"strconv"
 	"strings"
+
+	"golang.org/x/tools/go/packages"
 )
 
 // MatchCallByPackage ensures that the specified package is imported,
@@ -401,6 +405,29 @@ func FindVarIdentities(n *ast.BinaryExpr, c *Context) ([]*ast.Ident, bool) {
 
 // PackagePaths returns a slice with all packages path at given root directory
 func PackagePaths(root string, excludes []*regexp.Regexp) ([]string, error) {
+	return walkPackagePaths(root, func(path string) bool {
+		return isExcluded(path, excludes)
+	})
+}
+
+// PackagePathsGlob returns a slice with all packages path at given root directory,
+// the directories matching any of the gitignore-style excluded dirs are pruned
+// from the walk together with their sub-directories.
+func PackagePathsGlob(root string, excludes []string) ([]string, error) {
+	base := strings.TrimSuffix(root, "...")
+	return walkPackagePaths(root, func(path string) bool {
+		rel, err := filepath.Rel(base, path)
+		if err != nil || rel == "." {
+			return false
+		}
+		return isExcludedGlob(filepath.ToSlash(rel), excludes)
+	})
+}
+
+// walkPackagePaths walks the root directory, when it ends with an ellipsis, and
+// collects the directories holding Go files. The excluded directories are not
+// walked at all.
+func walkPackagePaths(root string, excluded func(string) bool) ([]string, error) {
 	if strings.HasSuffix(root, "...") {
 		root = root[0 : len(root)-3]
 	} else {
@@ -408,12 +435,17 @@ func PackagePaths(root string, excludes []*regexp.Regexp) ([]string, error) {
 	}
 	paths := map[string]bool{}
 	err := filepath.Walk(root, func(path string, f os.FileInfo, err error) error {
-		if filepath.Ext(path) == ".go" {
-			path = filepath.Dir(path)
-			if isExcluded(path, excludes) {
-				return nil
+		if err != nil {
+			return nil
+		}
+		if f.IsDir() {
+			if excluded(path) {
+				return filepath.SkipDir
 			}
-			paths[path] = true
+			return nil
+		}
+		if filepath.Ext(path) == ".go" {
+			paths[filepath.Dir(path)] = true
 		}
 		return nil
 	})
@@ -452,6 +484,111 @@ func ExcludedDirsRegExp(excludedDirs []string) []*regexp.Regexp {
 	return exps
 }
 
+// ExcludedDirsGlob validates a list of gitignore-style glob patterns of excluded dirs.
+// A pattern without a slash matches a directory name at any depth, a pattern with a
+// slash is matched against the path relative to the scanned root and "**" matches
+// any number of directories.
+func ExcludedDirsGlob(excludedDirs []string) ([]string, error) {
+	var patterns []string
+	for _, excludedDir := range excludedDirs {
+		pattern := strings.TrimSuffix(filepath.ToSlash(strings.TrimSpace(excludedDir)), "/")
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid excluded dir %q: %w", excludedDir, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// ReadExcludedDirs reads the patterns of excluded dirs from a file, one per line.
+// The blank lines and the lines starting with "#" are ignored.
+func ReadExcludedDirs(filename string) ([]string, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return nil, err
+	}
+	var patterns []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, nil
+}
+
+// isExcludedGlob checks if a slash separated relative path matches any of the
+// excluded dirs patterns
+func isExcludedGlob(rel string, excludes []string) bool {
+	segments := strings.Split(rel, "/")
+	for _, exclude := range excludes {
+		exclude = strings.TrimSuffix(exclude, "/")
+		if !strings.Contains(exclude, "/") {
+			if matched, _ := path.Match(exclude, segments[len(segments)-1]); matched {
+				return true
+			}
+			continue
+		}
+		if matchSegments(strings.Split(strings.TrimPrefix(exclude, "/"), "/"), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches the path segments against the pattern segments, where a
+// "**" segment matches zero or more path segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// ImportPathDirs resolves an import path, or an import path pattern such as
+// github.com/cosmos/cosmos-sdk/x/bank/..., to the directories of the matching
+// packages, for the packages which are not given as a directory.
+func ImportPathDirs(importPath string, buildTags []string) ([]string, error) {
+	config := &packages.Config{Mode: packages.NeedName | packages.NeedFiles}
+	if len(buildTags) > 0 {
+		config.BuildFlags = []string{"-tags=" + strings.Join(buildTags, ",")}
+	}
+	pkgs, err := packages.Load(config, importPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving import path %q: %w", importPath, err)
+	}
+	dirs := []string{}
+	for _, pkg := range pkgs {
+		files := append(append([]string{}, pkg.GoFiles...), pkg.OtherFiles...)
+		if len(files) == 0 {
+			if len(pkg.Errors) > 0 {
+				return nil, fmt.Errorf("resolving import path %q: %v", importPath, pkg.Errors[0])
+			}
+			continue
+		}
+		dirs = append(dirs, filepath.Dir(files[0]))
+	}
+	return dirs, nil
+}
+
 // RootPath returns the absolute root path of a scan
 func RootPath(root string) (string, error) {
 	if strings.HasSuffix(root, "...") {
@@ -49,6 +49,27 @@ var _ = Describe("Helpers", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(paths).Should(Equal([]string{dir}))
 		})
+		It("should prune the folders matching the glob patterns", func() {
+			for _, nested := range []string{"x/bank/keeper", "x/bank/types_pb", "proto/cosmos/gen", "third_party"} {
+				err := os.MkdirAll(filepath.Join(dir, nested), 0755)
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = os.Create(filepath.Join(dir, nested, "test.go"))
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+			paths, err := gosec.PackagePathsGlob(dir+"/...", []string{"*_pb", "proto/**/gen", "/third_party/"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(paths).Should(ConsistOf(dir, filepath.Join(dir, "x/bank/keeper")))
+		})
+		It("should not prune the folders only matching below the root", func() {
+			nested := filepath.Join(dir, "x", "third_party")
+			err := os.MkdirAll(nested, 0755)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = os.Create(filepath.Join(nested, "test.go"))
+			Expect(err).ShouldNot(HaveOccurred())
+			paths, err := gosec.PackagePathsGlob(dir+"/...", []string{"/third_party"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(paths).Should(ConsistOf(dir, nested))
+		})
 		It("should be empty when folder does not exist", func() {
 			nested := dir + "/test"
 			paths, err := gosec.PackagePaths(nested+"/...", nil)
@@ -94,6 +115,28 @@ var _ = Describe("Helpers", func() {
 		})
 	})
 
+	Context("when excluding the dirs with glob patterns", func() {
+		It("should trim the patterns and reject the invalid ones", func() {
+			patterns, err := gosec.ExcludedDirsGlob([]string{" vendor/ ", "", "x/**/types"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(patterns).Should(Equal([]string{"vendor", "x/**/types"}))
+			_, err = gosec.ExcludedDirsGlob([]string{"gen["})
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should read the patterns from a file", func() {
+			file, err := ioutil.TempFile("", "gosecignore")
+			Expect(err).ShouldNot(HaveOccurred())
+			defer os.Remove(file.Name())
+			_, err = file.WriteString("# generated code\n*_pb\n\n  proto/**  \n")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+			patterns, err := gosec.ReadExcludedDirs(file.Name())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(patterns).Should(Equal([]string{"*_pb", "proto/**"}))
+		})
+	})
+
 	Context("when getting call info", func() {
 		It("should return the type and call name for selector expression", func() {
 			pkg := testutils.NewTestPackage()
@@ -17,12 +17,16 @@ package gosec
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 // Score type used by severity and confidence values
@@ -88,15 +92,70 @@ var IssueToCWE = map[string]Cwe{
 
 // Issue is returned by a gosec rule if it discovers an issue with the scanned code.
 type Issue struct {
-	Severity   Score  `json:"severity"`   // issue severity (how problematic it is)
-	Confidence Score  `json:"confidence"` // issue confidence (how sure we are we found it)
-	Cwe        Cwe    `json:"cwe"`        // Cwe associated with RuleID
-	RuleID     string `json:"rule_id"`    // Human readable explanation
-	What       string `json:"details"`    // Human readable explanation
-	File       string `json:"file"`       // File name we found it in
-	Code       string `json:"code"`       // Impacted code line
-	Line       string `json:"line"`       // Line number in file
-	Col        string `json:"column"`     // Column number in line
+	Severity    Score    `json:"severity"`                                           // issue severity (how problematic it is)
+	Confidence  Score    `json:"confidence"`                                         // issue confidence (how sure we are we found it)
+	Cwe         Cwe      `json:"cwe"`                                                // Cwe associated with RuleID
+	RuleID      string   `json:"rule_id"`                                            // Human readable explanation
+	What        string   `json:"details"`                                            // Human readable explanation
+	File        string   `json:"file"`                                               // File name we found it in
+	Code        string   `json:"code"`                                               // Impacted code line
+	Line        string   `json:"line"`                                               // Line number in file
+	Col         string   `json:"column"`                                             // Column number in line
+	Autofix     *Autofix `json:"autofix,omitempty" yaml:"autofix,omitempty"`         // Suggested fix, for the rules which know how to fix the issue
+	Occurrences int      `json:"occurrences,omitempty" yaml:"occurrences,omitempty"` // Number of times the issue was found, e.g. across build tags
+	Blame       *Blame   `json:"blame,omitempty" yaml:"blame,omitempty"`             // Last commit which changed the line of the issue, when requested
+	HelpURL     string   `json:"help_url,omitempty" yaml:"help_url,omitempty"`       // Documentation of the rule, when it has one
+}
+
+// Blame is the last commit which changed the line of an issue, as reported by
+// git blame.
+type Blame struct {
+	Commit string `json:"commit"` // Hash of the commit
+	Author string `json:"author"` // Name of the author of the commit
+	Email  string `json:"email"`  // Email of the author of the commit
+}
+
+// Autofix is a fix suggested for an issue. The replacement is only provided when
+// the fix is mechanical, in which case it replaces the lines of the issue.
+type Autofix struct {
+	Message     string `json:"message"`               // Human readable description of the fix
+	Replacement string `json:"replacement,omitempty"` // Code replacing the lines of the issue
+}
+
+// WithAutofix attaches a suggested fix to the issue
+func (i *Issue) WithAutofix(message, replacement string) *Issue {
+	i.Autofix = &Autofix{Message: message, Replacement: replacement}
+	return i
+}
+
+// DeduplicateIssues collapses the issues found several times with the same rule, file,
+// line, column and message, e.g. when scanning with multiple build tags. The first
+// occurrence is kept, in order, with the number of occurrences.
+func DeduplicateIssues(issues []*Issue) []*Issue {
+	type issueKey struct {
+		rule, file, line, col, what string
+	}
+	seen := make(map[issueKey]*Issue)
+	result := make([]*Issue, 0, len(issues))
+	for _, issue := range issues {
+		file, err := filepath.Abs(issue.File)
+		if err != nil {
+			file = issue.File
+		}
+		occurrences := issue.Occurrences
+		if occurrences == 0 {
+			occurrences = 1
+		}
+		key := issueKey{issue.RuleID, file, issue.Line, issue.Col, issue.What}
+		if first, ok := seen[key]; ok {
+			first.Occurrences += occurrences
+			continue
+		}
+		issue.Occurrences = occurrences
+		seen[key] = issue
+		result = append(result, issue)
+	}
+	return result
 }
 
 // FileLocation point out the file path and line number in file
@@ -104,13 +163,76 @@ func (i Issue) FileLocation() string {
 	return fmt.Sprintf("%s:%s", i.File, i.Line)
 }
 
+// Fingerprint returns the fingerprint of the issue over its file path as it is
+// reported, see (*Issue).Fingerprint for the algorithm.
+func Fingerprint(issue *Issue) string {
+	return issue.Fingerprint(issue.File)
+}
+
+// Fingerprint hashes the rule, the file path and the offending lines of the
+// snippet without their numbers, so that the fingerprint of an issue stays the
+// same when unrelated lines are added or removed around it. The path is the
+// file of the issue, usually made relative to the root of the project.
+//
+// The fingerprint is stable across the gosec versions, and can be reproduced
+// by other tools: it is the lower case hex encoding of the SHA-256 hash of
+//
+//	<rule ID> "\n" <path> "\n" <line> "\n" ...
+//
+// where the path is cleaned and uses forward slashes, e.g. "x/bank/keeper.go",
+// and each line is the code of one of the lines of the snippet within the
+// range of the issue, e.g. 12 to 14 for "12-14", without its "12: " prefix and
+// with its leading and trailing white space trimmed.
+func (i *Issue) Fingerprint(path string) string {
+	lines := strings.Split(i.Line, "-")
+	start, _ := strconv.Atoi(lines[0])
+	end := start
+	if len(lines) > 1 {
+		end, _ = strconv.Atoi(lines[1])
+	}
+
+	h := sha256.New()
+	path = filepath.ToSlash(filepath.Clean(path))
+	h.Write([]byte(i.RuleID + "\n" + path + "\n")) // #nosec G104
+	scanner := bufio.NewScanner(strings.NewReader(i.Code))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ": ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		line, err := strconv.Atoi(parts[0])
+		if err != nil || line < start || line > end {
+			continue
+		}
+		h.Write([]byte(strings.TrimSpace(parts[1]) + "\n")) // #nosec G104
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // MetaData is embedded in all gosec rules. The Severity, Confidence and What message
-// will be passed through to reported issues.
+// will be passed through to reported issues, as well as the HelpURL linking the
+// documentation of the rule, if any. The rules defined outside of gosec embed it
+// as well to be listed with their metadata.
 type MetaData struct {
 	ID         string
 	Severity   Score
 	Confidence Score
 	What       string
+	HelpURL    string
+}
+
+// metaData returns the metadata of the rules embedding MetaData
+func (m MetaData) metaData() MetaData {
+	return m
+}
+
+// RuleMetaData returns the metadata of a rule, false if the rule does not embed
+// MetaData
+func RuleMetaData(rule Rule) (MetaData, bool) {
+	if r, ok := rule.(interface{ metaData() MetaData }); ok {
+		return r.metaData(), true
+	}
+	return MetaData{}, false
 }
 
 // MarshalJSON is used convert a Score object into a JSON representation
@@ -118,6 +240,41 @@ func (c Score) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.String())
 }
 
+// UnmarshalJSON is used to convert the JSON representation of a Score back
+// into a Score object
+func (c *Score) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch value {
+	case "HIGH":
+		*c = High
+	case "MEDIUM":
+		*c = Medium
+	case "LOW":
+		*c = Low
+	default:
+		return fmt.Errorf("invalid score %q", value)
+	}
+	return nil
+}
+
+// ParseScore converts the name of a score, low, medium or high in any case,
+// into a Score
+func ParseScore(value string) (Score, error) {
+	switch strings.ToUpper(value) {
+	case "HIGH":
+		return High, nil
+	case "MEDIUM":
+		return Medium, nil
+	case "LOW":
+		return Low, nil
+	default:
+		return Low, fmt.Errorf("invalid score %q, valid options are: low, medium, high", value)
+	}
+}
+
 // String converts a Score into a string
 func (c Score) String() string {
 	switch c {
@@ -152,17 +309,34 @@ func codeSnippet(file *os.File, start int64, end int64, n ast.Node) (string, err
 	return buf.String(), nil
 }
 
-func codeSnippetStartLine(node ast.Node, fobj *token.File) int64 {
+func codeSnippetStartLine(node ast.Node, fobj *token.File, offset int64) int64 {
 	s := (int64)(fobj.Line(node.Pos()))
-	if s-SnippetOffset > 0 {
-		return s - SnippetOffset
+	if s-offset > 0 {
+		return s - offset
 	}
-	return s
+	return 1
 }
 
-func codeSnippetEndLine(node ast.Node, fobj *token.File) int64 {
+func codeSnippetEndLine(node ast.Node, fobj *token.File, offset int64) int64 {
 	e := (int64)(fobj.Line(node.End()))
-	return e + SnippetOffset
+	return e + offset
+}
+
+// codeSnippetOffset returns the number of context lines of the code snippets,
+// which defaults to SnippetOffset unless set with the SnippetContext option.
+func codeSnippetOffset(ctx *Context) int64 {
+	if ctx.Config == nil {
+		return SnippetOffset
+	}
+	value, err := ctx.Config.GetGlobal(SnippetContext)
+	if err != nil {
+		return SnippetOffset
+	}
+	offset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || offset < 0 {
+		return SnippetOffset
+	}
+	return offset
 }
 
 // NewIssue creates a new Issue
@@ -179,8 +353,9 @@ func NewIssue(ctx *Context, node ast.Node, ruleID, desc string, severity Score,
 	var code string
 	if file, err := os.Open(fobj.Name()); err == nil {
 		defer file.Close() // #nosec
-		s := codeSnippetStartLine(node, fobj)
-		e := codeSnippetEndLine(node, fobj)
+		offset := codeSnippetOffset(ctx)
+		s := codeSnippetStartLine(node, fobj, offset)
+		e := codeSnippetEndLine(node, fobj, offset)
 		code, err = codeSnippet(file, s, e, node)
 		if err != nil {
 			code = err.Error()
@@ -1,7 +1,11 @@
 package gosec_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"go/ast"
+	"path/filepath"
 
 	"github.com/cosmos/gosec/v2"
 	"github.com/cosmos/gosec/v2/rules"
@@ -45,6 +49,68 @@ var _ = Describe("Issue", func() {
 			Expect(issue.Cwe.ID).Should(Equal(""))
 		})
 
+		It("should include the configured number of context lines around the issue", func() {
+			var target *ast.CallExpr
+			source := `package main
+
+import "fmt"
+
+func main() {
+	a := 1
+	b := 2
+	fmt.Println(a, b)
+	c := 3
+	d := 4
+	fmt.Println(c, d)
+}
+`
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("foo.go", source)
+			ctx := pkg.CreateContext("foo.go")
+			ctx.Config.SetGlobal(gosec.SnippetContext, "2")
+			v := testutils.NewMockVisitor()
+			v.Callback = func(n ast.Node, ctx *gosec.Context) bool {
+				if node, ok := n.(*ast.CallExpr); ok && target == nil {
+					target = node
+					return false
+				}
+				return true
+			}
+			v.Context = ctx
+			ast.Walk(v, ctx.Root)
+			Expect(target).ShouldNot(BeNil())
+
+			issue := gosec.NewIssue(ctx, target, "TEST", "", gosec.High, gosec.High)
+			Expect(issue.Line).Should(Equal("8"))
+			Expect(issue.Code).Should(Equal("6: \ta := 1\n7: \tb := 2\n8: \tfmt.Println(a, b)\n9: \tc := 3\n10: \td := 4\n"))
+		})
+
+		It("should not go past the start and the end of the file with the context lines", func() {
+			var target *ast.Ident
+			source := "package main\n\nfunc main() {}\n"
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("foo.go", source)
+			ctx := pkg.CreateContext("foo.go")
+			ctx.Config.SetGlobal(gosec.SnippetContext, "5")
+			v := testutils.NewMockVisitor()
+			v.Callback = func(n ast.Node, ctx *gosec.Context) bool {
+				if node, ok := n.(*ast.Ident); ok && node.Name == "main" && target == nil {
+					target = node
+					return false
+				}
+				return true
+			}
+			v.Context = ctx
+			ast.Walk(v, ctx.Root)
+			Expect(target).ShouldNot(BeNil())
+
+			issue := gosec.NewIssue(ctx, target, "TEST", "", gosec.High, gosec.High)
+			Expect(issue.Line).Should(Equal("1"))
+			Expect(issue.Code).Should(Equal("1: package main\n2: \n3: func main() {}\n"))
+		})
+
 		It("should return an error if specific context is not able to be obtained", func() {
 			Skip("Not implemented")
 		})
@@ -137,4 +203,84 @@ func main() {
 
 	})
 
+	Context("when deduplicating issues", func() {
+		newIssue := func(file, col string) *gosec.Issue {
+			return &gosec.Issue{
+				Severity:   gosec.High,
+				Confidence: gosec.High,
+				RuleID:     "G723",
+				What:       "math/big.Float rounds its results",
+				File:       file,
+				Line:       "7",
+				Col:        col,
+			}
+		}
+
+		It("should collapse the identical issues and count them", func() {
+			abs, err := filepath.Abs("keeper.go")
+			Expect(err).ShouldNot(HaveOccurred())
+			issues := gosec.DeduplicateIssues([]*gosec.Issue{newIssue(abs, "2"), newIssue("keeper.go", "2")})
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].File).Should(Equal(abs))
+			Expect(issues[0].Occurrences).Should(Equal(2))
+
+			raw, err := json.Marshal(issues[0])
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(raw)).Should(ContainSubstring(`"occurrences":2`))
+		})
+
+		It("should keep the issues found at different places in order", func() {
+			first, second := newIssue("keeper.go", "2"), newIssue("keeper.go", "10")
+			issues := gosec.DeduplicateIssues([]*gosec.Issue{first, second, newIssue("keeper.go", "2")})
+			Expect(issues).Should(Equal([]*gosec.Issue{first, second}))
+			Expect(first.Occurrences).Should(Equal(2))
+			Expect(second.Occurrences).Should(Equal(1))
+		})
+	})
+
+	Context("when fingerprinting an issue", func() {
+		newIssue := func(line, code string) *gosec.Issue {
+			return &gosec.Issue{
+				RuleID: "G701",
+				File:   "x/bank/keeper/keeper.go",
+				Line:   line,
+				Code:   code,
+			}
+		}
+		snippet := "11: func Send(amount int64) {\n12: \tburn(uint64(amount))\n13: }\n"
+
+		It("should hash the rule, the path and the code of the lines of the issue", func() {
+			h := sha256.Sum256([]byte("G701\nx/bank/keeper/keeper.go\nburn(uint64(amount))\n"))
+			Expect(gosec.Fingerprint(newIssue("12", snippet))).Should(Equal(hex.EncodeToString(h[:])))
+		})
+
+		It("should not change when the issue is shifted to other lines", func() {
+			shifted := "21: func Send(amount int64) {\n22: \tburn(uint64(amount))\n23: }\n"
+			Expect(gosec.Fingerprint(newIssue("22", shifted))).Should(Equal(gosec.Fingerprint(newIssue("12", snippet))))
+		})
+
+		It("should not depend on the lines of context around the issue", func() {
+			lines := "12: \tburn(uint64(amount))\n"
+			Expect(gosec.Fingerprint(newIssue("12", lines))).Should(Equal(gosec.Fingerprint(newIssue("12", snippet))))
+		})
+
+		It("should change when the code of the issue changes", func() {
+			changed := "11: func Send(amount int64) {\n12: \tburn(uint64(-amount))\n13: }\n"
+			Expect(gosec.Fingerprint(newIssue("12", changed))).ShouldNot(Equal(gosec.Fingerprint(newIssue("12", snippet))))
+		})
+
+		It("should change with the rule and the file", func() {
+			issue := newIssue("12", snippet)
+			fingerprint := gosec.Fingerprint(issue)
+			Expect(issue.Fingerprint("x/staking/keeper/keeper.go")).ShouldNot(Equal(fingerprint))
+			issue.RuleID = "G740"
+			Expect(gosec.Fingerprint(issue)).ShouldNot(Equal(fingerprint))
+		})
+
+		It("should normalize the path", func() {
+			issue := newIssue("12", snippet)
+			Expect(issue.Fingerprint("./x/bank/keeper/../keeper/keeper.go")).Should(Equal(gosec.Fingerprint(issue)))
+		})
+	})
+
 })
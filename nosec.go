@@ -0,0 +1,54 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gosec
+
+import (
+	"regexp"
+	"strings"
+)
+
+// noSecDefaultTag is the tag of the directives suppressing the issues, unless
+// an alternative is set with the NoSecAlternative option
+const noSecDefaultTag = "#nosec"
+
+var reNosecRules = regexp.MustCompile(`^(\s*[,:]?\s*G\d{3})*`)
+
+// ParseNosec parses a #nosec directive the same way as the analyzer does. It
+// returns false if the comment has no #nosec directive, otherwise the IDs of
+// the suppressed rules, which are all the rules when there are none, and the
+// justification following the list of rules on the line of the directive,
+// such as "md5 is only used for the cache keys" in
+// "#nosec G401 -- md5 is only used for the cache keys".
+func ParseNosec(comment string) (ruleIDs []string, justification string, ok bool) {
+	return parseNosec(comment, noSecDefaultTag)
+}
+
+// parseNosec parses the directive starting with tag in the comment
+func parseNosec(comment string, tag string) ([]string, string, bool) {
+	start := strings.Index(comment, tag)
+	if start < 0 {
+		return nil, "", false
+	}
+
+	// The rules are listed anywhere in the comment
+	ruleIDs := reRuleID.FindAllString(comment, -1)
+
+	text := comment[start+len(tag):]
+	if i := strings.Index(text, "\n"); i >= 0 {
+		text = text[:i]
+	}
+	text = reNosecRules.ReplaceAllString(text, "")
+	return ruleIDs, strings.Trim(text, " \t-:,"), true
+}
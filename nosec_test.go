@@ -0,0 +1,61 @@
+package gosec_test
+
+import (
+	"github.com/cosmos/gosec/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Nosec", func() {
+	Context("when parsing a #nosec directive", func() {
+		It("should suppress all the rules with a bare directive", func() {
+			ruleIDs, justification, ok := gosec.ParseNosec("#nosec")
+			Expect(ok).Should(BeTrue())
+			Expect(ruleIDs).Should(BeEmpty())
+			Expect(justification).Should(BeEmpty())
+		})
+
+		It("should return the rules listed after the directive", func() {
+			ruleIDs, justification, ok := gosec.ParseNosec("#nosec G401, G501:G101")
+			Expect(ok).Should(BeTrue())
+			Expect(ruleIDs).Should(Equal([]string{"G401", "G501", "G101"}))
+			Expect(justification).Should(BeEmpty())
+		})
+
+		It("should return the justification following the rules", func() {
+			ruleIDs, justification, ok := gosec.ParseNosec("#nosec G401 -- md5 is only used for the cache keys")
+			Expect(ok).Should(BeTrue())
+			Expect(ruleIDs).Should(Equal([]string{"G401"}))
+			Expect(justification).Should(Equal("md5 is only used for the cache keys"))
+		})
+
+		It("should return the justification of a bare directive", func() {
+			ruleIDs, justification, ok := gosec.ParseNosec("#nosec: the input is validated by the caller")
+			Expect(ok).Should(BeTrue())
+			Expect(ruleIDs).Should(BeEmpty())
+			Expect(justification).Should(Equal("the input is validated by the caller"))
+		})
+
+		It("should only take the justification from the line of the directive", func() {
+			_, justification, ok := gosec.ParseNosec("#nosec G104\nthe error is checked below")
+			Expect(ok).Should(BeTrue())
+			Expect(justification).Should(BeEmpty())
+		})
+
+		It("should not parse the comments without a directive", func() {
+			for _, comment := range []string{"", "nosec G401", "# nosec G401", "#NOSEC G401"} {
+				ruleIDs, justification, ok := gosec.ParseNosec(comment)
+				Expect(ok).Should(BeFalse(), comment)
+				Expect(ruleIDs).Should(BeEmpty())
+				Expect(justification).Should(BeEmpty())
+			}
+		})
+
+		It("should not take a truncated rule ID as a rule", func() {
+			ruleIDs, justification, ok := gosec.ParseNosec("#nosec G40")
+			Expect(ok).Should(BeTrue())
+			Expect(ruleIDs).Should(BeEmpty())
+			Expect(justification).Should(Equal("G40"))
+		})
+	})
+})
@@ -0,0 +1,34 @@
+package output
+
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+type codeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines codeClimateLines `json:"lines"`
+}
+
+type codeClimateIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Categories  []string            `json:"categories"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeClimateLocation `json:"location"`
+}
+
+func getCodeClimateSeverity(s string) string {
+	switch s {
+	case "LOW":
+		return "minor"
+	case "MEDIUM":
+		return "major"
+	case "HIGH":
+		return "critical"
+	default:
+		return "info"
+	}
+}
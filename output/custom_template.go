@@ -0,0 +1,63 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	plainTemplate "text/template"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// templateReportInfo is the data a user supplied template is executed against
+type templateReportInfo struct {
+	Errors   map[string][]gosec.Error
+	Issues   []*gosec.Issue
+	Stats    *gosec.Metrics
+	Severity map[string]int // number of issues per severity
+}
+
+// ParseTemplate parses the text/template file of the template format, with the
+// same functions as the text format available without colors
+func ParseTemplate(filename string) (*plainTemplate.Template, error) {
+	// #nosec
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading the report template: %v", err)
+	}
+	t, err := plainTemplate.
+		New(filepath.Base(filename)).
+		Funcs(plainTextFuncMap(false)).
+		Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing the report template: %v", err)
+	}
+	return t, nil
+}
+
+// CreateTemplateReport generates a report by executing the template against the
+// issues, metrics and errors.
+func CreateTemplateReport(w io.Writer, t *plainTemplate.Template, issues []*gosec.Issue, metrics *gosec.Metrics, errors map[string][]gosec.Error) error {
+	data := &templateReportInfo{
+		Errors:   errors,
+		Issues:   issues,
+		Stats:    metrics,
+		Severity: gosec.NewSummary(issues, metrics).BySeverity,
+	}
+	return t.Execute(w, data)
+}
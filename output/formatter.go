@@ -55,8 +55,8 @@ const (
 	SonarqubeEffortMinutes = 5
 )
 
-var text = `Results:
-{{range $filePath,$fileErrors := .Errors}}
+var text = `{{ if .Stats }}Results:
+{{ end }}{{range $filePath,$fileErrors := .Errors}}
 Golang errors in file: [{{ $filePath }}]:
 {{range $index, $error := $fileErrors}}
   > [line {{$error.Line}} : column {{$error.Column}}] - {{$error.Err}}
@@ -64,10 +64,12 @@ Golang errors in file: [{{ $filePath }}]:
 {{end}}
 {{ range $index, $issue := .Issues }}
 [{{ highlight $issue.FileLocation $issue.Severity }}] - {{ $issue.RuleID }} (CWE-{{ $issue.Cwe.ID }}): {{ $issue.What }} (Confidence: {{ $issue.Confidence}}, Severity: {{ $issue.Severity }})
-{{ printCode $issue }}
+{{ if $issue.Blame }}  Last changed by {{ $issue.Blame.Author }} in {{ $issue.Blame.Commit }}
+{{ end }}{{ if $issue.HelpURL }}  See {{ $issue.HelpURL }}
+{{ end }}{{ printCode $issue }}
 
 {{ end }}
-{{ notice "Summary:" }}
+{{ if .Stats }}{{ notice "Summary:" }}
    Files: {{.Stats.NumFiles}}
    Lines: {{.Stats.NumLines}}
    Nosec: {{.Stats.NumNosec}}
@@ -77,7 +79,7 @@ Golang errors in file: [{{ $filePath }}]:
 	{{- danger .Stats.NumFound }}
 	{{- end }}
 
-`
+{{ end }}`
 
 type reportInfo struct {
 	Errors map[string][]gosec.Error `json:"Golang errors"`
@@ -86,7 +88,10 @@ type reportInfo struct {
 }
 
 // CreateReport generates a report based for the supplied issues and metrics given
-// the specified format. The formats currently accepted are: json, yaml, csv, junit-xml, html, sonarqube, golint and text.
+// the specified format. The formats currently accepted are: json, ndjson, yaml, csv, junit-xml, html, sonarqube, golint, sarif, codeclimate, github-actions and text.
+// The text format leaves out the summary of the scan when no metrics are given.
+// The sonarqube, sarif, codeclimate and github-actions formats write the files
+// relative to the root paths holding them, or as they are without root paths.
 func CreateReport(w io.Writer, format string, enableColor bool, rootPaths []string, issues []*gosec.Issue, metrics *gosec.Metrics, errors map[string][]gosec.Error) error {
 	data := &reportInfo{
 		Errors: errors,
@@ -97,6 +102,8 @@ func CreateReport(w io.Writer, format string, enableColor bool, rootPaths []stri
 	switch format {
 	case "json":
 		err = reportJSON(w, data)
+	case "ndjson":
+		err = reportNDJSON(w, data)
 	case "yaml":
 		err = reportYAML(w, data)
 	case "csv":
@@ -113,6 +120,10 @@ func CreateReport(w io.Writer, format string, enableColor bool, rootPaths []stri
 		err = reportGolint(w, data)
 	case "sarif":
 		err = reportSARIFTemplate(rootPaths, w, data)
+	case "codeclimate":
+		err = reportCodeClimate(rootPaths, w, data)
+	case "github-actions":
+		err = reportGitHubActions(rootPaths, w, data)
 	default:
 		err = reportFromPlaintextTemplate(w, text, enableColor, data)
 	}
@@ -135,7 +146,11 @@ func reportSonarqube(rootPaths []string, w io.Writer, data *reportInfo) error {
 func convertToSonarIssues(rootPaths []string, data *reportInfo) (*sonarIssues, error) {
 	si := &sonarIssues{[]sonarIssue{}}
 	for _, issue := range data.Issues {
+		// Without root paths the files are written as they are
 		var sonarFilePath string
+		if len(rootPaths) == 0 {
+			sonarFilePath = issue.File
+		}
 		for _, rootPath := range rootPaths {
 			if strings.HasPrefix(issue.File, rootPath) {
 				sonarFilePath = strings.Replace(issue.File, rootPath+"/", "", 1)
@@ -177,6 +192,59 @@ func convertToSonarIssues(rootPaths []string, data *reportInfo) (*sonarIssues, e
 	return si, nil
 }
 
+func reportCodeClimate(rootPaths []string, w io.Writer, data *reportInfo) error {
+	ci, err := convertToCodeClimateIssues(rootPaths, data)
+	if err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(ci, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(raw)
+	return err
+}
+
+func convertToCodeClimateIssues(rootPaths []string, data *reportInfo) ([]codeClimateIssue, error) {
+	ci := []codeClimateIssue{}
+	for _, issue := range data.Issues {
+		path := issue.File
+		for _, rootPath := range rootPaths {
+			if strings.HasPrefix(issue.File, rootPath) {
+				path = strings.Replace(issue.File, rootPath+"/", "", 1)
+			}
+		}
+
+		lines := strings.Split(issue.Line, "-")
+		startLine, err := strconv.Atoi(lines[0])
+		if err != nil {
+			return ci, err
+		}
+		endLine := startLine
+		if len(lines) > 1 {
+			endLine, err = strconv.Atoi(lines[1])
+			if err != nil {
+				return ci, err
+			}
+		}
+
+		c := codeClimateIssue{
+			Type:        "issue",
+			CheckName:   issue.RuleID,
+			Description: issue.What,
+			Categories:  []string{"Security"},
+			Fingerprint: issue.Fingerprint(path),
+			Severity:    getCodeClimateSeverity(issue.Severity.String()),
+			Location: codeClimateLocation{
+				Path:  path,
+				Lines: codeClimateLines{Begin: startLine, End: endLine},
+			},
+		}
+		ci = append(ci, c)
+	}
+	return ci, nil
+}
+
 func convertToSarifReport(rootPaths []string, data *reportInfo) (*sarifReport, error) {
 	sr := buildSarifReport()
 
@@ -213,6 +281,7 @@ func convertToSarifReport(rootPaths []string, data *reportInfo) (*sarifReport, e
 				Text: issue.What,
 			},
 			Locations: []*sarifLocation{location},
+			Fixes:     buildSarifFixes(issue, location),
 		}
 
 		results = append(results, result)
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/cosmos/gosec/v2"
@@ -259,6 +262,7 @@ var _ = Describe("Formatter", func() {
 	Context("When using junit", func() {
 		It("preserves order of issues", func() {
 			issues := []*gosec.Issue{createIssueWithFileWhat("i1", "1"), createIssueWithFileWhat("i2", "2"), createIssueWithFileWhat("i3", "1")}
+			issues[1].RuleID = "i2"
 
 			junitReport := createJUnitXMLStruct(&reportInfo{Issues: issues})
 
@@ -271,6 +275,25 @@ var _ = Describe("Formatter", func() {
 			Expect(testSuite.Testcases[0].Name).To(Equal(issues[1].File))
 
 		})
+
+		It("groups the issues by rule and keeps the code snippets in character data", func() {
+			first := createIssue("G101", gosec.GetCwe("798"))
+			first.Code = "1: if a < b && c > \"d\" {"
+			second := createIssue("G401", gosec.GetCwe("326"))
+			second.File = "/home/src/project/crypto.go"
+			second.Line = "12"
+			third := createIssue("G101", gosec.GetCwe("798"))
+			third.Line = "5"
+			issues := []*gosec.Issue{&first, &second, &third}
+
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "junit-xml", false, []string{}, issues, &gosec.Metrics{}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			golden, err := ioutil.ReadFile("testdata/junit.golden.xml")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(Equal(string(golden)))
+		})
 	})
 	Context("When using different report formats", func() {
 
@@ -461,4 +484,318 @@ var _ = Describe("Formatter", func() {
 			}
 		})
 	})
+
+	Context("When merging SARIF reports", func() {
+		other := `{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"other","rules":[{"id":"O1","properties":{"precision":"high"}}]}},"results":[]}]}`
+
+		It("keeps gosec and the other tools as separate runs", func() {
+			issue := createIssue("G101", gosec.GetCwe("798"))
+			gosecReport := new(bytes.Buffer)
+			err := CreateReport(gosecReport, "sarif", false, []string{}, []*gosec.Issue{&issue}, &gosec.Metrics{}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			buf := new(bytes.Buffer)
+			err = MergeSarifReports(buf, []string{"gosec", "other.sarif"}, gosecReport, strings.NewReader(other))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			merged := &sarifRawReport{}
+			err = json.Unmarshal(buf.Bytes(), merged)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(merged.Version).To(Equal("2.1.0"))
+			Expect(merged.Schema).To(ContainSubstring("sarif-schema-2.1.0.json"))
+			Expect(merged.Runs).To(HaveLen(2))
+			Expect(string(merged.Runs[0])).To(ContainSubstring(`"name": "gosec"`))
+			Expect(stripString(string(merged.Runs[1]))).To(Equal(`{"tool":{"driver":{"name":"other","rules":[{"id":"O1","properties":{"precision":"high"}}]}},"results":[]}`))
+		})
+
+		It("rejects reports with an unsupported version", func() {
+			buf := new(bytes.Buffer)
+			err := MergeSarifReports(buf, []string{"old.sarif"}, strings.NewReader(`{"version":"1.0.0","runs":[]}`))
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("old.sarif"))
+		})
+
+		It("rejects runs without a tool driver name", func() {
+			buf := new(bytes.Buffer)
+			err := MergeSarifReports(buf, []string{"bad.sarif"}, strings.NewReader(`{"version":"2.1.0","runs":[{"results":[]}]}`))
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no tool driver name"))
+		})
+	})
+
+	Context("When reporting suggested fixes", func() {
+		It("emits the suggested fix in the JSON report", func() {
+			issue := createIssue("G716", gosec.GetCwe("G716"))
+			issue.WithAutofix("Sort keys after the range statement", "")
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "json", false, []string{}, []*gosec.Issue{&issue}, &gosec.Metrics{}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stripString(buf.String())).To(ContainSubstring(`"autofix":{"message":"Sortkeysaftertherangestatement"}`))
+		})
+
+		It("emits the mechanical fixes in the SARIF report", func() {
+			issue := createIssue("G716", gosec.GetCwe("G716"))
+			issue.Line = "3-5"
+			issue.WithAutofix("Sort keys after the range statement", "sorted\n")
+			unfixed := createIssue("G716", gosec.GetCwe("G716"))
+			unfixed.WithAutofix("Sort keys after the range statement", "")
+
+			report, err := convertToSarifReport([]string{"/home/src/project"}, &reportInfo{Issues: []*gosec.Issue{&issue, &unfixed}})
+			Expect(err).ShouldNot(HaveOccurred())
+			results := report.Runs[0].Results
+			Expect(results[1].Fixes).To(BeEmpty())
+			Expect(results[0].Fixes).To(HaveLen(1))
+			raw, err := json.Marshal(results[0].Fixes[0])
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(raw)).To(Equal(`{"description":{"text":"Sort keys after the range statement"},"artifactChanges":[{"artifactLocation":{"uri":"test.go"},"replacements":[{"deletedRegion":{"startLine":3,"endLine":5},"insertedContent":{"text":"sorted\n"}}]}]}`))
+		})
+	})
+
+	Context("When using a report template", func() {
+		writeTemplate := func(content string) string {
+			dir, err := ioutil.TempDir("", "gosec")
+			Expect(err).ShouldNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+			filename := filepath.Join(dir, "report.tmpl")
+			Expect(ioutil.WriteFile(filename, []byte(content), 0o600)).To(Succeed())
+			return filename
+		}
+
+		It("executes the template against the issues and metrics", func() {
+			filename := writeTemplate(`## gosec: {{ .Stats.NumFound }} issues, {{ index .Severity "HIGH" }} high
+{{ range .Issues }}- {{ .RuleID }} {{ .FileLocation }}: {{ .What }}
+{{ end }}`)
+			t, err := ParseTemplate(filename)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			first := createIssue("G101", gosec.GetCwe("798"))
+			second := createIssue("G401", gosec.GetCwe("326"))
+			second.Line = "12"
+			second.Severity = gosec.Medium
+			buf := new(bytes.Buffer)
+			err = CreateTemplateReport(buf, t, []*gosec.Issue{&first, &second}, &gosec.Metrics{NumFound: 2}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(Equal(`## gosec: 2 issues, 1 high
+- G101 /home/src/project/test.go:1: test
+- G401 /home/src/project/test.go:12: test
+`))
+		})
+
+		It("reports the templates which do not parse", func() {
+			filename := writeTemplate(`{{ range .Issues }}`)
+			_, err := ParseTemplate(filename)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("parsing the report template"))
+		})
+	})
+
+	Context("When using codeclimate", func() {
+		It("reports the issues in the CodeClimate format", func() {
+			first := createIssue("G101", gosec.GetCwe("798"))
+			first.Code = "1: password := \"f62e5bcda4fae4f82370da0c6f20697b8f8447ef\"\n2: fmt.Println(password)\n"
+			second := createIssue("G401", gosec.GetCwe("326"))
+			second.File = "/home/src/project/crypto.go"
+			second.Line = "11-12"
+			second.Severity = gosec.Medium
+			second.Code = "10: func sum() {\n11: \th := md5.New()\n12: \th.Write(data)\n13: }\n"
+			issues := []*gosec.Issue{&first, &second}
+
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "codeclimate", false, []string{"/home/src/project"}, issues, &gosec.Metrics{}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			golden, err := ioutil.ReadFile("testdata/codeclimate.golden.json")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(Equal(string(golden)))
+		})
+
+		It("keeps the fingerprints when unrelated lines shift", func() {
+			issue := createIssue("G401", gosec.GetCwe("326"))
+			issue.Line = "11"
+			issue.Code = "10: func sum() {\n11: \th := md5.New()\n12: }\n"
+			shifted := createIssue("G401", gosec.GetCwe("326"))
+			shifted.Line = "15"
+			shifted.Code = "14: \t// a comment added above\n15: \th := md5.New()\n16: \treturn\n"
+			other := createIssue("G401", gosec.GetCwe("326"))
+			other.Line = "11"
+			other.Code = "10: func sum() {\n11: \th := sha1.New()\n12: }\n"
+
+			ci, err := convertToCodeClimateIssues([]string{}, &reportInfo{Issues: []*gosec.Issue{&issue, &shifted, &other}})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ci[0].Fingerprint).To(Equal(ci[1].Fingerprint))
+			Expect(ci[0].Fingerprint).NotTo(Equal(ci[2].Fingerprint))
+		})
+	})
+
+	Context("When the issues are blamed", func() {
+		It("reports the last commit of the issues in the text format", func() {
+			issue := createIssue("G101", gosec.GetCwe("798"))
+			issue.Blame = &gosec.Blame{Commit: "3f1c2d4e", Author: "Alice Martin", Email: "alice@example.com"}
+			unblamed := createIssue("G401", gosec.GetCwe("326"))
+
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "text", false, []string{}, []*gosec.Issue{&issue, &unblamed}, &gosec.Metrics{}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring("(Confidence: HIGH, Severity: HIGH)\n  Last changed by Alice Martin in 3f1c2d4e\n"))
+			Expect(strings.Count(buf.String(), "Last changed by")).To(Equal(1))
+		})
+
+		It("reports the blame in the json format", func() {
+			issue := createIssue("G101", gosec.GetCwe("798"))
+			issue.Blame = &gosec.Blame{Commit: "3f1c2d4e", Author: "Alice Martin", Email: "alice@example.com"}
+
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "json", false, []string{}, []*gosec.Issue{&issue}, &gosec.Metrics{}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring(`"blame": {`))
+			Expect(buf.String()).To(ContainSubstring(`"commit": "3f1c2d4e"`))
+		})
+	})
+
+	Context("When the rules link their documentation", func() {
+		var issues []*gosec.Issue
+		BeforeEach(func() {
+			issue := createIssue("G702", gosec.GetCwe(""))
+			issue.HelpURL = "https://github.com/cosmos/gosec/blob/master/rules/sdk/README.md#determinism-guidelines"
+			undocumented := createIssue("G401", gosec.GetCwe("326"))
+			issues = []*gosec.Issue{&issue, &undocumented}
+		})
+
+		It("reports the link in the text format", func() {
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "text", false, []string{}, issues, &gosec.Metrics{}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring("(Confidence: HIGH, Severity: HIGH)\n  See https://github.com/cosmos/gosec/blob/master/rules/sdk/README.md#determinism-guidelines\n"))
+			Expect(strings.Count(buf.String(), "  See ")).To(Equal(1))
+		})
+
+		It("reports the link in the json format", func() {
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "json", false, []string{}, issues, &gosec.Metrics{}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+			var report reportInfo
+			Expect(json.Unmarshal(buf.Bytes(), &report)).To(Succeed())
+			Expect(report.Issues[0].HelpURL).To(Equal(issues[0].HelpURL))
+			Expect(report.Issues[1].HelpURL).To(BeEmpty())
+			Expect(strings.Count(buf.String(), `"help_url"`)).To(Equal(1))
+		})
+
+		It("reports the link as the help URI of the sarif rule", func() {
+			report, err := convertToSarifReport([]string{}, &reportInfo{Issues: issues, Stats: &gosec.Metrics{}})
+			Expect(err).ShouldNot(HaveOccurred())
+			rules := report.Runs[0].Tool.Driver.Rules
+			Expect(rules).To(HaveLen(2))
+			Expect(rules[0].HelpURI).To(Equal(issues[0].HelpURL))
+			Expect(rules[1].HelpURI).To(BeEmpty())
+		})
+	})
+
+	Context("When using github-actions", func() {
+		It("reports the issues as workflow commands", func() {
+			first := createIssue("G101", gosec.GetCwe("798"))
+			second := createIssue("G401", gosec.GetCwe("326"))
+			second.File = "/home/src/project/crypto, v2.go"
+			second.Line = "11-12"
+			second.Severity = gosec.Medium
+			second.What = "100% weak\r\nhash"
+			third := createIssue("G104", gosec.Cwe{})
+			third.Severity = gosec.Low
+			issues := []*gosec.Issue{&first, &second, &third}
+
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "github-actions", false, []string{"/home/src/project"}, issues, &gosec.Metrics{}, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			golden, err := ioutil.ReadFile("testdata/github-actions.golden.txt")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(buf.String()).To(Equal(string(golden)))
+		})
+	})
+
+	Context("When using html", func() {
+		It("should write a self-contained report embedding the issues as JSON", func() {
+			issues := []*gosec.Issue{
+				createIssueWithFileWhat("/home/src/project/keeper.go", "Insecure random number source (rand)"),
+				createIssueWithFileWhat("/home/src/project/types.go", "Casting integers </script>"),
+			}
+			issues[0].RuleID = "G404"
+			issues[1].RuleID = "G701"
+			issues[1].Severity = gosec.Medium
+			errors := map[string][]gosec.Error{"/home/src/project/msgs.go": {{Line: 3, Column: 1, Err: "expected declaration"}}}
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "html", false, []string{}, issues, &gosec.Metrics{NumFiles: 3, NumFound: 2}, errors)
+			Expect(err).ShouldNot(HaveOccurred())
+			report := buf.String()
+			Expect(report).To(ContainSubstring("G404"))
+			Expect(report).To(ContainSubstring("G701"))
+			Expect(report).NotTo(ContainSubstring("<link"))
+			Expect(report).NotTo(ContainSubstring("<script src"))
+
+			start := strings.Index(report, `<script type="application/json" id="gosec-report">`)
+			Expect(start).To(BeNumerically(">=", 0))
+			embedded := report[start:]
+			embedded = embedded[strings.Index(embedded, ">")+1 : strings.Index(embedded, "</script>")]
+			var data reportInfo
+			Expect(json.Unmarshal([]byte(embedded), &data)).To(Succeed())
+			Expect(data.Issues).To(HaveLen(2))
+			Expect(data.Issues[0].RuleID).To(Equal("G404"))
+			Expect(data.Issues[1].What).To(Equal("Casting integers </script>"))
+			Expect(data.Issues[1].Severity).To(Equal(gosec.Medium))
+			Expect(data.Errors).To(HaveKey("/home/src/project/msgs.go"))
+			Expect(data.Stats.NumFiles).To(Equal(3))
+		})
+	})
+
+	Context("When using ndjson", func() {
+		It("writes each issue on its own line followed by the summary", func() {
+			first := createIssue("G101", gosec.GetCwe("798"))
+			second := createIssue("G401", gosec.GetCwe("326"))
+			second.Severity = gosec.Medium
+			second.Line = "11"
+			issues := []*gosec.Issue{&first, &second}
+			metrics := &gosec.Metrics{NumFiles: 2, NumFound: 2}
+
+			buf := new(bytes.Buffer)
+			err := CreateReport(buf, "ndjson", false, []string{}, issues, metrics, map[string][]gosec.Error{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			Expect(lines).To(HaveLen(3))
+			for i, issue := range issues {
+				var decoded gosec.Issue
+				Expect(json.Unmarshal([]byte(lines[i]), &decoded)).To(Succeed())
+				Expect(decoded).To(Equal(*issue))
+			}
+			var summary ndjsonSummary
+			Expect(json.Unmarshal([]byte(lines[2]), &summary)).To(Succeed())
+			Expect(summary.Summary.Stats).To(Equal(metrics))
+		})
+
+		It("writes the streamed issues as lines which can be parsed independently", func() {
+			buf := new(bytes.Buffer)
+			writer := NewNDJSONWriter(buf)
+			done := make(chan struct{})
+			for i := 0; i < 8; i++ {
+				go func() {
+					defer GinkgoRecover()
+					issue := createIssue("G101", gosec.GetCwe("798"))
+					Expect(writer.WriteIssue(&issue)).To(Succeed())
+					done <- struct{}{}
+				}()
+			}
+			for i := 0; i < 8; i++ {
+				<-done
+			}
+			Expect(writer.WriteSummary(&gosec.Metrics{NumFound: 8}, nil)).To(Succeed())
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			Expect(lines).To(HaveLen(9))
+			for _, line := range lines[:8] {
+				var decoded gosec.Issue
+				Expect(json.Unmarshal([]byte(line), &decoded)).To(Succeed())
+				Expect(decoded.RuleID).To(Equal("G101"))
+				Expect(decoded.Severity).To(Equal(gosec.High))
+			}
+		})
+	})
 })
@@ -0,0 +1,73 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// githubActionsDataEscaper escapes the message of a workflow command
+var githubActionsDataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+// githubActionsPropertyEscaper escapes the properties of a workflow command,
+// which are also separated by commas and colons
+var githubActionsPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+func getGitHubActionsCommand(s string) string {
+	switch s {
+	case "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// reportGitHubActions writes each issue as a GitHub Actions workflow command,
+// which the runner turns into an annotation of the offending lines
+func reportGitHubActions(rootPaths []string, w io.Writer, data *reportInfo) error {
+	for _, issue := range data.Issues {
+		path := issue.File
+		for _, rootPath := range rootPaths {
+			if strings.HasPrefix(issue.File, rootPath) {
+				path = strings.Replace(issue.File, rootPath+"/", "", 1)
+			}
+		}
+
+		lines := strings.Split(issue.Line, "-")
+		properties := []string{
+			"file=" + githubActionsPropertyEscaper.Replace(path),
+			"line=" + lines[0],
+		}
+		if len(lines) > 1 {
+			properties = append(properties, "endLine="+lines[1])
+		}
+		title := "gosec " + issue.RuleID
+		if issue.Cwe.ID != "" {
+			title += fmt.Sprintf(" (CWE-%s)", issue.Cwe.ID)
+		}
+		properties = append(properties, "col="+issue.Col, "title="+githubActionsPropertyEscaper.Replace(title))
+
+		message := fmt.Sprintf("%s (Confidence: %s, Severity: %s)", issue.What, issue.Confidence, issue.Severity)
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", getGitHubActionsCommand(issue.Severity.String()),
+			strings.Join(properties, ","), githubActionsDataEscaper.Replace(message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package output
 
 import (
 	"encoding/xml"
-	htmlLib "html"
 	"strconv"
 
 	"github.com/cosmos/gosec/v2"
@@ -17,6 +16,7 @@ type testsuite struct {
 	XMLName   xml.Name   `xml:"testsuite"`
 	Name      string     `xml:"name,attr"`
 	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
 	Testcases []testcase `xml:"testcase"`
 }
 
@@ -29,7 +29,7 @@ type testcase struct {
 type failure struct {
 	XMLName xml.Name `xml:"failure"`
 	Message string   `xml:"message,attr"`
-	Text    string   `xml:",innerxml"`
+	Text    string   `xml:",cdata"`
 }
 
 func generatePlaintext(issue *gosec.Issue) string {
@@ -37,21 +37,22 @@ func generatePlaintext(issue *gosec.Issue) string {
 		"[" + issue.File + ":" + issue.Line + "] - " +
 		issue.What + " (Confidence: " + strconv.Itoa(int(issue.Confidence)) +
 		", Severity: " + strconv.Itoa(int(issue.Severity)) +
-		", CWE: " + issue.Cwe.ID + ")\n" + "> " + htmlLib.EscapeString(issue.Code)
+		", CWE: " + issue.Cwe.ID + ")\n" + "> " + issue.Code
 }
 
 func createJUnitXMLStruct(data *reportInfo) junitXMLReport {
 	var xmlReport junitXMLReport
 	testsuites := map[string]int{}
 
+	// The test cases are grouped into one test suite per rule.
 	for _, issue := range data.Issues {
-		index, ok := testsuites[issue.What]
+		index, ok := testsuites[issue.RuleID]
 		if !ok {
 			xmlReport.Testsuites = append(xmlReport.Testsuites, testsuite{
-				Name: issue.What,
+				Name: issue.RuleID,
 			})
 			index = len(xmlReport.Testsuites) - 1
-			testsuites[issue.What] = index
+			testsuites[issue.RuleID] = index
 		}
 		testcase := testcase{
 			Name: issue.File,
@@ -63,6 +64,7 @@ func createJUnitXMLStruct(data *reportInfo) junitXMLReport {
 
 		xmlReport.Testsuites[index].Testcases = append(xmlReport.Testsuites[index].Testcases, testcase)
 		xmlReport.Testsuites[index].Tests++
+		xmlReport.Testsuites[index].Failures++
 	}
 
 	return xmlReport
@@ -0,0 +1,73 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// ndjsonSummary is the last line of a ndjson report, it is told apart from the
+// issues by its single summary key
+type ndjsonSummary struct {
+	Summary struct {
+		Errors map[string][]gosec.Error `json:"Golang errors"`
+		Stats  *gosec.Metrics
+	} `json:"summary"`
+}
+
+// NDJSONWriter writes a report in the JSON Lines format, one JSON object per
+// issue followed by a summary line. Each line is written as soon as it is
+// available, so the issues can be streamed out while the scan is running.
+type NDJSONWriter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewNDJSONWriter creates a writer of ndjson lines to w
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{encoder: json.NewEncoder(w)}
+}
+
+// WriteIssue writes the issue as a single line
+func (n *NDJSONWriter) WriteIssue(issue *gosec.Issue) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.encoder.Encode(issue)
+}
+
+// WriteSummary writes the metrics and the golang errors of the scan as the
+// last line
+func (n *NDJSONWriter) WriteSummary(metrics *gosec.Metrics, errors map[string][]gosec.Error) error {
+	summary := &ndjsonSummary{}
+	summary.Summary.Errors = errors
+	summary.Summary.Stats = metrics
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.encoder.Encode(summary)
+}
+
+func reportNDJSON(w io.Writer, data *reportInfo) error {
+	writer := NewNDJSONWriter(w)
+	for _, issue := range data.Issues {
+		if err := writer.WriteIssue(issue); err != nil {
+			return err
+		}
+	}
+	return writer.WriteSummary(data.Stats, data.Errors)
+}
@@ -1,7 +1,9 @@
 package output
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
@@ -27,6 +29,7 @@ type sarifRule struct {
 	ShortDescription     *sarifMessage       `json:"shortDescription"`
 	FullDescription      *sarifMessage       `json:"fullDescription"`
 	Help                 *sarifMessage       `json:"help"`
+	HelpURI              string              `json:"helpUri,omitempty"`
 	Properties           *sarifProperties    `json:"properties"`
 	DefaultConfiguration *sarifConfiguration `json:"defaultConfiguration"`
 }
@@ -42,8 +45,8 @@ type sarifArtifactLocation struct {
 type sarifRegion struct {
 	StartLine   uint64 `json:"startLine"`
 	EndLine     uint64 `json:"endLine"`
-	StartColumn uint64 `json:"startColumn"`
-	EndColumn   uint64 `json:"endColumn"`
+	StartColumn uint64 `json:"startColumn,omitempty"`
+	EndColumn   uint64 `json:"endColumn,omitempty"`
 }
 
 type sarifPhysicalLocation struct {
@@ -59,12 +62,32 @@ type sarifMessage struct {
 	Text string `json:"text"`
 }
 
+type sarifArtifactContent struct {
+	Text string `json:"text"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   *sarifRegion          `json:"deletedRegion"`
+	InsertedContent *sarifArtifactContent `json:"insertedContent"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation *sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []*sarifReplacement    `json:"replacements"`
+}
+
+type sarifFix struct {
+	Description     *sarifMessage          `json:"description"`
+	ArtifactChanges []*sarifArtifactChange `json:"artifactChanges"`
+}
+
 type sarifResult struct {
 	RuleID    string           `json:"ruleId"`
 	RuleIndex int              `json:"ruleIndex"`
 	Level     sarifLevel       `json:"level"`
 	Message   *sarifMessage    `json:"message"`
 	Locations []*sarifLocation `json:"locations"`
+	Fixes     []*sarifFix      `json:"fixes,omitempty"`
 }
 
 type sarifDriver struct {
@@ -112,6 +135,7 @@ func buildSarifRule(issue *gosec.Issue) *sarifRule {
 		Help: &sarifMessage{
 			Text: fmt.Sprintf("%s\nSeverity: %s\nConfidence: %s\nCWE: %s", issue.What, issue.Severity.String(), issue.Confidence.String(), issue.Cwe.URL),
 		},
+		HelpURI: issue.HelpURL,
 		Properties: &sarifProperties{
 			Tags: []string{fmt.Sprintf("CWE-%s", issue.Cwe.ID), issue.Severity.String()},
 		},
@@ -143,6 +167,10 @@ func buildSarifLocation(issue *gosec.Issue, rootPaths []string) (*sarifLocation,
 		return nil, err
 	}
 
+	// Without root paths the files are written as they are
+	if len(rootPaths) == 0 {
+		filePath = issue.File
+	}
 	for _, rootPath := range rootPaths {
 		if strings.HasPrefix(issue.File, rootPath) {
 			filePath = strings.Replace(issue.File, rootPath+"/", "", 1)
@@ -166,6 +194,38 @@ func buildSarifLocation(issue *gosec.Issue, rootPaths []string) (*sarifLocation,
 	return location, nil
 }
 
+// buildSarifFixes return the SARIF fixes of the issue. SARIF requires each fix to
+// change an artifact, so only the mechanical fixes are reported
+func buildSarifFixes(issue *gosec.Issue, location *sarifLocation) []*sarifFix {
+	if issue.Autofix == nil || issue.Autofix.Replacement == "" {
+		return nil
+	}
+	region := location.PhysicalLocation.Region
+	return []*sarifFix{
+		{
+			Description: &sarifMessage{
+				Text: issue.Autofix.Message,
+			},
+			ArtifactChanges: []*sarifArtifactChange{
+				{
+					ArtifactLocation: location.PhysicalLocation.ArtifactLocation,
+					Replacements: []*sarifReplacement{
+						{
+							DeletedRegion: &sarifRegion{
+								StartLine: region.StartLine,
+								EndLine:   region.EndLine,
+							},
+							InsertedContent: &sarifArtifactContent{
+								Text: issue.Autofix.Replacement,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // From https://docs.oasis-open.org/sarif/sarif/v2.0/csprd02/sarif-v2.0-csprd02.html#_Toc10127839
 // * "warning": The rule specified by ruleId was evaluated and a problem was found.
 // * "error": The rule specified by ruleId was evaluated and a serious problem was found.
@@ -182,3 +242,70 @@ func getSarifLevel(s string) sarifLevel {
 		return sarifNote
 	}
 }
+
+// sarifRawReport is a SARIF report whose runs are kept verbatim, so that the
+// runs of other tools are merged without losing any of their metadata.
+type sarifRawReport struct {
+	Schema  string            `json:"$schema"`
+	Version string            `json:"version"`
+	Runs    []json.RawMessage `json:"runs"`
+}
+
+// sarifRunHeader holds the fields of a run required by the merge.
+type sarifRunHeader struct {
+	Tool *struct {
+		Driver *struct {
+			Name string `json:"name"`
+		} `json:"driver"`
+	} `json:"tool"`
+}
+
+// readSarifRuns validates a SARIF report and returns its runs.
+func readSarifRuns(name string, r io.Reader) ([]json.RawMessage, error) {
+	var report sarifRawReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode the SARIF report %s: %s", name, err)
+	}
+	if report.Version != "2.1.0" {
+		return nil, fmt.Errorf("SARIF report %s has unsupported version %q", name, report.Version)
+	}
+	for i, run := range report.Runs {
+		var header sarifRunHeader
+		if err := json.Unmarshal(run, &header); err != nil {
+			return nil, fmt.Errorf("failed to decode run %d of the SARIF report %s: %s", i, name, err)
+		}
+		if header.Tool == nil || header.Tool.Driver == nil || header.Tool.Driver.Name == "" {
+			return nil, fmt.Errorf("run %d of the SARIF report %s has no tool driver name", i, name)
+		}
+	}
+	return report.Runs, nil
+}
+
+// MergeSarifReports writes a single SARIF report containing the runs of all the
+// given reports in order. Each run is preserved as is, including the rules
+// metadata of its tool, while the report envelope is normalized to SARIF 2.1.0.
+func MergeSarifReports(w io.Writer, names []string, reports ...io.Reader) error {
+	sr := buildSarifReport()
+	merged := &sarifRawReport{
+		Schema:  sr.Schema,
+		Version: sr.Version,
+		Runs:    []json.RawMessage{},
+	}
+	for i, report := range reports {
+		name := strconv.Itoa(i)
+		if i < len(names) {
+			name = names[i]
+		}
+		runs, err := readSarifRuns(name, report)
+		if err != nil {
+			return err
+		}
+		merged.Runs = append(merged.Runs, runs...)
+	}
+	raw, err := json.MarshalIndent(merged, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(raw)
+	return err
+}
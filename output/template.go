@@ -14,383 +14,231 @@
 
 package output
 
+// html is a self-contained report, without any external stylesheet or script:
+// the report is injected as JSON in the gosec-report element and rendered in
+// the browser, the issues being grouped by file and filtered by severity and
+// confidence.
 const html = `
 <!doctype html>
 <html lang="en">
 <head>
   <meta charset="utf-8">
-  <title>Go AST Scanner</title>
-  <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/bulma/0.2.1/css/bulma.min.css" integrity="sha256-DRcOKg8NK1KkSkcymcGmxOtS/lAn0lHWJXRa15gMHHk=" crossorigin="anonymous"/>
-  <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/react/15.3.2/react.min.js" integrity="sha256-cLWs9L+cjZg8CjGHMpJqUgKKouPlmoMP/0wIdPtaPGs=" crossorigin="anonymous"></script>
-  <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/react/15.3.2/react-dom.min.js" integrity="sha256-JIW8lNqN2EtqC6ggNZYnAdKMJXRQfkPMvdRt+b0/Jxc=" crossorigin="anonymous"></script>
-  <script type="text/javascript" src="https://cdnjs.cloudflare.com/ajax/libs/babel-standalone/6.17.0/babel.min.js" integrity="sha256-1IWWLlCKFGFj/cjryvC7GDF5wRYnf9tSvNVVEj8Bm+o=" crossorigin="anonymous"></script>
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>gosec report</title>
   <style>
-    div.issue div.tag, div.panel-block input[type="checkbox"] {
-      margin-right: 0.5em;
+    body {
+      margin: 0;
+      font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+      color: #24292f;
+      background: #f6f8fa;
+    }
+
+    header, main {
+      max-width: 72em;
+      margin: 0 auto;
+      padding: 1em 1.5em;
+    }
+
+    header h1 {
+      margin: 0 0 0.25em 0;
+      font-size: 1.5em;
+    }
+
+    .summary, .empty {
+      color: #57606a;
     }
-    
-    label.disabled {
-      text-decoration: line-through;
+
+    .filters {
+      display: flex;
+      flex-wrap: wrap;
+      gap: 1.5em;
+      margin-top: 1em;
+    }
+
+    .filters fieldset {
+      border: 1px solid #d0d7de;
+      border-radius: 6px;
+      background: #fff;
     }
-    
-    nav.panel select {
-      width: 100%;
+
+    .filters label {
+      margin-right: 0.75em;
     }
 
-    .break-word {
+    details.file {
+      margin-bottom: 1em;
+      border: 1px solid #d0d7de;
+      border-radius: 6px;
+      background: #fff;
+    }
+
+    details.file > summary {
+      padding: 0.75em 1em;
+      font-weight: 600;
       word-wrap: break-word;
+      cursor: pointer;
+    }
+
+    details.issue {
+      border-top: 1px solid #d0d7de;
+    }
+
+    details.issue > summary {
+      padding: 0.5em 1em;
+      cursor: pointer;
+    }
+
+    .tag {
+      display: inline-block;
+      min-width: 4.5em;
+      margin-right: 0.5em;
+      padding: 0 0.5em;
+      border-radius: 1em;
+      font-size: 0.8em;
+      text-align: center;
+      color: #fff;
+      background: #6e7781;
+    }
+
+    .tag.HIGH {
+      background: #cf222e;
+    }
+
+    .tag.MEDIUM {
+      background: #bf8700;
+    }
+
+    .rule {
+      margin-right: 0.5em;
+      font-family: monospace;
+      font-weight: 600;
+    }
+
+    pre {
+      margin: 0 1em 1em 1em;
+      padding: 0.75em;
+      overflow-x: auto;
+      background: #f6f8fa;
+      border-radius: 6px;
+    }
+
+    .errors {
+      color: #cf222e;
     }
   </style>
 </head>
 <body>
-  <section class="section">
-    <div class="container">
-      <div id="content"></div>
+  <header>
+    <h1>gosec report</h1>
+    <div class="summary" id="summary"></div>
+    <div class="filters">
+      <fieldset id="severity">
+        <legend>Severity</legend>
+      </fieldset>
+      <fieldset id="confidence">
+        <legend>Confidence</legend>
+      </fieldset>
     </div>
-  </section>
+  </header>
+  <main id="content"></main>
+  <script type="application/json" id="gosec-report">{{ . }}</script>
   <script>
-    var data = {{ . }};
-  </script>
-  <script type="text/babel">
-    var IssueTag = React.createClass({
-      render: function() {
-        var level = ""
-        if (this.props.level === "HIGH") {
-          level = "is-danger";
+    (function() {
+      var report = JSON.parse(document.getElementById("gosec-report").textContent);
+      var issues = report.Issues || [];
+      var levels = ["HIGH", "MEDIUM", "LOW"];
+      var selected = {severity: {}, confidence: {}};
+
+      function element(tag, className, text) {
+        var node = document.createElement(tag);
+        if (className) {
+          node.className = className;
         }
-        if (this.props.level === "MEDIUM") {
-          level = "is-warning";
+        if (text !== undefined) {
+          node.textContent = text;
         }
-        return (
-          <div className="tag { level }">
-            { this.props.label }: { this.props.level }
-          </div>
-        );
+        return node;
       }
-    });
-    
-    var Issue = React.createClass({
-      render: function() {
-        return (
-          <div className="issue box">
-            <div className="is-pulled-right">
-              <IssueTag label="Severity" level={ this.props.data.severity }/>
-              <IssueTag label="Confidence" level={ this.props.data.confidence }/>
-            </div>
-            <p>
-              <strong className="break-word">
-                { this.props.data.file } (line { this.props.data.line })
-              </strong>
-              <br/>
-              { this.props.data.details }
-            </p>
-            <figure className="highlight">
-              <pre>
-                <code className="golang hljs">
-                  { this.props.data.code }
-                </code>
-              </pre>
-            </figure>
-          </div>
-        );
+
+      function filter(name) {
+        var fieldset = document.getElementById(name);
+        levels.forEach(function(level) {
+          var count = issues.filter(function(issue) { return issue[name] === level; }).length;
+          var input = element("input");
+          input.type = "checkbox";
+          input.checked = true;
+          input.disabled = count === 0;
+          input.addEventListener("change", function() {
+            selected[name][level] = input.checked;
+            render();
+          });
+          selected[name][level] = true;
+          var label = element("label");
+          label.appendChild(input);
+          label.appendChild(document.createTextNode(" " + level.charAt(0) + level.slice(1).toLowerCase() + " (" + count + ")"));
+          fieldset.appendChild(label);
+        });
       }
-    });
-    
-    var Stats = React.createClass({
-      render: function() {
-        return (
-          <p className="help">
-            Scanned { this.props.data.Stats.files.toLocaleString() } files
-            with { this.props.data.Stats.lines.toLocaleString() } lines of code.
-          </p>
-        );
+
+      function renderIssue(issue) {
+        var details = element("details", "issue");
+        var summary = element("summary");
+        summary.appendChild(element("span", "tag " + issue.severity, issue.severity));
+        summary.appendChild(element("span", "rule", issue.rule_id));
+        summary.appendChild(document.createTextNode("line " + issue.line + ": " + issue.details));
+        details.appendChild(summary);
+        details.appendChild(element("pre", "", issue.code));
+        return details;
       }
-    });
-    
-    var Issues = React.createClass({
-      render: function() {
-        if (this.props.data.Stats.files === 0) {
-          return (
-            <div className="notification">
-              No source files found. Do you even Go?
-            </div>
-          );
-        }
-    
-        if (this.props.data.Issues.length === 0) {
-          return (
-            <div>
-              <div className="notification">
-                Awesome! No issues found!
-              </div>
-              <Stats data={ this.props.data } />
-            </div>
-          );
-        }
-    
-        var issues = this.props.data.Issues
-          .filter(function(issue) {
-            return this.props.severity.includes(issue.severity);
-          }.bind(this))
-          .filter(function(issue) {
-            return this.props.confidence.includes(issue.confidence);
-          }.bind(this))
-          .filter(function(issue) {
-            if (this.props.issueType) {
-              return issue.details.toLowerCase().startsWith(this.props.issueType.toLowerCase());
-            } else {
-              return true
-            }
-          }.bind(this))
-          .map(function(issue) {
-            return (<Issue data={issue} />);
-          }.bind(this));
-    
-        if (issues.length === 0) {
-          return (
-            <div>
-              <div className="notification">
-                No issues matched given filters
-                (of total { this.props.data.Issues.length } issues).
-              </div>
-              <Stats data={ this.props.data } />
-            </div>
-          );
-        }
-    
-        return (
-          <div className="issues">
-            { issues }
-            <Stats data={ this.props.data } />
-          </div>
-        );
+
+      function renderErrors(content) {
+        var errors = report["Golang errors"] || {};
+        Object.keys(errors).sort().forEach(function(file) {
+          errors[file].forEach(function(err) {
+            content.appendChild(element("p", "errors", file + ":" + err.line + ":" + err.column + ": " + err.error));
+          });
+        });
       }
-    });
-    
-    var LevelSelector = React.createClass({
-      handleChange: function(level) {
-        return function(e) {
-          var updated = this.props.selected
-            .filter(function(item) { return item != level; });
-          if (e.target.checked) {
-            updated.push(level);
+
+      function render() {
+        var content = document.getElementById("content");
+        content.textContent = "";
+        renderErrors(content);
+
+        var files = {};
+        var shown = 0;
+        issues.forEach(function(issue) {
+          if (!selected.severity[issue.severity] || !selected.confidence[issue.confidence]) {
+            return;
           }
-          this.props.onChange(updated);
-        }.bind(this);
-      },
-      render: function() {
-        var highDisabled = !this.props.available.includes("HIGH");
-        var mediumDisabled = !this.props.available.includes("MEDIUM");
-        var lowDisabled = !this.props.available.includes("LOW");
-        var on = "", off = "disabled";
-        var HIGH = "HIGH", MEDIUM = "MEDIUM", LOW = "LOW";
-        return (
-          <span>
-            <label className="label checkbox { (highDisabled ? off : on )}">
-              <input
-                type="checkbox"
-                checked={ this.props.selected.includes(HIGH) }
-                disabled={ highDisabled }
-                onChange={ this.handleChange(HIGH) }/>
-              High
-            </label>
-            <label className="label checkbox {( mediumDisabled ? off : on )}">
-              <input
-                type="checkbox"
-                checked={ this.props.selected.includes(MEDIUM) }
-                disabled={ mediumDisabled }
-                onChange={ this.handleChange(MEDIUM) }/>
-              Medium
-            </label>
-            <label className="label checkbox {( lowDisabled ? off : on )}">
-              <input
-                type="checkbox"
-                checked={ this.props.selected.includes(LOW) }
-                disabled={ lowDisabled }
-                onChange={ this.handleChange(LOW) }/>
-              Low
-            </label>
-          </span>
-        );
-      }
-    });
-    
-    var Navigation = React.createClass({
-      updateSeverity: function(vals) {
-        this.props.onSeverity(vals);
-      },
-      updateConfidence: function(vals) {
-        this.props.onConfidence(vals);
-      },
-      updateIssueType: function(e) {
-        if (e.target.value == "all") {
-          this.props.onIssueType(null);
-        } else {
-          this.props.onIssueType(e.target.value);
-        }
-      },
-      render: function() {
-        var issueTypes = this.props.allIssueTypes
-          .map(function(it) {
-            var matches = this.props.issueType == it
-            return (
-              <option value={ it } selected={ matches }>
-                { it }
-              </option>
-            );
-          }.bind(this));
-        return (
-          <nav className="panel">
-            <div className="panel-heading">
-              Filters
-            </div>
-            <div className="panel-block">
-              <strong>
-                Severity
-              </strong>
-            </div>
-            <div className="panel-block">
-              <LevelSelector 
-                selected={ this.props.severity }
-                available={ this.props.allSeverities }
-                onChange={ this.updateSeverity } />
-            </div>
-            <div className="panel-block">
-              <strong>
-                Confidence
-              </strong>
-            </div>
-            <div className="panel-block">
-              <LevelSelector
-                selected={ this.props.confidence }
-                available={ this.props.allConfidences }
-                onChange={ this.updateConfidence } />
-            </div>
-            <div className="panel-block">
-              <strong>
-                Issue Type
-              </strong>
-            </div>
-            <div className="panel-block">
-              <select onChange={ this.updateIssueType }>
-                <option value="all" selected={ !this.props.issueType }>
-                  (all)
-                </option>
-                { issueTypes }
-              </select>
-            </div>
-          </nav>
-        );
-      }
-    });
-    var IssueBrowser = React.createClass({
-      getInitialState: function() {
-        return {};
-      },
-      componentWillMount: function() {
-        this.updateIssues(this.props.data);
-      },
-      handleSeverity: function(val) {
-        this.updateIssueTypes(this.props.data.Issues, val, this.state.confidence);
-        this.setState({severity: val});
-      },
-      handleConfidence: function(val) {
-        this.updateIssueTypes(this.props.data.Issues, this.state.severity, val);
-        this.setState({confidence: val});
-      },
-      handleIssueType: function(val) {
-        this.setState({issueType: val});
-      },
-      updateIssues: function(data) {
-        if (!data) {
-          this.setState({data: data});
-          return;
-        }
-        var allSeverities = data.Issues
-          .map(function(issue) {
-            return issue.severity
-          })
-          .sort()
-          .filter(function(item, pos, ary) {
-            return !pos || item != ary[pos - 1];
-          });
-        var allConfidences = data.Issues
-          .map(function(issue) {
-            return issue.confidence
-          })
-          .sort()
-          .filter(function(item, pos, ary) {
-            return !pos || item != ary[pos - 1];
-          });
-        var selectedSeverities = allSeverities;
-        var selectedConfidences = allConfidences;
-        this.updateIssueTypes(data.Issues, selectedSeverities, selectedConfidences);
-        this.setState({
-          data: data,
-          severity: selectedSeverities,
-          allSeverities: allSeverities,
-          confidence: selectedConfidences,
-          allConfidences: allConfidences,
-          issueType: null
+          (files[issue.file] = files[issue.file] || []).push(issue);
+          shown++;
         });
-      },
-      updateIssueTypes: function(issues, severities, confidences) {
-        var allTypes = issues
-          .filter(function(issue) {
-            return severities.includes(issue.severity);
-          })
-          .filter(function(issue) {
-            return confidences.includes(issue.confidence);
-          })
-          .map(function(issue) {
-            return issue.details;
-          })
-          .sort()
-          .filter(function(item, pos, ary) {
-            return !pos || item != ary[pos - 1];
+
+        Object.keys(files).sort().forEach(function(file) {
+          var details = element("details", "file");
+          details.open = true;
+          details.appendChild(element("summary", "", file + " (" + files[file].length + ")"));
+          files[file].forEach(function(issue) {
+            details.appendChild(renderIssue(issue));
           });
-    
-        if (this.state.issueType && !allTypes.includes(this.state.issueType)) {
-          this.setState({issueType: null});
+          content.appendChild(details);
+        });
+
+        if (issues.length === 0) {
+          content.appendChild(element("p", "empty", "No issues found."));
+        } else if (shown === 0) {
+          content.appendChild(element("p", "empty", "No issues match the filters, of " + issues.length + " issues."));
         }
-        
-        this.setState({allIssueTypes: allTypes});
-      },
-      render: function() {
-        return (
-          <div className="content">
-            <div className="columns">
-              <div className="column is-one-quarter">
-                <Navigation
-                  severity={ this.state.severity } 
-                  confidence={ this.state.confidence }
-                  issueType={ this.state.issueType }
-                  allSeverities={ this.state.allSeverities } 
-                  allConfidences={ this.state.allConfidences }
-                  allIssueTypes={ this.state.allIssueTypes }
-                  onSeverity={ this.handleSeverity } 
-                  onConfidence={ this.handleConfidence } 
-                  onIssueType={ this.handleIssueType }
-                />
-              </div>
-              <div className="column is-three-quarters">
-                <Issues
-                  data={ this.props.data }
-                  severity={ this.state.severity }
-                  confidence={ this.state.confidence }
-                  issueType={ this.state.issueType }
-                />
-              </div>
-            </div>
-          </div>
-        );
       }
-    });
 
-    ReactDOM.render(
-      <IssueBrowser data={ data } />,
-      document.getElementById("content")
-    );
+      var stats = report.Stats || {};
+      document.getElementById("summary").textContent = "Scanned " + (stats.files || 0).toLocaleString() + " files with " +
+        (stats.lines || 0).toLocaleString() + " lines of code, found " + issues.length + " issues.";
+      filter("severity");
+      filter("confidence");
+      render();
+    })();
   </script>
 </body>
 </html>`
@@ -15,15 +15,22 @@ package gosec
 import (
 	"go/ast"
 	"reflect" // #nosec G702
+	"sort"
 )
 
-// The Rule interface used by all rules supported by gosec.
+// The Rule interface used by all rules supported by gosec. It is also an
+// extension point: the rules defined in other modules implement it and are
+// loaded into the analyzer through their RuleBuilder, see examples/customrule.
+// Match is called with each AST node of the types the rule is registered for,
+// and returns the issue found on the node, if any.
 type Rule interface {
 	ID() string
 	Match(ast.Node, *Context) (*Issue, error)
 }
 
-// RuleBuilder is used to register a rule definition with the analyzer
+// RuleBuilder is used to register a rule definition with the analyzer. It
+// creates the rule with the given ID and configuration, and returns the types
+// of the AST nodes the rule is matched against, e.g. (*ast.CallExpr)(nil).
 type RuleBuilder func(id string, c Config) (Rule, []ast.Node)
 
 // A RuleSet maps lists of rules to the type of AST node they should be run on.
@@ -57,3 +64,21 @@ func (r RuleSet) RegisteredFor(n ast.Node) []Rule {
 	}
 	return []Rule{}
 }
+
+// Rules returns the registered rules sorted by ID, each rule once whatever the
+// number of node types it is registered for
+func (r RuleSet) Rules() []Rule {
+	// the rules are told apart by ID, a rule of a non comparable type cannot be a map key
+	seen := make(map[string]bool)
+	var rules []Rule
+	for _, registered := range r {
+		for _, rule := range registered {
+			if !seen[rule.ID()] {
+				seen[rule.ID()] = true
+				rules = append(rules, rule)
+			}
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID() < rules[j].ID() })
+	return rules
+}
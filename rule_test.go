@@ -26,6 +26,32 @@ func (m *mockrule) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
 	return nil, m.err
 }
 
+type metarule struct {
+	gosec.MetaData
+}
+
+func (m *metarule) ID() string {
+	return m.MetaData.ID
+}
+
+func (m *metarule) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	return nil, nil
+}
+
+// valuerule is a rule of a non comparable type, such as the rules of the plugins
+type valuerule struct {
+	id    string
+	paths []string
+}
+
+func (v valuerule) ID() string {
+	return v.id
+}
+
+func (v valuerule) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	return nil, nil
+}
+
 var _ = Describe("Rule", func() {
 
 	Context("when using a ruleset", func() {
@@ -84,6 +110,30 @@ var _ = Describe("Rule", func() {
 			Expect(ruleset.RegisteredFor(registeredNode)).Should(ContainElement(dummyIssueRule))
 		})
 
+		It("should list each registered rule once", func() {
+			ruleset.Register(dummyIssueRule, (*ast.CallExpr)(nil), (*ast.AssignStmt)(nil))
+			Expect(ruleset.Rules()).Should(Equal([]gosec.Rule{dummyIssueRule}))
+		})
+
+		It("should list each registered rule of a non comparable type once", func() {
+			rule := valuerule{id: "G999", paths: []string{"example.com/keeper"}}
+			ruleset.Register(rule, (*ast.CallExpr)(nil), (*ast.AssignStmt)(nil))
+			rules := ruleset.Rules()
+			Expect(rules).Should(HaveLen(1))
+			Expect(rules[0].ID()).Should(Equal("G999"))
+		})
+
+		It("should only provide the metadata of the rules embedding it", func() {
+			_, ok := gosec.RuleMetaData(dummyIssueRule)
+			Expect(ok).Should(BeFalse())
+
+			rule := &metarule{MetaData: gosec.MetaData{ID: "G101", Severity: gosec.High, Confidence: gosec.Low}}
+			meta, ok := gosec.RuleMetaData(rule)
+			Expect(ok).Should(BeTrue())
+			Expect(meta.Severity).Should(Equal(gosec.High))
+			Expect(meta.Confidence).Should(Equal(gosec.Low))
+		})
+
 	})
 
 })
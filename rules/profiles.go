@@ -0,0 +1,85 @@
+// (c) Copyright 2016 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+const (
+	// ProfileStrict enables all the determinism rules, including the opt-in
+	// ones, and reports their issues as high severity.
+	ProfileStrict = "strict"
+
+	// ProfileStandard runs the rules with their default settings.
+	ProfileStandard = "standard"
+
+	// ProfileRelaxed disables the heuristic rules, which report their issues
+	// with less than a high confidence.
+	ProfileRelaxed = "relaxed"
+
+	// ProfileCritical only keeps the rules reporting their issues with a high
+	// severity and a high confidence, e.g. to audit the vendored dependencies.
+	ProfileCritical = "critical"
+)
+
+// determinismRules lists the rules catching the sources of non determinism,
+// which make the nodes of a chain compute different states.
+var determinismRules = []string{
+	"G702", "G705", "G706", "G707", "G710", "G711", "G713", "G716", "G722", "G723", "G726",
+	"G728", "G729", "G730", "G731", "G732", "G733", "G736", "G739", "G744", "G746", "G753", "G755",
+}
+
+// Profile returns the preset configuration of the named profile for the rules
+// of the definitions, which the configuration of the user overrides.
+func Profile(name string, definitions RuleList) (gosec.Config, error) {
+	config := gosec.NewConfig()
+	switch name {
+	case ProfileStrict:
+		config[gosec.EnabledRules] = append([]string{}, determinismRules...)
+		for _, id := range determinismRules {
+			config[id] = map[string]interface{}{gosec.RuleSeverity: "high"}
+		}
+	case ProfileStandard:
+	case ProfileRelaxed:
+		config[gosec.DisabledRules] = disabledRules(definitions, func(meta gosec.MetaData) bool {
+			return meta.Confidence < gosec.High
+		})
+	case ProfileCritical:
+		config[gosec.DisabledRules] = disabledRules(definitions, func(meta gosec.MetaData) bool {
+			return meta.Severity < gosec.High || meta.Confidence < gosec.High
+		})
+	default:
+		return nil, fmt.Errorf("unknown profile %q, valid options are: %s, %s, %s, %s", name, ProfileStrict, ProfileStandard, ProfileRelaxed, ProfileCritical)
+	}
+	return config, nil
+}
+
+// disabledRules returns the sorted IDs of the rules of the definitions whose
+// metadata are disabled by the profile
+func disabledRules(definitions RuleList, disable func(gosec.MetaData) bool) []string {
+	var disabled []string
+	for id, build := range definitions.Builders() {
+		rule, _ := build(id, gosec.NewConfig())
+		if meta, ok := gosec.RuleMetaData(rule); ok && disable(meta) {
+			disabled = append(disabled, id)
+		}
+	}
+	sort.Strings(disabled)
+	return disabled
+}
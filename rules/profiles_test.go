@@ -0,0 +1,91 @@
+package rules_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cosmos/gosec/v2"
+	"github.com/cosmos/gosec/v2/rules"
+	"github.com/cosmos/gosec/v2/testutils"
+)
+
+var _ = Describe("Rule profiles", func() {
+	var (
+		definitions rules.RuleList
+		scan        func(gosec.Config) []*gosec.Issue
+	)
+
+	BeforeEach(func() {
+		definitions = rules.Generate()
+		scan = func(config gosec.Config) []*gosec.Issue {
+			logger, _ := testutils.NewLogger()
+			analyzer := gosec.NewAnalyzer(config, false, logger)
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G705")).Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("sample.go", testutils.SampleCodeMapRangingNonDeterministic[0].Code[0])
+			Expect(pkg.Build()).Should(Succeed())
+			Expect(analyzer.Process(nil, pkg.Path)).Should(Succeed())
+			issues, _, _ := analyzer.Report()
+			return issues
+		}
+	})
+
+	It("should enable the map iteration rule in the strict profile", func() {
+		config, err := rules.Profile(rules.ProfileStrict, definitions)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(config.GetEnabledRules()).Should(ContainElements("G705", "G710", "G746"))
+		Expect(config.GetDisabledRules()).Should(BeEmpty())
+		severity, ok, err := config.GetRuleSeverity("G710")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ok).Should(BeTrue())
+		Expect(severity).Should(Equal(gosec.High))
+
+		issues := scan(config)
+		Expect(issues).ShouldNot(BeEmpty())
+		for _, issue := range issues {
+			Expect(issue.RuleID).Should(Equal("G705"))
+			Expect(issue.Severity).Should(Equal(gosec.High))
+		}
+	})
+
+	It("should run the enabled opt-in rules in the strict profile", func() {
+		config, err := rules.Profile(rules.ProfileStrict, definitions)
+		Expect(err).ShouldNot(HaveOccurred())
+		enabled := rules.Generate(rules.NewOptInFilter(config.GetEnabledRules()...))
+		Expect(enabled).Should(HaveKey("G710"))
+		Expect(enabled).ShouldNot(HaveKey("G745"))
+	})
+
+	It("should keep the default settings in the standard profile", func() {
+		config, err := rules.Profile(rules.ProfileStandard, definitions)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(config).Should(Equal(gosec.NewConfig()))
+
+		Expect(scan(config)).ShouldNot(BeEmpty())
+	})
+
+	It("should disable the map iteration rule in the relaxed profile", func() {
+		config, err := rules.Profile(rules.ProfileRelaxed, definitions)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(config.GetDisabledRules()).Should(ContainElement("G705"))
+		Expect(config.GetDisabledRules()).Should(ContainElement("G101"))
+		Expect(config.GetDisabledRules()).ShouldNot(ContainElement("G702"))
+
+		Expect(scan(config)).Should(BeEmpty())
+	})
+
+	It("should only keep the high severity and high confidence rules in the critical profile", func() {
+		config, err := rules.Profile(rules.ProfileCritical, definitions)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(config.GetDisabledRules()).Should(ContainElements("G101", "G401", "G705"))
+		Expect(config.GetDisabledRules()).ShouldNot(ContainElement("G708"))
+
+		Expect(scan(config)).Should(BeEmpty())
+	})
+
+	It("should fail on an unknown profile", func() {
+		_, err := rules.Profile("paranoid", definitions)
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -66,6 +66,34 @@ func NewRuleFilter(action bool, ruleIDs ...string) RuleFilter {
 	}
 }
 
+// optInRules lists the rules which are too noisy to run by default, they only run
+// when explicitly included.
+var optInRules = map[string]bool{
+	"G710": true,
+	"G722": true,
+	"G726": true,
+	"G730": true,
+	"G745": true,
+	"G746": true,
+}
+
+// IsOptIn returns true if the rule only runs when it is explicitly included.
+func IsOptIn(ruleID string) bool {
+	return optInRules[ruleID]
+}
+
+// NewOptInFilter excludes the opt-in rules but the enabled ones, for when no
+// rules are explicitly included.
+func NewOptInFilter(enabled ...string) RuleFilter {
+	enabledRules := make(map[string]bool)
+	for _, rule := range enabled {
+		enabledRules[rule] = true
+	}
+	return func(rule string) bool {
+		return IsOptIn(rule) && !enabledRules[rule]
+	}
+}
+
 // Generate the list of rules to use
 func Generate(filters ...RuleFilter) RuleList {
 	rules := []RuleDefinition{
@@ -117,12 +145,68 @@ func Generate(filters ...RuleFilter) RuleList {
 		{"G703", "Errors that don't result in rollback", sdk.NewErrorNotPropagated},
 		{"G704", "Strconv invalid bitSize and cast", sdk.NewStrconvIntBitSizeOverflow},
 		{"G705", "Iterating over maps undeterministically", sdk.NewMapRangingCheck},
+		{"G706", "Hashing or encoding map iterations in genesis", sdk.NewGenesisMapHash},
+		{"G707", "Iterating over sync.Map undeterministically", sdk.NewSyncMapRangeCheck},
+		{"G708", "Weak random source used for cryptographic material", sdk.NewWeakRandomCrypto},
+		{"G709", "Unhandled errors of crypto and encoding operations", sdk.NewUnhandledCryptoErrors},
+		{"G710", "Order dependent iota constants", sdk.NewIotaEnumCheck},
+		{"G711", "Hashing map iterations undeterministically", sdk.NewMapRangeHashCheck},
+		{"G712", "Deferred Close discarding its error", sdk.NewDeferredCloseCheck},
+		{"G713", "JSON marshaling of maps with non-string keys", sdk.NewJSONMapKeysCheck},
+		{"G714", "Recovered panics which are swallowed", sdk.NewSwallowedRecoverCheck},
+		{"G715", "Import blocklist for testing packages in non-test files", sdk.NewTestingImport},
+		{"G716", "Returning unsorted slices built from map iterations", sdk.NewUnsortedMapAppendCheck},
+		{"G717", "Audit each use of unsafe", sdk.NewUnsafeUsage},
+		{"G718", "Mutable package level variables", sdk.NewMutableGlobalCheck},
+		{"G719", "Narrowing conversions of 64-bit integers", sdk.NewNarrowingConversionCheck},
+		{"G720", "Use of reflect.DeepEqual", sdk.NewDeepEqualCheck},
+		{"G721", "Calls of os.Exit outside of the commands", sdk.NewOsExitCheck},
+		{"G722", "Formatting of values holding maps used as keys", sdk.NewMapFormatCheck},
+		{"G723", "Use of math/big.Float in state code", sdk.NewBigFloatCheck},
+		{"G724", "Lazy initialization of package level variables without sync.Once", sdk.NewLazyInitCheck},
+		{"G725", "Contexts created with context.Background or context.TODO", sdk.NewContextBackgroundCheck},
+		{"G726", "Clearing maps with the clear builtin", sdk.NewClearMapCheck},
+		{"G727", "Appending to package level slices shared by the results", sdk.NewSharedAppendCheck},
+		{"G728", "JSON decoding of numbers into floats", sdk.NewJSONFloatCheck},
+		{"G729", "Map keys holding pointers", sdk.NewPointerMapKeysCheck},
+		{"G730", "Sorting with a less function leaving ties", sdk.NewSortTiesCheck},
+		{"G731", "Reads from the filesystem in state code", sdk.NewFilesystemReadCheck},
+		{"G732", "Import blocklist for the other random number packages", sdk.NewRandImport},
+		{"G733", "Ranging over channels to build the state", sdk.NewChannelRangeCheck},
+		{"G734", "Keeper methods mutating their map or slice parameters", sdk.NewParamMutationCheck},
+		{"G735", "Network or disk I/O in init functions", sdk.NewInitIOCheck},
+		{"G736", "Unicode case mappings of stored or hashed strings", sdk.NewLocaleStringsCheck},
+		{"G737", "Comparisons of secrets which are not constant time", sdk.NewSecretCompareCheck},
+		{"G738", "Releases of resources deferred inside loops", sdk.NewLoopDeferCheck},
+		{"G739", "Rounding of floats with the math package", sdk.NewFloatRoundingCheck},
+		{"G740", "Conversions between signed and unsigned integers", sdk.NewSignConversionCheck},
+		{"G741", "Blocking forever outside of the main packages", sdk.NewBlockForeverCheck},
+		{"G742", "Package level calls assigned to the blank identifier", sdk.NewBlankInitCheck},
+		{"G743", "Exported methods returning internal slices", sdk.NewReturnedFieldCheck},
+		{"G744", "Calls tuning the runtime in state code", sdk.NewRuntimeCallCheck},
+		{"G745", "Recursive handlers without a depth bound", sdk.NewRecursionCheck},
+		{"G746", "Shared state accessed concurrently without a lock", sdk.NewSharedStateCheck},
+		{"G747", "Divisions of big.Int discarding their remainder", sdk.NewBigIntDivisionCheck},
+		{"G748", "Hardcoded mnemonics and private keys", sdk.NewHardcodedMnemonicCheck},
+		{"G749", "Duplicate keys in map literals", sdk.NewDuplicateMapKeysCheck},
+		{"G750", "Shadowing of err dropping an outer error", sdk.NewShadowedErrCheck},
+		{"G751", "Context values with keys of a built-in or non-comparable type", sdk.NewContextValueKeysCheck},
+		{"G752", "Goroutines of the servers blocking without a case on ctx.Done()", sdk.NewGoroutineLeakCheck},
+		{"G753", "Use of the default HTTP client", sdk.NewDefaultHTTPClientCheck},
+		{"G754", "Calls of errors.As with a target which is not a pointer to an error", sdk.NewErrorsAsTargetCheck},
+		{"G755", "Timers and tickers in deterministic packages", sdk.NewTimerCheck},
 	}
 
+	return NewRuleList(rules, filters...)
+}
+
+// NewRuleList builds the list of the rule definitions which are not excluded
+// by the filters, e.g. of the rules defined outside of gosec
+func NewRuleList(definitions []RuleDefinition, filters ...RuleFilter) RuleList {
 	ruleMap := make(map[string]RuleDefinition)
 
 RULES:
-	for _, rule := range rules {
+	for _, rule := range definitions {
 		for _, filter := range filters {
 			if filter(rule.ID) {
 				continue RULES
@@ -1,7 +1,10 @@
 package rules_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"go/ast"
+	"go/types"
 	"log"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -9,18 +12,20 @@ import (
 
 	"github.com/cosmos/gosec/v2"
 	"github.com/cosmos/gosec/v2/rules"
+	"github.com/cosmos/gosec/v2/rules/sdk"
 	"github.com/cosmos/gosec/v2/testutils"
 )
 
 var _ = Describe("gosec rules", func() {
 
 	var (
-		logger    *log.Logger
-		config    gosec.Config
-		analyzer  *gosec.Analyzer
-		runner    func(string, []testutils.CodeSample)
-		buildTags []string
-		tests     bool
+		logger      *log.Logger
+		config      gosec.Config
+		analyzer    *gosec.Analyzer
+		runner      func(string, []testutils.CodeSample)
+		runBuilders func(map[string]gosec.RuleBuilder, []testutils.CodeSample)
+		buildTags   []string
+		tests       bool
 	)
 
 	BeforeEach(func() {
@@ -28,10 +33,13 @@ var _ = Describe("gosec rules", func() {
 		config = gosec.NewConfig()
 		analyzer = gosec.NewAnalyzer(config, tests, logger)
 		runner = func(rule string, samples []testutils.CodeSample) {
+			runBuilders(rules.Generate(rules.NewRuleFilter(false, rule)).Builders(), samples)
+		}
+		runBuilders = func(builders map[string]gosec.RuleBuilder, samples []testutils.CodeSample) {
 			for n, sample := range samples {
 				analyzer.Reset()
 				analyzer.SetConfig(sample.Config)
-				analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, rule)).Builders())
+				analyzer.LoadRules(builders)
 				pkg := testutils.NewTestPackage()
 				defer pkg.Close()
 				for i, code := range sample.Code {
@@ -95,6 +103,414 @@ var _ = Describe("gosec rules", func() {
 			runner("G705", testutils.SampleCodeMapRangingNonDeterministic)
 		})
 
+		It("should detect non-deterministic map iterations hashed in genesis", func() {
+			runner("G706", testutils.SampleCodeGenesisMapHash)
+		})
+
+		It("should detect non-deterministic sync.Map ranging", func() {
+			runner("G707", testutils.SampleCodeSyncMapRange)
+		})
+
+		It("should detect weak random used for cryptographic material", func() {
+			runner("G708", testutils.SampleCodeWeakRandomCrypto)
+		})
+
+		It("should detect unhandled errors of crypto and encoding operations", func() {
+			runner("G709", testutils.SampleCodeUnhandledCryptoErrors)
+		})
+
+		It("should detect blocklisted calls", func() {
+			runBuilders(map[string]gosec.RuleBuilder{
+				"blocklisted-calls": func(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+					return sdk.NewBlocklistedCalls(id, conf, map[string]string{
+						"math.Mod":         "Blocklisted call math.Mod",
+						"math/big.Int.Exp": "Blocklisted call math/big.Int.Exp",
+					})
+				},
+			}, testutils.SampleCodeBlocklistedCalls)
+		})
+
+		It("should detect order dependent iota constants", func() {
+			runner("G710", testutils.SampleCodeIotaEnum)
+		})
+
+		It("should leave out the opt-in rules with the opt-in filter", func() {
+			Expect(rules.Generate()).Should(HaveKey("G710"))
+			Expect(rules.Generate(rules.NewOptInFilter())).ShouldNot(HaveKey("G710"))
+		})
+
+		It("should detect map iterations written into a hash", func() {
+			runner("G711", testutils.SampleCodeMapRangeHash)
+		})
+
+		It("should detect deferred Close calls discarding their error", func() {
+			runner("G712", testutils.SampleCodeDeferredClose)
+		})
+
+		It("should detect JSON marshaling of maps with non-string keys", func() {
+			runner("G713", testutils.SampleCodeJSONMapKeys)
+		})
+
+		It("should detect recovered panics which are swallowed", func() {
+			runner("G714", testutils.SampleCodeSwallowedRecover)
+		})
+
+		It("should detect testing imports in non-test files", func() {
+			runner("G715", testutils.SampleCodeTestingImport)
+		})
+
+		It("should allow testing imports in test files", func() {
+			testAnalyzer := gosec.NewAnalyzer(gosec.NewConfig(), true, logger)
+			testAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G715")).Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("keeper.go", "package keeper\n\nfunc Add(a, b int) int { return a + b }\n")
+			pkg.AddFile("keeper_test.go", testutils.SampleCodeTestingImport[0].Code[0])
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = testAnalyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := testAnalyzer.Report()
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should detect unsorted slices built from map iterations being returned", func() {
+			runner("G716", testutils.SampleCodeUnsortedMapAppend)
+		})
+
+		It("should suggest sorting the unsorted slices built from map iterations", func() {
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G716")).Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("keys.go", `
+package keeper
+
+import "sort"
+
+var _ = sort.Strings
+
+func ExportKeys(balances map[string]int64) []string {
+	keys := make([]string, 0, len(balances))
+	for key := range balances {
+		keys = append(keys, key)
+	}
+	return keys
+}
+`)
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = analyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].Line).Should(Equal("10-12"))
+			Expect(issues[0].Autofix).Should(Equal(&gosec.Autofix{
+				Message:     "Sort keys after the range statement",
+				Replacement: "\tfor key := range balances {\n\t\tkeys = append(keys, key)\n\t}\n\tsort.Strings(keys)\n",
+			}))
+
+			raw, err := json.Marshal(issues[0])
+			Expect(err).ShouldNot(HaveOccurred())
+			decoded := struct {
+				Autofix *gosec.Autofix `json:"autofix"`
+			}{}
+			Expect(json.Unmarshal(raw, &decoded)).To(Succeed())
+			Expect(decoded.Autofix).Should(Equal(issues[0].Autofix))
+		})
+
+		It("should honor the disabled rules and blocklist entries", func() {
+			runner("G702", testutils.SampleCodeUnsafeImport)
+		})
+
+		It("should report the chain of imports reaching a blocklisted package", func() {
+			analyzer.SetConfig(gosec.Config{"G702": map[string]interface{}{"transitive": true}})
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G702")).Builders())
+			err := analyzer.Process(buildTags, "github.com/cosmos/gosec/v2/testdata/blocklist/ourpkg")
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].File).Should(HaveSuffix("ourpkg.go"))
+			Expect(issues[0].What).Should(Equal("Blocklisted import unsafe reached transitively: ourpkg -> " +
+				"github.com/cosmos/gosec/v2/testdata/blocklist/helper -> " +
+				"github.com/cosmos/gosec/v2/testdata/blocklist/thirdparty -> unsafe"))
+
+			analyzer.Reset()
+			analyzer.SetConfig(gosec.NewConfig())
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G702")).Builders())
+			err = analyzer.Process(buildTags, "github.com/cosmos/gosec/v2/testdata/blocklist/ourpkg")
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ = analyzer.Report()
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should detect each use of unsafe", func() {
+			runner("G717", testutils.SampleCodeUnsafeUsage)
+		})
+
+		It("should detect mutable package level variables", func() {
+			runner("G718", testutils.SampleCodeMutableGlobal)
+		})
+
+		It("should detect narrowing conversions of 64-bit integers", func() {
+			runner("G719", testutils.SampleCodeNarrowingConversion)
+		})
+
+		It("should detect calls of reflect.DeepEqual", func() {
+			runner("G720", testutils.SampleCodeDeepEqual)
+		})
+
+		It("should allow calls of reflect.DeepEqual in test files", func() {
+			testAnalyzer := gosec.NewAnalyzer(gosec.NewConfig(), true, logger)
+			testAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G720")).Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("keys.go", "package crypto\n\ntype PubKey struct{ Key []byte }\n")
+			pkg.AddFile("keys_test.go", testutils.SampleCodeDeepEqual[0].Code[0])
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = testAnalyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := testAnalyzer.Report()
+			Expect(issues).Should(BeEmpty())
+		})
+		It("should check the test files when asked to", func() {
+			testAnalyzer := gosec.NewAnalyzer(gosec.NewConfig(), true, logger)
+			testAnalyzer.SetCheckTestFiles(true)
+			testAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G720")).Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("keys.go", "package crypto\n\ntype PubKey struct{ Key []byte }\n")
+			pkg.AddFile("keys_test.go", testutils.SampleCodeDeepEqual[0].Code[0])
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = testAnalyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := testAnalyzer.Report()
+			Expect(issues).Should(HaveLen(testutils.SampleCodeDeepEqual[0].Errors))
+		})
+		It("should still allow testing imports in test files when checking them", func() {
+			testAnalyzer := gosec.NewAnalyzer(gosec.NewConfig(), true, logger)
+			testAnalyzer.SetCheckTestFiles(true)
+			testAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G715")).Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("keeper.go", "package keeper\n\nfunc Add(a, b int) int { return a + b }\n")
+			pkg.AddFile("keeper_test.go", testutils.SampleCodeTestingImport[0].Code[0])
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = testAnalyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := testAnalyzer.Report()
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should detect calls of os.Exit outside of the commands", func() {
+			runner("G721", testutils.SampleCodeOsExit)
+		})
+
+		It("should allow calls of os.Exit in test files", func() {
+			testAnalyzer := gosec.NewAnalyzer(gosec.NewConfig(), true, logger)
+			testAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G721")).Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("keeper_test.go", testutils.SampleCodeOsExit[0].Code[0])
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = testAnalyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := testAnalyzer.Report()
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should detect formatted values holding maps used as keys", func() {
+			runner("G722", testutils.SampleCodeMapFormat)
+		})
+
+		It("should detect the use of math/big.Float", func() {
+			runner("G723", testutils.SampleCodeBigFloat)
+		})
+
+		It("should detect lazy initialization of package level variables without sync.Once", func() {
+			runner("G724", testutils.SampleCodeLazyInit)
+		})
+
+		It("should detect contexts created with context.Background or context.TODO", func() {
+			runner("G725", testutils.SampleCodeContextBackground)
+		})
+
+		It("should detect the clear builtin on maps", func() {
+			if types.Universe.Lookup("clear") == nil {
+				Skip("the clear builtin requires Go 1.21")
+			}
+			runner("G726", testutils.SampleCodeClearMap)
+		})
+
+		It("should detect appending to package level slices shared by the results", func() {
+			runner("G727", testutils.SampleCodeSharedAppend)
+		})
+
+		It("should detect the JSON decoding of numbers into floats", func() {
+			runner("G728", testutils.SampleCodeJSONFloat)
+		})
+
+		It("should detect the map keys holding pointers", func() {
+			runner("G729", testutils.SampleCodePointerMapKeys)
+		})
+
+		It("should detect the sort.Slice calls comparing a single field", func() {
+			runner("G730", testutils.SampleCodeSortTies)
+		})
+
+		It("should detect the reads from the filesystem in state code", func() {
+			runner("G731", testutils.SampleCodeFilesystemRead)
+		})
+
+		It("should detect the imports of the other random number packages", func() {
+			runner("G732", testutils.SampleCodeRandImport)
+		})
+
+		It("should detect the random number types re-exported by other packages", func() {
+			analyzer.SetConfig(gosec.Config{"G732": map[string]interface{}{
+				"packages": []interface{}{"github.com/cosmos/gosec/v2/testdata/rand/prng"},
+			}})
+			analyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G732")).Builders())
+			err := analyzer.Process(buildTags, "github.com/cosmos/gosec/v2/testdata/rand/keeper")
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).Should(HaveLen(1))
+			Expect(issues[0].File).Should(HaveSuffix("keeper.go"))
+			Expect(issues[0].What).Should(Equal("Use of prng.Rand re-exported by " +
+				"github.com/cosmos/gosec/v2/testdata/rand/wrapper from the blocklisted random number package " +
+				"github.com/cosmos/gosec/v2/testdata/rand/prng"))
+		})
+
+		It("should detect the channel ranges building the state", func() {
+			runner("G733", testutils.SampleCodeChannelRange)
+		})
+
+		It("should detect the keeper methods mutating their parameters", func() {
+			runner("G734", testutils.SampleCodeParamMutation)
+		})
+
+		It("should detect the I/O in init functions", func() {
+			runner("G735", testutils.SampleCodeInitIO)
+		})
+
+		It("should detect the case mappings of stored or hashed strings", func() {
+			runner("G736", testutils.SampleCodeLocaleStrings)
+		})
+
+		It("should detect the comparisons of secrets which are not constant time", func() {
+			runner("G737", testutils.SampleCodeSecretCompare)
+		})
+
+		It("should detect the comparisons of the secrets matching the configured pattern", func() {
+			config := gosec.NewConfig()
+			config.Set("G737", map[string]interface{}{"pattern": "(^|_)(token|key)(_|$)"})
+			runner("G737", []testutils.CodeSample{{Code: testutils.SampleCodeSecretCompare[1].Code, Errors: 2, Config: config}})
+		})
+
+		It("should detect the releases of resources deferred inside loops", func() {
+			runner("G738", testutils.SampleCodeLoopDefer)
+		})
+
+		It("should detect the rounding of floats with the math package", func() {
+			runner("G739", testutils.SampleCodeFloatRounding)
+		})
+
+		It("should detect the conversions between signed and unsigned integers", func() {
+			runner("G740", testutils.SampleCodeSignConversion)
+		})
+
+		It("should detect the blocking forever outside of the main packages", func() {
+			runner("G741", testutils.SampleCodeBlockForever)
+		})
+
+		It("should detect the package level calls assigned to the blank identifier", func() {
+			runner("G742", testutils.SampleCodeBlankInit)
+		})
+
+		It("should detect the exported methods returning internal slices", func() {
+			runner("G743", testutils.SampleCodeReturnedField)
+		})
+
+		It("should detect the calls tuning the runtime in state code", func() {
+			runner("G744", testutils.SampleCodeRuntimeCall)
+		})
+
+		It("should allow the calls tuning the runtime in the benchmarks", func() {
+			testAnalyzer := gosec.NewAnalyzer(gosec.NewConfig(), true, logger)
+			testAnalyzer.LoadRules(rules.Generate(rules.NewRuleFilter(false, "G744")).Builders())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("keeper.go", "package keeper\n\nfunc Add(a, b int) int { return a + b }\n")
+			pkg.AddFile("keeper_test.go", `
+package keeper
+
+import (
+	"runtime"
+	"testing"
+)
+
+func BenchmarkAdd(b *testing.B) {
+	runtime.GC()
+	for i := 0; i < b.N; i++ {
+		Add(i, i)
+	}
+}
+`)
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = testAnalyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := testAnalyzer.Report()
+			Expect(issues).Should(BeEmpty())
+		})
+
+		It("should detect the recursive handlers without a depth bound", func() {
+			runner("G745", testutils.SampleCodeRecursion)
+		})
+
+		It("should detect the shared state accessed concurrently without a lock", func() {
+			runner("G746", testutils.SampleCodeSharedState)
+		})
+
+		It("should detect the divisions of big.Int discarding their remainder", func() {
+			runner("G747", testutils.SampleCodeBigIntDivision)
+		})
+
+		It("should detect the hardcoded mnemonics and private keys", func() {
+			runner("G748", testutils.SampleCodeHardcodedMnemonic)
+		})
+
+		It("should detect the duplicate keys in map literals", func() {
+			runner("G749", testutils.SampleCodeDuplicateMapKeys)
+		})
+
+		It("should detect the shadowing of err dropping an outer error", func() {
+			runner("G750", testutils.SampleCodeShadowedErr)
+		})
+
+		It("should detect the context values with keys of a built-in or non-comparable type", func() {
+			runner("G751", testutils.SampleCodeContextValueKeys)
+		})
+
+		It("should detect the goroutines of the servers blocking without a case on ctx.Done()", func() {
+			runner("G752", testutils.SampleCodeGoroutineLeak)
+		})
+
+		It("should detect the uses of the default HTTP client", func() {
+			runner("G753", testutils.SampleCodeDefaultHTTPClient)
+		})
+
+		It("should detect the calls of errors.As with a target which is not a pointer to an error", func() {
+			runner("G754", testutils.SampleCodeErrorsAsTarget)
+		})
+
+		It("should detect the timers and the tickers in deterministic packages", func() {
+			runner("G755", testutils.SampleCodeTimers)
+		})
+
 		It("should detect DoS vulnerability via decompression bomb", func() {
 			runner("G110", testutils.SampleCodeG110)
 		})
@@ -0,0 +1,109 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass keeps math/big.Float away from the state: its results are rounded
+// to a precision and with a rounding mode which are easy to get wrong, unlike
+// big.Int and big.Rat which are exact.
+
+type bigFloat struct {
+	gosec.MetaData
+	packages []string
+}
+
+func (r *bigFloat) ID() string {
+	return r.MetaData.ID
+}
+
+func isBigFloat(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "math/big" && named.Obj().Name() == "Float"
+}
+
+// isBigFloatCall returns true for new(big.Float), for the functions returning a
+// big.Float and for the methods of big.Float, such as big.NewFloat or Mul.
+func (r *bigFloat) isBigFloatCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	if ident, ok := call.Fun.(*ast.Ident); ok {
+		if _, ok := ctx.Info.ObjectOf(ident).(*types.Builtin); ok && ident.Name == "new" && len(call.Args) == 1 {
+			return isBigFloat(ctx.Info.TypeOf(call.Args[0]))
+		}
+	}
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	signature := fn.Type().(*types.Signature)
+	if recv := signature.Recv(); recv != nil && isBigFloat(recv.Type()) {
+		// A chain of calls, e.g. new(big.Float).SetInt(x).Mul(...), is reported once at its root.
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+			if inner, ok := sel.X.(*ast.CallExpr); ok && r.isBigFloatCall(inner, ctx) {
+				return false
+			}
+		}
+		return true
+	}
+	results := signature.Results()
+	for i := 0; i < results.Len(); i++ {
+		if isBigFloat(results.At(i).Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *bigFloat) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if ctx.SkipTestFile() || isSimulationPkg(ctx) || matchesPackage(ctx.Pkg.Path(), r.packages) {
+		return nil, nil
+	}
+	switch n := node.(type) {
+	case *ast.CallExpr:
+		if r.isBigFloatCall(n, ctx) {
+			return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+		}
+	case *ast.CompositeLit:
+		if isBigFloat(ctx.Info.TypeOf(n)) {
+			return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+		}
+	}
+	return nil, nil
+}
+
+// NewBigFloatCheck flags the construction of and the arithmetic on math/big.Float
+// values outside of the tests and of the allowlisted packages.
+func NewBigFloatCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	packages, _ := configuredPackages(id, conf)
+
+	return &bigFloat{
+		packages: packages,
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.High,
+			What:       "math/big.Float rounds its results and must not touch the state, use the SDK's decimal type sdk.Dec instead",
+			HelpURL:    helpURL("use-of-mathbigfloat-in-state-code"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil), (*ast.CompositeLit)(nil)}
+}
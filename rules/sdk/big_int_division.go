@@ -0,0 +1,118 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// This pass flags the divisions of math/big integers discarding their
+// remainder: splitting an amount, e.g. the rewards between the delegators,
+// truncates each share and the remainder disappears from the supply unless it
+// is accounted for, e.g. sent to the community pool.
+
+type bigIntDivision struct {
+	gosec.MetaData
+}
+
+func (r *bigIntDivision) ID() string {
+	return r.MetaData.ID
+}
+
+// remainderMethods are the methods of big.Int computing the remainder of a
+// division.
+var remainderMethods = map[string]bool{
+	"DivMod": true,
+	"Mod":    true,
+	"QuoRem": true,
+	"Rem":    true,
+}
+
+// bigIntMethod returns the name of the method of math/big.Int called, if any.
+func bigIntMethod(call *ast.CallExpr, ctx *gosec.Context) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	selection, ok := ctx.Info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return ""
+	}
+	recv := selection.Recv()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "math/big" || named.Obj().Name() != "Int" {
+		return ""
+	}
+	return sel.Sel.Name
+}
+
+// computesRemainder returns true if the block computing the division also
+// computes a remainder.
+func computesRemainder(call *ast.CallExpr, ctx *gosec.Context) bool {
+	path, _ := astutil.PathEnclosingInterval(ctx.Root, call.Pos(), call.End())
+	var block ast.Node
+	for _, node := range path {
+		if b, ok := node.(*ast.BlockStmt); ok {
+			block = b
+			break
+		}
+	}
+	if block == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(block, func(n ast.Node) bool {
+		if other, ok := n.(*ast.CallExpr); ok && remainderMethods[bigIntMethod(other, ctx)] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+func (r *bigIntDivision) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || ctx.SkipTestFile() || isSimulationPkg(ctx) {
+		return nil, nil
+	}
+	if method := bigIntMethod(call, ctx); method != "Div" && method != "Quo" {
+		return nil, nil
+	}
+	if computesRemainder(call, ctx) {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, call, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewBigIntDivisionCheck flags the calls of big.Int Div and Quo in a block
+// which does not compute the remainder of a division as well.
+func NewBigIntDivisionCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &bigIntDivision{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.Low,
+			What:       "Division of a big.Int discards its remainder, account for it with DivMod or QuoRem",
+			HelpURL:    helpURL("divisions-of-bigint-discarding-their-remainder"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
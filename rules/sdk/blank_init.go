@@ -0,0 +1,139 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// This pass flags the package level variables assigned to the blank
+// identifier to call a function: the call only runs for its side effects, at an
+// order which depends on the dependencies between the package variables and on
+// the other files of the package, and it is easily mistaken for a compile time
+// assertion. Such initializations belong in an init function or in an explicit
+// registration.
+
+type blankInit struct {
+	gosec.MetaData
+}
+
+func (r *blankInit) ID() string {
+	return r.MetaData.ID
+}
+
+// pureBuiltins are the builtins, including the ones of the unsafe package,
+// without side effects.
+var pureBuiltins = map[string]bool{
+	"Alignof":  true,
+	"Offsetof": true,
+	"Sizeof":   true,
+	"cap":      true,
+	"complex":  true,
+	"imag":     true,
+	"len":      true,
+	"make":     true,
+	"new":      true,
+	"real":     true,
+}
+
+// callsFunction returns true if evaluating expr calls a function, the type
+// conversions, the pure builtins and the bodies of the function literals aside.
+func callsFunction(expr ast.Expr, ctx *gosec.Context) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if tv, ok := ctx.Info.Types[n.Fun]; ok && tv.IsType() {
+				return true
+			}
+			var ident *ast.Ident
+			switch fun := astutil.Unparen(n.Fun).(type) {
+			case *ast.Ident:
+				ident = fun
+			case *ast.SelectorExpr:
+				ident = fun.Sel
+			}
+			if builtin, ok := ctx.Info.Uses[ident].(*types.Builtin); ok && pureBuiltins[builtin.Name()] {
+				return true
+			}
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isPackageVarSpec returns true if the spec belongs to a package level var
+// declaration of the file.
+func isPackageVarSpec(spec *ast.ValueSpec, file *ast.File) bool {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, s := range genDecl.Specs {
+			if s == spec {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *blankInit) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	valueSpec, ok := node.(*ast.ValueSpec)
+	// The declarations in the functions are statements, only the package level
+	// ones are declarations of the file.
+	if !ok || ctx.SkipTestFile() || !isPackageVarSpec(valueSpec, ctx.Root) {
+		return nil, nil
+	}
+
+	for i, name := range valueSpec.Names {
+		if name.Name != "_" {
+			continue
+		}
+		// A call returning several values is assigned to all the names.
+		value := i
+		if len(valueSpec.Values) == 1 {
+			value = 0
+		}
+		if value < len(valueSpec.Values) && callsFunction(valueSpec.Values[value], ctx) {
+			return gosec.NewIssue(ctx, valueSpec, r.ID(), r.What, r.Severity, r.Confidence), nil
+		}
+	}
+	return nil, nil
+}
+
+// NewBlankInitCheck flags the package level variables assigned to the blank
+// identifier with a value calling a function, each spec of a grouped
+// declaration on its own.
+func NewBlankInitCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &blankInit{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.Medium,
+			What:       "Package level call assigned to the blank identifier runs for its side effects in an implicit order; call it from an init function instead",
+			HelpURL:    helpURL("package-level-calls-assigned-to-the-blank-identifier"),
+		},
+	}, []ast.Node{(*ast.ValueSpec)(nil)}
+}
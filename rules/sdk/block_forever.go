@@ -0,0 +1,79 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass flags the idioms blocking the goroutine forever, an empty select
+// statement or a receive from a channel just made: they keep a server main
+// running, but in a library they hang the node on the code path reaching them,
+// e.g. a block or a transaction, without any error.
+
+type blockForever struct {
+	gosec.MetaData
+}
+
+func (r *blockForever) ID() string {
+	return r.MetaData.ID
+}
+
+// isMakeCall returns true if the expression is a call of the make builtin.
+func isMakeCall(expr ast.Expr, ctx *gosec.Context) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ctx.Info.Uses[ident] == types.Universe.Lookup("make")
+}
+
+func (r *blockForever) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if ctx.SkipTestFile() || isCommandPkg(ctx) {
+		return nil, nil
+	}
+	switch n := node.(type) {
+	case *ast.SelectStmt:
+		if len(n.Body.List) > 0 {
+			return nil, nil
+		}
+	case *ast.UnaryExpr:
+		if n.Op != token.ARROW || !isMakeCall(n.X, ctx) {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, node, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewBlockForeverCheck flags the empty select statements and the receives from
+// channels just made outside of the main packages and of the commands.
+func NewBlockForeverCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &blockForever{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "Blocking forever outside of a main package hangs the caller; return or wait on a context instead",
+			HelpURL:    helpURL("blocking-forever-outside-of-the-main-packages"),
+		},
+	}, []ast.Node{(*ast.SelectStmt)(nil), (*ast.UnaryExpr)(nil)}
+}
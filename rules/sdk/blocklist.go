@@ -15,16 +15,21 @@
 package sdk
 
 import (
+	"fmt"
 	"go/ast"
+	"go/types"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/packages"
 )
 
 type blocklistedImport struct {
 	gosec.MetaData
 	Blocklisted map[string]string
+	transitive  bool
 }
 
 func unquote(original string) string {
@@ -65,31 +70,224 @@ func forbiddenFromBlockedImports(ctx *gosec.Context) bool {
 	}
 }
 
+// isStdPkg returns true for the packages of the standard library, whose import
+// paths don't start with a domain name.
+func isStdPkg(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+// importChain returns the shortest chain of imports going through the packages
+// outside of the standard library from the given package to a blocklisted one.
+func (r *blocklistedImport) importChain(path string, c *gosec.Context) []string {
+	root, ok := c.PkgImports[path]
+	if !ok {
+		return nil
+	}
+	type step struct {
+		pkg   *packages.Package
+		chain []string
+	}
+	visited := map[string]bool{path: true}
+	queue := []step{{root, []string{path}}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		imports := make([]string, 0, len(current.pkg.Imports))
+		for imp := range current.pkg.Imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+		for _, imp := range imports {
+			chain := append(append([]string{}, current.chain...), imp)
+			if _, ok := r.Blocklisted[imp]; ok {
+				return chain
+			}
+			if visited[imp] || isStdPkg(imp) {
+				continue
+			}
+			visited[imp] = true
+			queue = append(queue, step{current.pkg.Imports[imp], chain})
+		}
+	}
+	return nil
+}
+
 func (r *blocklistedImport) Match(n ast.Node, c *gosec.Context) (*gosec.Issue, error) {
 	if node, ok := n.(*ast.ImportSpec); ok && forbiddenFromBlockedImports(c) {
-		if description, ok := r.Blocklisted[unquote(node.Path.Value)]; ok {
+		path := unquote(node.Path.Value)
+		if description, ok := r.Blocklisted[path]; ok {
+			return gosec.NewIssue(c, node, r.ID(), description, r.Severity, r.Confidence), nil
+		}
+		if !r.transitive || isStdPkg(path) {
+			return nil, nil
+		}
+		if chain := r.importChain(path, c); chain != nil {
+			blocked := chain[len(chain)-1]
+			description := fmt.Sprintf("%s reached transitively: %s -> %s", r.Blocklisted[blocked], c.Pkg.Name(), strings.Join(chain, " -> "))
 			return gosec.NewIssue(c, node, r.ID(), description, r.Severity, r.Confidence), nil
 		}
 	}
 	return nil, nil
 }
 
+// enabledEntries returns the blocklist without the entries disabled in the
+// configuration of the rule:
+//
+//	{"G702": {"disabled": ["reflect"]}}
+func enabledEntries(id string, conf gosec.Config, blocklist map[string]string) map[string]string {
+	disabled := make(map[string]bool)
+	if val, ok := conf[id]; ok {
+		if ruleConf, ok := val.(map[string]interface{}); ok {
+			if configDisabled, ok := ruleConf["disabled"].([]interface{}); ok {
+				for _, entry := range configDisabled {
+					if entry, ok := entry.(string); ok {
+						disabled[entry] = true
+					}
+				}
+			}
+		}
+	}
+
+	enabled := make(map[string]string, len(blocklist))
+	for entry, description := range blocklist {
+		if !disabled[entry] {
+			enabled[entry] = description
+		}
+	}
+	return enabled
+}
+
 // NewBlocklistedImports reports when a blocklisted import is being used.
-// Typically when a deprecated technology is being used.
+// Typically when a deprecated technology is being used. The imports reaching
+// a blocklisted package through other packages are reported as well when the
+// rule is configured with:
+//
+//	{"G702": {"transitive": true}}
 func NewBlocklistedImports(id string, conf gosec.Config, blocklist map[string]string) (gosec.Rule, []ast.Node) {
+	transitive := false
+	if val, ok := conf[id]; ok {
+		if ruleConf, ok := val.(map[string]interface{}); ok {
+			transitive, _ = ruleConf["transitive"].(bool)
+		}
+	}
+
 	return &blocklistedImport{
 		MetaData: gosec.MetaData{
 			ID:         id,
 			Severity:   gosec.Medium,
 			Confidence: gosec.High,
 		},
-		Blocklisted: blocklist,
+		Blocklisted: enabledEntries(id, conf, blocklist),
+		transitive:  transitive,
+	}, []ast.Node{(*ast.ImportSpec)(nil)}
+}
+
+type testingImport struct {
+	gosec.MetaData
+	Blocklisted map[string]string
+}
+
+func (r *testingImport) ID() string {
+	return r.MetaData.ID
+}
+
+func (r *testingImport) Match(n ast.Node, c *gosec.Context) (*gosec.Issue, error) {
+	node, ok := n.(*ast.ImportSpec)
+	if !ok || c.IsTestFile || isTestHelperPkg(c) {
+		return nil, nil
+	}
+	path := unquote(node.Path.Value)
+	if description, ok := r.Blocklisted[path]; ok {
+		return gosec.NewIssue(c, node, r.ID(), description, r.Severity, r.Confidence), nil
+	}
+	// A blocklisted "dir/*" entry covers all the packages below dir.
+	for dir := path; strings.Contains(dir, "/"); {
+		dir = dir[:strings.LastIndex(dir, "/")]
+		if description, ok := r.Blocklisted[dir+"/*"]; ok {
+			return gosec.NewIssue(c, node, r.ID(), description, r.Severity, r.Confidence), nil
+		}
+	}
+	return nil, nil
+}
+
+// NewTestingImport fails if the testing packages are imported by non-test files,
+// which bloats the binaries and may register the testing flags at init.
+func NewTestingImport(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &testingImport{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			HelpURL:    helpURL("testing-imports-in-non-test-files"),
+		},
+		Blocklisted: enabledEntries(id, conf, map[string]string{
+			"testing":                       "Blocklisted import testing in a non-test file",
+			"net/http/httptest":             "Blocklisted import net/http/httptest in a non-test file",
+			"github.com/stretchr/testify":   "Blocklisted import github.com/stretchr/testify in a non-test file",
+			"github.com/stretchr/testify/*": "Blocklisted import github.com/stretchr/testify in a non-test file",
+		}),
 	}, []ast.Node{(*ast.ImportSpec)(nil)}
 }
 
+type blocklistedCall struct {
+	gosec.MetaData
+	Blocklisted map[string]string
+}
+
+func (r *blocklistedCall) ID() string {
+	return r.MetaData.ID
+}
+
+// qualifiedFuncName returns the fully qualified name of a function such as
+// "math/rand.Intn", or "math/big.Int.Exp" for a method.
+func qualifiedFuncName(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return ""
+	}
+	name := fn.Name()
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		recv := sig.Recv().Type()
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+		}
+		if named, ok := recv.(*types.Named); ok {
+			name = named.Obj().Name() + "." + name
+		}
+	}
+	return fn.Pkg().Path() + "." + name
+}
+
+func (r *blocklistedCall) Match(n ast.Node, c *gosec.Context) (*gosec.Issue, error) {
+	if node, ok := n.(*ast.CallExpr); ok && forbiddenFromBlockedImports(c) {
+		_, obj := gosec.GetCallObject(node, c)
+		if fn, ok := obj.(*types.Func); ok {
+			if description, ok := r.Blocklisted[qualifiedFuncName(fn)]; ok {
+				return gosec.NewIssue(c, node, r.ID(), description, r.Severity, r.Confidence), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// NewBlocklistedCalls reports when a blocklisted function is being called, for
+// the packages which are allowed to be imported but of which some functions are
+// dangerous. The blocklist is keyed by the fully qualified function names e.g.
+// "math/rand.Intn" or "math/big.Int.Exp" for methods.
+func NewBlocklistedCalls(id string, conf gosec.Config, blocklist map[string]string) (gosec.Rule, []ast.Node) {
+	return &blocklistedCall{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+		},
+		Blocklisted: enabledEntries(id, conf, blocklist),
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
+
 // NewUnsafeImport fails if any of "unsafe", "reflect", "crypto/rand", "math/rand" are imported.
+// Its issues link the determinism guidelines.
 func NewUnsafeImport(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
-	return NewBlocklistedImports(id, conf, map[string]string{
+	rule, nodes := NewBlocklistedImports(id, conf, map[string]string{
 		// unsafe exposes memory bugs
 		"unsafe": "Blocklisted import unsafe",
 
@@ -106,4 +304,6 @@ func NewUnsafeImport(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
 		"math/rand":   "Blocklisted import math/rand",
 		"crypto/rand": "Blocklisted import crypto/rand",
 	})
+	rule.(*blocklistedImport).HelpURL = helpURL(determinismGuidelines)
+	return rule, nodes
 }
@@ -0,0 +1,118 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the results collected from goroutines: the values are
+// received from a channel in the order the goroutines sent them, which depends
+// on the scheduling, so a slice or a state built in that order differs from
+// one node to the other.
+
+type channelRange struct {
+	gosec.MetaData
+}
+
+func (r *channelRange) ID() string {
+	return r.MetaData.ID
+}
+
+// mutatesState returns true if the value assigned to the expression outlives
+// the function and depends on the order of the assignments. The map entries are
+// left out as a map has no order.
+func mutatesState(lhs ast.Expr, returned map[types.Object]bool, ctx *gosec.Context) bool {
+	if index, ok := lhs.(*ast.IndexExpr); ok {
+		if typ := ctx.Info.TypeOf(index.X); typ != nil {
+			if _, ok := typ.Underlying().(*types.Map); ok {
+				return false
+			}
+		}
+	}
+	return escapes(lhs, nil, returned, ctx)
+}
+
+// buildsState returns true if the body of the loop assigns a value outliving
+// the function, e.g. appends to a returned slice or updates a field.
+func buildsState(body *ast.BlockStmt, returned map[types.Object]bool, ctx *gosec.Context) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			if stmt.Tok == token.DEFINE {
+				return true
+			}
+			for _, lhs := range stmt.Lhs {
+				if mutatesState(lhs, returned, ctx) {
+					found = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if mutatesState(stmt.X, returned, ctx) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func (r *channelRange) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	// the order of the values received by the commands, the servers and the simulations does not reach the state
+	if !ok || funcDecl.Body == nil || ctx.SkipTestFile() || isCommandPkg(ctx) || isServerPkg(ctx) ||
+		isSimulationPkg(ctx) {
+		return nil, nil
+	}
+
+	returned := returnedVars(funcDecl, ctx)
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		rangeStmt, ok := n.(*ast.RangeStmt)
+		if !ok {
+			return issue == nil
+		}
+		typ := ctx.Info.TypeOf(rangeStmt.X)
+		if typ == nil {
+			return true
+		}
+		if _, ok := typ.Underlying().(*types.Chan); ok && buildsState(rangeStmt.Body, returned, ctx) {
+			issue = gosec.NewIssue(ctx, rangeStmt, r.ID(), r.What, r.Severity, r.Confidence)
+		}
+		return issue == nil
+	})
+	return issue, nil
+}
+
+// NewChannelRangeCheck flags the loops receiving the values of a channel which
+// append them to a returned slice or store them in the state.
+func NewChannelRangeCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &channelRange{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "The values received from a channel come in the order of the goroutine scheduling, sort them before they reach the state or the results",
+			HelpURL:    helpURL("ranging-over-channels-to-build-the-state"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
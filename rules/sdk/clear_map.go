@@ -0,0 +1,70 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass is advisory: the clear builtin of Go 1.21 empties a map in place,
+// so every holder of the map sees it emptied, unlike the assignment of a new
+// map. On slices it zeroes the elements instead and is not reported.
+
+type clearMap struct {
+	gosec.MetaData
+}
+
+func (r *clearMap) ID() string {
+	return r.MetaData.ID
+}
+
+func (r *clearMap) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || ctx.SkipTestFile() {
+		return nil, nil
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || ident.Name != "clear" {
+		return nil, nil
+	}
+	if _, ok := ctx.Info.ObjectOf(ident).(*types.Builtin); !ok {
+		return nil, nil
+	}
+	typ := ctx.Info.TypeOf(call.Args[0])
+	if typ == nil {
+		return nil, nil
+	}
+	if _, ok := typ.Underlying().(*types.Map); !ok {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, call, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewClearMapCheck flags the calls of the clear builtin on maps outside of the
+// tests.
+func NewClearMapCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &clearMap{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.Medium,
+			What:       "clear empties the map in place for all its holders, make sure no state or iteration still relies on its entries",
+			HelpURL:    helpURL("clearing-maps-with-the-clear-builtin-opt-in"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
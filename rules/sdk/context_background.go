@@ -0,0 +1,62 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the keepers and the handlers: a context created from
+// scratch drops the deadline, the cancellation and the values threaded from the
+// request, such as the gas meter and the block height of the sdk.Context.
+
+type contextBackground struct {
+	gosec.MetaData
+}
+
+func (r *contextBackground) ID() string {
+	return r.MetaData.ID
+}
+
+func (r *contextBackground) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || ctx.SkipTestFile() || isCommandPkg(ctx) {
+		return nil, nil
+	}
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "context" || (fn.Name() != "Background" && fn.Name() != "TODO") {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, call, r.ID(), fmt.Sprintf(r.What, fn.Name()), r.Severity, r.Confidence), nil
+}
+
+// NewContextBackgroundCheck flags the calls of context.Background and
+// context.TODO outside of the tests and of the commands.
+func NewContextBackgroundCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &contextBackground{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.Medium,
+			What:       "context.%s discards the deadline, the cancellation and the values of the request, thread the existing sdk.Context instead",
+			HelpURL:    helpURL("contexts-created-with-contextbackground-or-contexttodo"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
@@ -0,0 +1,75 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the values threaded through the contexts: a key of a
+// built-in type, such as a string, matches the same key set by any other
+// package, and a key which is not comparable panics when the value is set.
+
+type contextValueKeys struct {
+	gosec.MetaData
+}
+
+func (r *contextValueKeys) ID() string {
+	return r.MetaData.ID
+}
+
+func (r *contextValueKeys) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) != 3 || ctx.SkipTestFile() {
+		return nil, nil
+	}
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "context" || fn.Name() != "WithValue" {
+		return nil, nil
+	}
+	key := call.Args[1]
+	typ := ctx.Info.TypeOf(key)
+	if typ == nil {
+		return nil, nil
+	}
+	if _, ok := typ.(*types.Basic); ok {
+		what := fmt.Sprintf("context.WithValue with a key of the built-in type %s collides with the same key of the other packages, use a value of an unexported key type", types.Default(typ))
+		return gosec.NewIssue(ctx, key, r.ID(), what, r.Severity, r.Confidence), nil
+	}
+	if !types.Comparable(typ) {
+		what := fmt.Sprintf("context.WithValue panics on a key of the non-comparable type %s, use a value of an unexported key type", typ)
+		return gosec.NewIssue(ctx, key, r.ID(), what, r.Severity, r.Confidence), nil
+	}
+	return nil, nil
+}
+
+// NewContextValueKeysCheck flags the calls of context.WithValue with a key of
+// a built-in or of a non-comparable type.
+func NewContextValueKeysCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &contextValueKeys{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "context.WithValue with a key of a built-in or of a non-comparable type",
+			HelpURL:    helpURL("context-values-with-keys-of-a-built-in-or-non-comparable-type"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
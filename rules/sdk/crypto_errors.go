@@ -0,0 +1,88 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass narrows down the unhandled errors check to the packages where an
+// ignored error is security critical, such as a failed signature verification
+// or a failed decoding of untrusted bytes.
+
+type cryptoErrorCheck struct {
+	gosec.MetaData
+	packages []string
+}
+
+func (r *cryptoErrorCheck) ID() string {
+	return r.MetaData.ID
+}
+
+func (r *cryptoErrorCheck) isCheckedCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	if obj == nil || obj.Pkg() == nil || !matchesPackage(obj.Pkg().Path(), r.packages) {
+		return false
+	}
+	return returnsError(call, ctx) >= 0
+}
+
+func (r *cryptoErrorCheck) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	switch stmt := n.(type) {
+	case *ast.AssignStmt:
+		for _, expr := range stmt.Rhs {
+			call, ok := expr.(*ast.CallExpr)
+			if !ok || !r.isCheckedCall(call, ctx) {
+				continue
+			}
+			pos := returnsError(call, ctx)
+			if len(stmt.Rhs) != 1 || pos >= len(stmt.Lhs) {
+				continue
+			}
+			if id, ok := stmt.Lhs[pos].(*ast.Ident); ok && id.Name == "_" {
+				return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+			}
+		}
+	case *ast.ExprStmt:
+		if call, ok := stmt.X.(*ast.CallExpr); ok && r.isCheckedCall(call, ctx) {
+			return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+		}
+	}
+	return nil, nil
+}
+
+// NewUnhandledCryptoErrors detects discarded errors returned by the crypto and
+// encoding packages. The packages can be configured, "/*" matching subpackages:
+//
+//	{"G709": {"packages": ["crypto/*", "encoding/*", "github.com/cosmos/cosmos-sdk/crypto/*"]}}
+func NewUnhandledCryptoErrors(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	packages, ok := configuredPackages(id, conf)
+	if !ok {
+		packages = []string{"crypto/*", "encoding/*"}
+	}
+
+	return &cryptoErrorCheck{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.High,
+			What:       "Errors of cryptographic or encoding operations must be handled",
+			HelpURL:    helpURL("unhandled-errors-of-crypto-and-encoding-operations"),
+		},
+		packages: packages,
+	}, []ast.Node{(*ast.AssignStmt)(nil), (*ast.ExprStmt)(nil)}
+}
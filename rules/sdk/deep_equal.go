@@ -0,0 +1,60 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the packages which are allowed to import reflect, such as
+// the crypto ones: reflect.DeepEqual compares the unexported fields and never
+// finds two non-nil functions equal, so it must not decide consensus outcomes.
+
+type deepEqual struct {
+	gosec.MetaData
+}
+
+func (r *deepEqual) ID() string {
+	return r.MetaData.ID
+}
+
+func (r *deepEqual) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || ctx.SkipTestFile() {
+		return nil, nil
+	}
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "reflect" || fn.Name() != "DeepEqual" {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, call, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewDeepEqualCheck flags the calls of reflect.DeepEqual outside of the tests.
+func NewDeepEqualCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &deepEqual{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "reflect.DeepEqual must not decide consensus outcomes, use proto.Equal or a type specific comparison",
+			HelpURL:    helpURL("use-of-reflectdeepequal"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
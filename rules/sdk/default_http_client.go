@@ -0,0 +1,83 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the requests sent with the default HTTP client: it has no
+// timeout, so a slow peer hangs the node, and a request sent by the keepers or
+// the handlers makes the state depend on the network, which differs from one
+// node to the other.
+
+type defaultHTTPClient struct {
+	gosec.MetaData
+	packages []string
+}
+
+func (r *defaultHTTPClient) ID() string {
+	return r.MetaData.ID
+}
+
+// defaultClientObjects lists the functions and the variables of net/http using
+// the default client
+var defaultClientObjects = map[string]bool{
+	"DefaultClient": true,
+	"Get":           true,
+	"Head":          true,
+	"Post":          true,
+	"PostForm":      true,
+}
+
+func (r *defaultHTTPClient) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	sel, ok := node.(*ast.SelectorExpr)
+	if !ok || ctx.SkipTestFile() || isCommandPkg(ctx) || isServerPkg(ctx) || isCLIPkg(ctx) ||
+		matchesPackage(ctx.Pkg.Path(), r.packages) {
+		return nil, nil
+	}
+	// the methods of a http.Client are left out, they are not in the scope of the package
+	obj := ctx.Info.Uses[sel.Sel]
+	if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != "net/http" || obj.Parent() != obj.Pkg().Scope() ||
+		!defaultClientObjects[obj.Name()] {
+		return nil, nil
+	}
+	if isKeeperOrHandlerPkg(ctx) {
+		what := fmt.Sprintf("http.%s sends a request from the state logic, which depends on the network and differs from one node to the other", obj.Name())
+		return gosec.NewIssue(ctx, sel, r.ID(), what, gosec.High, r.Confidence), nil
+	}
+	return gosec.NewIssue(ctx, sel, r.ID(), fmt.Sprintf(r.What, obj.Name()), r.Severity, r.Confidence), nil
+}
+
+// NewDefaultHTTPClientCheck flags the uses of the default HTTP client outside
+// of the tests, of the commands, of the servers and of the allowlisted
+// packages, with a high severity in the keepers and the handlers.
+func NewDefaultHTTPClientCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	packages, _ := configuredPackages(id, conf)
+
+	return &defaultHTTPClient{
+		packages: packages,
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "http.%s uses the default HTTP client which has no timeout, use a http.Client with a Timeout instead",
+			HelpURL:    helpURL("use-of-the-default-http-client"),
+		},
+	}, []ast.Node{(*ast.SelectorExpr)(nil)}
+}
@@ -0,0 +1,89 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass flags the deferred Close calls whose error is lost: closing a file
+// or a writer flushes the data, so a failed Close may leave corrupted state
+// behind without anyone noticing.
+
+type deferredClose struct {
+	gosec.MetaData
+	packages []string
+	closer   *types.Interface
+}
+
+func (r *deferredClose) ID() string {
+	return r.MetaData.ID
+}
+
+// newCloserInterface returns an interface identical to io.Closer, which may not
+// be imported by the package under analysis.
+func newCloserInterface() *types.Interface {
+	errType := types.Universe.Lookup("error").Type()
+	results := types.NewTuple(types.NewVar(0, nil, "", errType))
+	sig := types.NewSignature(nil, nil, results, false)
+	closer := types.NewInterfaceType([]*types.Func{types.NewFunc(0, nil, "Close", sig)}, nil)
+	return closer.Complete()
+}
+
+func (r *deferredClose) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	deferStmt, ok := n.(*ast.DeferStmt)
+	if !ok {
+		return nil, nil
+	}
+	sel, ok := deferStmt.Call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Close" {
+		return nil, nil
+	}
+	typ := ctx.Info.TypeOf(sel.X)
+	if typ == nil || !types.Implements(typ, r.closer) {
+		return nil, nil
+	}
+	if !matchesPackage(typePkgPath(typ), r.packages) {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewDeferredCloseCheck detects the deferred Close calls discarding the error
+// of the io.Closer types declared by the configured packages, "/*" matching
+// subpackages:
+//
+//	{"G712": {"packages": ["os", "bufio", "crypto/*", "compress/*"]}}
+func NewDeferredCloseCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	packages, ok := configuredPackages(id, conf)
+	if !ok {
+		packages = []string{"os", "bufio", "crypto/*"}
+	}
+
+	return &deferredClose{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "Deferred Close discards its error, which may hide unflushed or corrupted data",
+			HelpURL:    helpURL("deferred-close-discarding-its-error"),
+		},
+		packages: packages,
+		closer:   newCloserInterface(),
+	}, []ast.Node{(*ast.DeferStmt)(nil)}
+}
@@ -0,0 +1,28 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+// docsURL is the documentation of the rules of this package, each rule linking
+// its own section from its issues
+const docsURL = "https://github.com/cosmos/gosec/blob/master/rules/sdk/README.md"
+
+// determinismGuidelines is the section of the documentation explaining why the
+// state must not depend on the node computing it
+const determinismGuidelines = "determinism-guidelines"
+
+// helpURL returns the link to the section of the documentation with the anchor
+func helpURL(anchor string) string {
+	return docsURL + "#" + anchor
+}
@@ -0,0 +1,171 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// This pass flags the map literals holding the same key twice: the compiler
+// rejects the duplicate constant keys, but not the duplicate keys of array or
+// struct types built from constants, e.g. {1, 2} and {1, 1 + 1}, and the later
+// entry silently overrides the earlier one.
+
+type duplicateMapKeys struct {
+	gosec.MetaData
+}
+
+func (r *duplicateMapKeys) ID() string {
+	return r.MetaData.ID
+}
+
+// zeroValue returns the value of an omitted field of a basic type, or false
+// for the other types.
+func zeroValue(typ types.Type) (constant.Value, bool) {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return nil, false
+	}
+	switch {
+	case basic.Info()&types.IsBoolean != 0:
+		return constant.MakeBool(false), true
+	case basic.Info()&types.IsString != 0:
+		return constant.MakeString(""), true
+	case basic.Info()&types.IsNumeric != 0:
+		return constant.MakeInt64(0), true
+	default:
+		return nil, false
+	}
+}
+
+// constantValue formats a constant with its type.
+func constantValue(typ types.Type, value constant.Value) string {
+	return types.TypeString(typ, nil) + "(" + value.ExactString() + ")"
+}
+
+// constantKey returns the value of a key built from constants, prefixed with
+// its type so that the keys of an interface type only match the keys of the
+// same dynamic type, or false when the value is only known at run time.
+func constantKey(expr ast.Expr, ctx *gosec.Context) (string, bool) {
+	expr = astutil.Unparen(expr)
+	tv, ok := ctx.Info.Types[expr]
+	if !ok || tv.Type == nil {
+		return "", false
+	}
+	if tv.Value != nil {
+		return constantValue(tv.Type, tv.Value), true
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return "", false
+	}
+
+	var values []string
+	switch t := tv.Type.Underlying().(type) {
+	case *types.Array:
+		if int64(len(lit.Elts)) != t.Len() {
+			return "", false
+		}
+		for _, elt := range lit.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); ok {
+				return "", false
+			}
+			value, ok := constantKey(elt, ctx)
+			if !ok {
+				return "", false
+			}
+			values = append(values, value)
+		}
+	case *types.Struct:
+		fields := make(map[string]ast.Expr)
+		for i, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if ident, ok := kv.Key.(*ast.Ident); ok {
+					fields[ident.Name] = kv.Value
+				}
+			} else if i < t.NumFields() {
+				fields[t.Field(i).Name()] = elt
+			}
+		}
+		for i := 0; i < t.NumFields(); i++ {
+			field := t.Field(i)
+			elt, found := fields[field.Name()]
+			if !found {
+				zero, ok := zeroValue(field.Type())
+				if !ok {
+					return "", false
+				}
+				values = append(values, constantValue(field.Type(), zero))
+				continue
+			}
+			value, ok := constantKey(elt, ctx)
+			if !ok {
+				return "", false
+			}
+			values = append(values, value)
+		}
+	default:
+		return "", false
+	}
+	return types.TypeString(tv.Type, nil) + "{" + strings.Join(values, ", ") + "}", true
+}
+
+func (r *duplicateMapKeys) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	lit, ok := n.(*ast.CompositeLit)
+	if !ok {
+		return nil, nil
+	}
+	if typ := ctx.Info.TypeOf(lit); typ == nil {
+		return nil, nil
+	} else if _, ok := typ.Underlying().(*types.Map); !ok {
+		return nil, nil
+	}
+	seen := make(map[string]bool)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := constantKey(kv.Key, ctx)
+		if !ok {
+			continue
+		}
+		if seen[key] {
+			return gosec.NewIssue(ctx, kv.Key, r.ID(), r.What, r.Severity, r.Confidence), nil
+		}
+		seen[key] = true
+	}
+	return nil, nil
+}
+
+// NewDuplicateMapKeysCheck flags the map literals holding two keys built from
+// constants which are equal.
+func NewDuplicateMapKeysCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &duplicateMapKeys{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "Duplicate key in map literal, the later entry silently overrides the earlier one",
+			HelpURL:    helpURL("duplicate-keys-in-map-literals"),
+		},
+	}, []ast.Node{(*ast.CompositeLit)(nil)}
+}
@@ -105,6 +105,7 @@ func NewErrorNotPropagated(id string, conf gosec.Config) (gosec.Rule, []ast.Node
 			Severity:   gosec.Low,
 			Confidence: gosec.High,
 			What:       "Returned error is not propagated up the stack.",
+			HelpURL:    helpURL("errors-which-are-not-propagated"),
 		},
 	}, []ast.Node{(*ast.AssignStmt)(nil), (*ast.ExprStmt)(nil)}
 }
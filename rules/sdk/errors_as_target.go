@@ -0,0 +1,91 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the targets of errors.As: it panics unless its target is
+// a non-nil pointer to an interface or to a type implementing error, which
+// halts the node on the first error unwrapped by the faulty call.
+
+type errorsAsTarget struct {
+	gosec.MetaData
+}
+
+func (r *errorsAsTarget) ID() string {
+	return r.MetaData.ID
+}
+
+// errorsAsPackages lists the packages whose As function panics on a bad target
+var errorsAsPackages = map[string]bool{
+	"errors":                true,
+	"github.com/pkg/errors": true,
+}
+
+var errorInterface = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+func (r *errorsAsTarget) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || ctx.SkipTestFile() {
+		return nil, nil
+	}
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil || !errorsAsPackages[fn.Pkg().Path()] || fn.Name() != "As" {
+		return nil, nil
+	}
+	target := call.Args[1]
+	typ := ctx.Info.TypeOf(target)
+	if typ == nil {
+		return nil, nil
+	}
+	if basic, ok := typ.(*types.Basic); ok && basic.Kind() == types.UntypedNil {
+		return gosec.NewIssue(ctx, target, r.ID(), "errors.As panics on a nil target, pass the address of an error variable", r.Severity, r.Confidence), nil
+	}
+	// the dynamic type of an interface is only known at run time
+	if types.IsInterface(typ) {
+		return nil, nil
+	}
+	ptr, ok := typ.Underlying().(*types.Pointer)
+	if !ok {
+		what := fmt.Sprintf("errors.As panics on the target of the non-pointer type %s, pass the address of the variable instead", typ)
+		return gosec.NewIssue(ctx, target, r.ID(), what, r.Severity, r.Confidence), nil
+	}
+	if elem := ptr.Elem(); !types.IsInterface(elem) && !types.Implements(elem, errorInterface) {
+		what := fmt.Sprintf("errors.As panics on the target of type %s, %s neither is an interface nor implements error", typ, elem)
+		return gosec.NewIssue(ctx, target, r.ID(), what, r.Severity, r.Confidence), nil
+	}
+	return nil, nil
+}
+
+// NewErrorsAsTargetCheck flags the calls of errors.As with a target which is
+// not a pointer to an interface or to a type implementing error.
+func NewErrorsAsTargetCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &errorsAsTarget{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.High,
+			What:       "errors.As with a target which is not a pointer to an error",
+			HelpURL:    helpURL("calls-of-errorsas-with-a-target-which-is-not-a-pointer-to-an-error"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
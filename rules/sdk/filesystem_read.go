@@ -0,0 +1,87 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the state machine: the files of a node differ from the
+// files of the other nodes, so anything read from the filesystem while
+// processing the blocks can make the validators compute different states. The
+// commands, including the CLI commands of the modules, are excused.
+
+type filesystemRead struct {
+	gosec.MetaData
+}
+
+func (r *filesystemRead) ID() string {
+	return r.MetaData.ID
+}
+
+// filesystemReads lists the functions reading from the filesystem per package
+var filesystemReads = map[string]map[string]bool{
+	"os": {
+		"ReadFile": true,
+		"ReadDir":  true,
+		"Open":     true,
+		"OpenFile": true,
+		"Stat":     true,
+		"Lstat":    true,
+	},
+	"io/ioutil": {
+		"ReadFile": true,
+		"ReadDir":  true,
+	},
+	"path/filepath": {
+		"Glob":    true,
+		"Walk":    true,
+		"WalkDir": true,
+	},
+}
+
+func (r *filesystemRead) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || ctx.SkipTestFile() || isCommandPkg(ctx) || isCLIPkg(ctx) {
+		return nil, nil
+	}
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil || !filesystemReads[fn.Pkg().Path()][fn.Name()] {
+		return nil, nil
+	}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, call, r.ID(), fmt.Sprintf(r.What, fn.Pkg().Name()+"."+fn.Name()), r.Severity, r.Confidence), nil
+}
+
+// NewFilesystemReadCheck flags the functions reading from the filesystem
+// called outside of the tests and of the commands.
+func NewFilesystemReadCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &filesystemRead{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "%s makes the execution depend on the local files of the node, which differ across the validators and break consensus",
+			HelpURL:    helpURL("reads-from-the-filesystem-in-state-code"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
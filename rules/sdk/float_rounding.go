@@ -0,0 +1,79 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the amounts rounded as floats: math.Floor and the like are
+// only called on float64 values, whose arithmetic loses the low digits of the
+// large amounts and whose results can differ with the instructions the
+// compiler picks, e.g. the fused multiply-adds on some architectures.
+
+type floatRounding struct {
+	gosec.MetaData
+	packages []string
+}
+
+func (r *floatRounding) ID() string {
+	return r.MetaData.ID
+}
+
+// roundingFuncs lists the rounding functions of the math package
+var roundingFuncs = map[string]bool{
+	"Ceil":        true,
+	"Floor":       true,
+	"Mod":         true,
+	"Round":       true,
+	"RoundToEven": true,
+	"Trunc":       true,
+}
+
+func (r *floatRounding) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || ctx.SkipTestFile() || isSimulationPkg(ctx) || isCommandPkg(ctx) ||
+		isCLIPkg(ctx) || matchesPackage(ctx.Pkg.Path(), r.packages) {
+		return nil, nil
+	}
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "math" || !roundingFuncs[fn.Name()] {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, call, r.ID(), fmt.Sprintf(r.What, "math."+fn.Name()), r.Severity, r.Confidence), nil
+}
+
+// NewFloatRoundingCheck flags the calls of the rounding functions of the math
+// package outside of the tests, of the commands and of the allowlisted
+// packages.
+func NewFloatRoundingCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	packages, _ := configuredPackages(id, conf)
+
+	return &floatRounding{
+		packages: packages,
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "%s rounds a float64 which loses the precision of the amounts, use integer arithmetic or the SDK's decimal type sdk.Dec instead",
+			HelpURL:    helpURL("rounding-of-floats-with-the-math-package"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
@@ -0,0 +1,241 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass specializes the map ranging check for the genesis functions: hashing
+// or encoding the initial state out of a map iteration produces a different
+// genesis on every node, which breaks the chain at launch.
+
+type genesisMapHash struct {
+	gosec.MetaData
+}
+
+func (gm *genesisMapHash) ID() string {
+	return gm.MetaData.ID
+}
+
+func isGenesisFunc(name string) bool {
+	return name == "InitGenesis" || name == "ExportGenesis"
+}
+
+// typePkgPath returns the path of the package declaring the named type
+// behind typ, dereferencing pointers.
+func typePkgPath(typ types.Type) string {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil {
+		return named.Obj().Pkg().Path()
+	}
+	return ""
+}
+
+func isHashPkg(path string) bool {
+	return path == "hash" || strings.HasPrefix(path, "hash/") || strings.HasPrefix(path, "crypto/")
+}
+
+// isHashOrEncodeCall returns true if the call hashes or serializes its arguments,
+// e.g. sha256.Sum256, hash.Hash.Write, json.Marshal or codec.MustMarshalJSON.
+func isHashOrEncodeCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	name := fn.Name()
+	if strings.Contains(name, "Marshal") || strings.HasPrefix(name, "Encode") {
+		return true
+	}
+	return isHashCall(call, ctx)
+}
+
+// isHashCall returns true if the call feeds or computes a hash, e.g.
+// sha256.Sum256 or hash.Hash.Write.
+func isHashCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	name := fn.Name()
+
+	// Methods such as Write are declared by io.Writer, so look at the
+	// receiver to find out whether the call is made on a hash.
+	var pkgPath string
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if selection, ok := ctx.Info.Selections[sel]; ok {
+			pkgPath = typePkgPath(selection.Recv())
+		}
+	}
+	if pkgPath == "" && fn.Pkg() != nil {
+		pkgPath = fn.Pkg().Path()
+	}
+	if !isHashPkg(pkgPath) {
+		return false
+	}
+	return name == "Write" || name == "WriteString" || strings.HasPrefix(name, "Sum")
+}
+
+func isSortCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	if fn.Pkg() != nil && (fn.Pkg().Path() == "sort" || fn.Pkg().Path() == "golang.org/x/exp/slices") {
+		return true
+	}
+	return strings.HasPrefix(fn.Name(), "Sort")
+}
+
+// refersTo returns true if any identifier within node resolves to one of objs.
+func refersTo(node ast.Node, objs map[types.Object]bool, ctx *gosec.Context) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && objs[ctx.Info.ObjectOf(ident)] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isMapRange returns true if the range statement iterates over a map.
+func isMapRange(rangeStmt *ast.RangeStmt, ctx *gosec.Context) bool {
+	if typ := ctx.Info.TypeOf(rangeStmt.X); typ != nil {
+		_, ok := typ.Underlying().(*types.Map)
+		return ok
+	}
+	return false
+}
+
+// rangeOutputs returns the objects written by the body of a range statement:
+// the variables assigned to and the receivers of the methods called.
+func rangeOutputs(rangeStmt *ast.RangeStmt, ctx *gosec.Context) map[types.Object]bool {
+	outputs := make(map[types.Object]bool)
+	ast.Inspect(rangeStmt.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && ident.Name != "_" {
+					if obj := ctx.Info.ObjectOf(ident); obj != nil {
+						outputs[obj] = true
+					}
+				}
+			}
+		case *ast.CallExpr:
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					if obj, ok := ctx.Info.ObjectOf(ident).(*types.Var); ok {
+						outputs[obj] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+	return outputs
+}
+
+// unsortedSink returns true if, after the range statement, the outputs of the
+// range are passed to a sink call within body before being sorted.
+func unsortedSink(body *ast.BlockStmt, rangeStmt *ast.RangeStmt, outputs map[types.Object]bool, isSink func(*ast.CallExpr, *gosec.Context) bool, ctx *gosec.Context) bool {
+	if len(outputs) == 0 {
+		return false
+	}
+	sorted := false
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if sorted || found {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call.Pos() < rangeStmt.End() {
+			return true
+		}
+		if isSortCall(call, ctx) && refersTo(call, outputs, ctx) {
+			sorted = true
+		} else if isSink(call, ctx) && refersTo(call, outputs, ctx) {
+			found = true
+		}
+		return true
+	})
+	return found
+}
+
+func (gm *genesisMapHash) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if pkgExcusedFromMapRangingChecks(ctx) {
+		return nil, nil
+	}
+
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil || !isGenesisFunc(funcDecl.Name.Name) {
+		return nil, nil
+	}
+
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if issue != nil {
+			return false
+		}
+		rangeStmt, ok := n.(*ast.RangeStmt)
+		if !ok || !isMapRange(rangeStmt, ctx) {
+			return true
+		}
+
+		// The map entries are hashed or encoded straight from the loop.
+		direct := false
+		ast.Inspect(rangeStmt.Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && isHashOrEncodeCall(call, ctx) {
+				direct = true
+			}
+			return !direct
+		})
+		if direct {
+			issue = gosec.NewIssue(ctx, rangeStmt, gm.ID(), gm.What, gm.Severity, gosec.High)
+			return false
+		}
+
+		// The map entries are collected in the loop and hashed or encoded afterwards without sorting.
+		if unsortedSink(funcDecl.Body, rangeStmt, rangeOutputs(rangeStmt, ctx), isHashOrEncodeCall, ctx) {
+			issue = gosec.NewIssue(ctx, rangeStmt, gm.ID(), gm.What, gm.Severity, gm.Confidence)
+			return false
+		}
+		return true
+	})
+	return issue, nil
+}
+
+// NewGenesisMapHash flags map iterations whose output is hashed or encoded
+// inside of the InitGenesis and ExportGenesis functions.
+func NewGenesisMapHash(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &genesisMapHash{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.Medium,
+			What:       "Non-deterministic map iteration is hashed or encoded in genesis; iterate over the sorted keys to get a deterministic genesis encoding",
+			HelpURL:    helpURL("map-iteration-hashed-or-encoded-in-genesis"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
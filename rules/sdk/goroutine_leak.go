@@ -0,0 +1,155 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// This pass targets the goroutines of the long-running servers: a goroutine
+// blocked receiving from a channel which is never sent to again, with no case
+// on the cancellation of its context, is never collected and leaks along with
+// everything it holds, one more each time the server starts it.
+
+type goroutineLeak struct {
+	gosec.MetaData
+}
+
+func (r *goroutineLeak) ID() string {
+	return r.MetaData.ID
+}
+
+// unblocksReceive returns true if the channel received from is closed on the
+// cancellation of a context, e.g. ctx.Done(), or fires after a timeout, e.g.
+// time.After(timeout).
+func unblocksReceive(ch ast.Expr, ctx *gosec.Context) bool {
+	call, ok := astutil.Unparen(ch).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Done" && len(call.Args) == 0 {
+		if typ := ctx.Info.TypeOf(call); typ != nil {
+			if _, ok := typ.Underlying().(*types.Chan); ok {
+				return true
+			}
+		}
+	}
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "time" && fn.Name() == "After"
+}
+
+// receivedChannel returns the channel received from by the communication of a
+// select case, nil for the sends and the default case.
+func receivedChannel(comm ast.Stmt) ast.Expr {
+	var expr ast.Expr
+	switch stmt := comm.(type) {
+	case *ast.ExprStmt:
+		expr = stmt.X
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) == 1 {
+			expr = stmt.Rhs[0]
+		}
+	}
+	if recv, ok := astutil.Unparen(expr).(*ast.UnaryExpr); ok && recv.Op == token.ARROW {
+		return recv.X
+	}
+	return nil
+}
+
+// blocksForever returns true if the select waits on channels only, without a
+// default case nor a case unblocking it on a cancellation or a timeout.
+func blocksForever(stmt *ast.SelectStmt, ctx *gosec.Context) bool {
+	for _, clause := range stmt.Body.List {
+		comm, ok := clause.(*ast.CommClause)
+		if !ok {
+			continue
+		}
+		if comm.Comm == nil {
+			return false
+		}
+		if ch := receivedChannel(comm.Comm); ch != nil && unblocksReceive(ch, ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+// blockingReceive returns the first receive of the body which may block the
+// goroutine forever, the nested function literals being left out.
+func blockingReceive(body *ast.BlockStmt, ctx *gosec.Context) ast.Node {
+	var found ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SelectStmt:
+			if blocksForever(node, ctx) {
+				found = node
+				return false
+			}
+			// the communications of the cases are checked above, only their bodies are left
+			for _, clause := range node.Body.List {
+				if comm, ok := clause.(*ast.CommClause); ok && found == nil {
+					found = blockingReceive(&ast.BlockStmt{List: comm.Body}, ctx)
+				}
+			}
+			return false
+		case *ast.UnaryExpr:
+			if node.Op == token.ARROW && !unblocksReceive(node.X, ctx) {
+				found = node
+			}
+		}
+		return true
+	})
+	return found
+}
+
+func (r *goroutineLeak) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	goStmt, ok := node.(*ast.GoStmt)
+	if !ok || ctx.SkipTestFile() || !isCommandPkg(ctx) && !isServerPkg(ctx) {
+		return nil, nil
+	}
+	fn, ok := astutil.Unparen(goStmt.Call.Fun).(*ast.FuncLit)
+	if !ok {
+		return nil, nil
+	}
+	if blockingReceive(fn.Body, ctx) == nil {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, goStmt, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewGoroutineLeakCheck flags the goroutines of the commands and the servers
+// which block receiving from a channel without a case on ctx.Done().
+func NewGoroutineLeakCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &goroutineLeak{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Low,
+			What:       "Goroutine blocking on a channel without a case on ctx.Done() leaks when the context is cancelled, select on ctx.Done() as well",
+			HelpURL:    helpURL("goroutines-of-the-servers-blocking-without-a-case-on-ctxdone"),
+		},
+	}, []ast.Node{(*ast.GoStmt)(nil)}
+}
@@ -0,0 +1,184 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the init functions doing network or disk I/O: they run
+// whenever the package is imported, even indirectly by the consensus code, so
+// the import depends on the network and the files of the node and can fail or
+// hang before the node starts.
+
+type initIO struct {
+	gosec.MetaData
+}
+
+func (r *initIO) ID() string {
+	return r.MetaData.ID
+}
+
+// initIOFuncs lists the functions doing network or disk I/O per package
+var initIOFuncs = map[string]map[string]bool{
+	"net": {
+		"Dial":           true,
+		"DialTimeout":    true,
+		"DialTCP":        true,
+		"DialUDP":        true,
+		"DialIP":         true,
+		"DialUnix":       true,
+		"Listen":         true,
+		"ListenPacket":   true,
+		"ListenTCP":      true,
+		"ListenUDP":      true,
+		"ListenIP":       true,
+		"ListenUnix":     true,
+		"LookupAddr":     true,
+		"LookupCNAME":    true,
+		"LookupHost":     true,
+		"LookupIP":       true,
+		"LookupMX":       true,
+		"LookupNS":       true,
+		"LookupPort":     true,
+		"LookupSRV":      true,
+		"LookupTXT":      true,
+		"ResolveIPAddr":  true,
+		"ResolveTCPAddr": true,
+		"ResolveUDPAddr": true,
+	},
+	"net/http": {
+		"Get":               true,
+		"Head":              true,
+		"Post":              true,
+		"PostForm":          true,
+		"ListenAndServe":    true,
+		"ListenAndServeTLS": true,
+		"Serve":             true,
+		"ServeTLS":          true,
+	},
+	"os": {
+		"Create":     true,
+		"Open":       true,
+		"OpenFile":   true,
+		"ReadFile":   true,
+		"WriteFile":  true,
+		"ReadDir":    true,
+		"Mkdir":      true,
+		"MkdirAll":   true,
+		"MkdirTemp":  true,
+		"CreateTemp": true,
+		"Remove":     true,
+		"RemoveAll":  true,
+		"Rename":     true,
+		"Stat":       true,
+		"Lstat":      true,
+		"Chmod":      true,
+		"Chdir":      true,
+	},
+	"io/ioutil": {
+		"ReadFile":  true,
+		"WriteFile": true,
+		"ReadDir":   true,
+		"TempFile":  true,
+		"TempDir":   true,
+	},
+}
+
+// initIOTypes lists the types whose methods do network or disk I/O per package
+var initIOTypes = map[string]map[string]bool{
+	"net": {
+		"Dialer":       true,
+		"ListenConfig": true,
+		"Resolver":     true,
+	},
+	"net/http": {
+		"Client":    true,
+		"Server":    true,
+		"Transport": true,
+	},
+	"os": {
+		"File": true,
+	},
+}
+
+// ioCall returns the name of the function or method doing I/O called by call,
+// if any, e.g. http.Get or http.Client.Do.
+func ioCall(call *ast.CallExpr, ctx *gosec.Context) string {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok {
+		return ""
+	}
+	if sig.Recv() == nil {
+		if initIOFuncs[fn.Pkg().Path()][fn.Name()] {
+			return fn.Pkg().Name() + "." + fn.Name()
+		}
+		return ""
+	}
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	if !ok || !initIOTypes[fn.Pkg().Path()][named.Obj().Name()] {
+		return ""
+	}
+	return fn.Pkg().Name() + "." + named.Obj().Name() + "." + fn.Name()
+}
+
+func (r *initIO) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Recv != nil || funcDecl.Name.Name != "init" || funcDecl.Body == nil || ctx.SkipTestFile() {
+		return nil, nil
+	}
+
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// The closures run when they are called, not at the import
+			return false
+		case *ast.CallExpr:
+			if name := ioCall(node, ctx); name != "" {
+				issue = gosec.NewIssue(ctx, node, r.ID(), fmt.Sprintf(r.What, name), r.Severity, r.Confidence)
+			}
+		}
+		return issue == nil
+	})
+	return issue, nil
+}
+
+// NewInitIOCheck flags the init functions calling the functions doing network
+// or disk I/O.
+func NewInitIOCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &initIO{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "init function calling %s, the I/O runs whenever the package is imported; move it to an explicit setup function",
+			HelpURL:    helpURL("network-or-disk-io-in-init-functions"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
@@ -148,6 +148,7 @@ func NewIntegerCast(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
 			Severity:   gosec.High,
 			Confidence: gosec.Medium,
 			What:       "Potential integer overflow by integer type conversion",
+			HelpURL:    helpURL("integer-casts-which-can-overflow"),
 		},
 	}, []ast.Node{(*ast.FuncDecl)(nil), (*ast.AssignStmt)(nil), (*ast.CallExpr)(nil)}
 }
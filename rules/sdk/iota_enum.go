@@ -0,0 +1,127 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass reminds that the values of iota constants depend on their order:
+// reordering or inserting a constant silently changes the values which were
+// already persisted or sent over the wire.
+
+type iotaEnum struct {
+	gosec.MetaData
+}
+
+func (r *iotaEnum) ID() string {
+	return r.MetaData.ID
+}
+
+func usesIota(decl *ast.GenDecl, ctx *gosec.Context) bool {
+	iota := types.Universe.Lookup("iota")
+	found := false
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ctx.Info.ObjectOf(ident) == iota {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// constsType returns the named type of the constants declared by decl.
+func constsType(decl *ast.GenDecl, ctx *gosec.Context) *types.Named {
+	for _, spec := range decl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for _, name := range valueSpec.Names {
+			if obj := ctx.Info.ObjectOf(name); obj != nil {
+				if named, ok := obj.Type().(*types.Named); ok {
+					return named
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isSerializationTag(tag string) bool {
+	for _, key := range []string{"json:", "yaml:", "protobuf:", "amino:"} {
+		if strings.Contains(tag, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSerialized returns true if typ is the type of a field with a serialization
+// tag in one of the structs of the package.
+func isSerialized(typ *types.Named, ctx *gosec.Context) bool {
+	scope := ctx.Pkg.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		st, ok := typeName.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for i := 0; i < st.NumFields(); i++ {
+			if types.Identical(st.Field(i).Type(), typ) && isSerializationTag(st.Tag(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *iotaEnum) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	decl, ok := node.(*ast.GenDecl)
+	if !ok || decl.Tok != token.CONST || !usesIota(decl, ctx) {
+		return nil, nil
+	}
+	if ctx.SkipTestFile() {
+		return nil, nil
+	}
+
+	// The constants are likely to be persisted when their type is serialized.
+	if typ := constsType(decl, ctx); typ != nil && isSerialized(typ, ctx) {
+		return gosec.NewIssue(ctx, decl, r.ID(), r.What, gosec.Medium, gosec.High), nil
+	}
+	return gosec.NewIssue(ctx, decl, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewIotaEnumCheck flags the constant blocks using iota, whose values change
+// when the constants are reordered. It is an opt-in rule as it is noisy.
+func NewIotaEnumCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &iotaEnum{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.Medium,
+			What:       "The values of iota constants depend on their order, reordering them changes persisted and wire values",
+			HelpURL:    helpURL("order-dependent-iota-constants"),
+		},
+	}, []ast.Node{(*ast.GenDecl)(nil)}
+}
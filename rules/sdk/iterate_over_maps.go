@@ -40,8 +40,11 @@ func (mr *mapRanging) ID() string {
 // There are some packages that inherently need map ranging such as "testutil"
 // so return true if we detect such.
 func pkgExcusedFromMapRangingChecks(ctx *gosec.Context) bool {
+	if isSimulationPkg(ctx) {
+		return true
+	}
 	switch pkg := ctx.Pkg.Name(); pkg {
-	case "core", "gogoreflection", "proto", "runtime", "simapp", "simulation", "testutil":
+	case "core", "gogoreflection", "proto", "runtime":
 		return true
 	default:
 		return false
@@ -270,6 +273,7 @@ func NewMapRangingCheck(id string, config gosec.Config) (rule gosec.Rule, nodes
 			Severity:   gosec.High,
 			Confidence: gosec.Medium,
 			What:       "Non-determinism from ranging over maps",
+			HelpURL:    helpURL("non-deterministic-map-iteration"),
 		},
 		calls: calls,
 	}
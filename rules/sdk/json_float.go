@@ -0,0 +1,144 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass flags the JSON decoding into floats and empty interfaces:
+// encoding/json decodes the numbers into float64 by default, which rounds the
+// large integers and makes anything derived from them depend on the float
+// representation instead of the exact value sent.
+
+type jsonFloat struct {
+	gosec.MetaData
+	calls gosec.CallList
+}
+
+func (r *jsonFloat) ID() string {
+	return r.MetaData.ID
+}
+
+// hasFloatDecoding returns true if typ is, or contains through its elements
+// and decoded struct fields, a float or an empty interface which is not
+// decoded by its own UnmarshalJSON method.
+func hasFloatDecoding(typ types.Type, seen map[types.Type]bool) bool {
+	if seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	if _, ok := typ.(*types.Named); ok && hasUnmarshalJSON(typ) {
+		return false
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		return t.Info()&types.IsFloat != 0
+	case *types.Interface:
+		return t.Empty()
+	case *types.Pointer:
+		return hasFloatDecoding(t.Elem(), seen)
+	case *types.Slice:
+		return hasFloatDecoding(t.Elem(), seen)
+	case *types.Array:
+		return hasFloatDecoding(t.Elem(), seen)
+	case *types.Map:
+		return hasFloatDecoding(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			field := t.Field(i)
+			if !field.Exported() || reflect.StructTag(t.Tag(i)).Get("json") == "-" {
+				continue
+			}
+			if hasFloatDecoding(field.Type(), seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasUnmarshalJSON returns true if typ, or a pointer to it, implements
+// json.Unmarshaler.
+func hasUnmarshalJSON(typ types.Type) bool {
+	methods := types.NewMethodSet(types.NewPointer(typ))
+	for i := 0; i < methods.Len(); i++ {
+		if methods.At(i).Obj().Name() == "UnmarshalJSON" {
+			return true
+		}
+	}
+	return false
+}
+
+// isJSONDecode returns true if the call is json.Decoder.Decode.
+func isJSONDecode(call *ast.CallExpr, ctx *gosec.Context) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Decode" {
+		return false
+	}
+	selection, ok := ctx.Info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return false
+	}
+	return typePkgPath(selection.Recv()) == "encoding/json"
+}
+
+func (r *jsonFloat) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if ctx.SkipTestFile() {
+		return nil, nil
+	}
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return nil, nil
+	}
+	var target ast.Expr
+	switch {
+	case r.calls.ContainsPkgCallExpr(call, ctx, false) != nil && len(call.Args) == 2:
+		target = call.Args[1]
+	case isJSONDecode(call, ctx) && len(call.Args) == 1:
+		target = call.Args[0]
+	default:
+		return nil, nil
+	}
+	// The values passed through an interface, e.g. by decoding helpers, are
+	// checked where their type is known
+	typ := ctx.Info.TypeOf(target)
+	if typ == nil || types.IsInterface(typ) || !hasFloatDecoding(typ, make(map[types.Type]bool)) {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewJSONFloatCheck flags the values decoded by encoding/json which hold
+// floats or empty interfaces.
+func NewJSONFloatCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	calls := gosec.NewCallList()
+	calls.Add("encoding/json", "Unmarshal")
+	return &jsonFloat{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "JSON decoding into float64 or interface{} values rounds the numbers; decode them into json.Number or typed integers",
+			HelpURL:    helpURL("json-decoding-of-numbers-into-floats"),
+		},
+		calls: calls,
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
@@ -0,0 +1,111 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass flags the JSON encoding of maps keyed by anything but strings:
+// encoding/json only sorts string keys reliably, the other keys go through
+// their text representation or make the encoding fail, which is unstable for
+// state that is persisted or hashed.
+
+type jsonMapKeys struct {
+	gosec.MetaData
+	calls gosec.CallList
+}
+
+func (r *jsonMapKeys) ID() string {
+	return r.MetaData.ID
+}
+
+// hasNonStringMapKey returns true if typ is, or contains through its elements
+// and struct fields, a map whose keys are not strings.
+func hasNonStringMapKey(typ types.Type, seen map[types.Type]bool) bool {
+	if seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	switch t := typ.Underlying().(type) {
+	case *types.Map:
+		if basic, ok := t.Key().Underlying().(*types.Basic); !ok || basic.Kind() != types.String {
+			return true
+		}
+		return hasNonStringMapKey(t.Elem(), seen)
+	case *types.Pointer:
+		return hasNonStringMapKey(t.Elem(), seen)
+	case *types.Slice:
+		return hasNonStringMapKey(t.Elem(), seen)
+	case *types.Array:
+		return hasNonStringMapKey(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if t.Field(i).Exported() && hasNonStringMapKey(t.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *jsonMapKeys) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := n.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil, nil
+	}
+	if r.calls.ContainsPkgCallExpr(call, ctx, false) == nil && !isJSONEncode(call, ctx) {
+		return nil, nil
+	}
+	typ := ctx.Info.TypeOf(call.Args[0])
+	if typ == nil || !hasNonStringMapKey(typ, make(map[types.Type]bool)) {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// isJSONEncode returns true if the call is json.Encoder.Encode.
+func isJSONEncode(call *ast.CallExpr, ctx *gosec.Context) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Encode" {
+		return false
+	}
+	selection, ok := ctx.Info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return false
+	}
+	return typePkgPath(selection.Recv()) == "encoding/json"
+}
+
+// NewJSONMapKeysCheck flags the values marshaled by encoding/json which hold
+// maps with non-string keys.
+func NewJSONMapKeysCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	calls := gosec.NewCallList()
+	calls.AddAll("encoding/json", "Marshal", "MarshalIndent")
+	return &jsonMapKeys{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "JSON marshaling of a map with non-string keys produces unstable or erroring output; use string keys or a sorted slice of entries",
+			HelpURL:    helpURL("json-marshaling-of-maps-with-non-string-keys"),
+		},
+		calls: calls,
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
@@ -0,0 +1,149 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// This pass targets the server code, such as the gRPC and REST handlers, which
+// runs concurrently: a package level cache initialized behind a bare nil check
+// races when two requests find it nil at the same time.
+
+type lazyInit struct {
+	gosec.MetaData
+}
+
+func (r *lazyInit) ID() string {
+	return r.MetaData.ID
+}
+
+// nilCheckedGlobal returns the package level pointer or map variable compared
+// to nil by the condition, e.g. cache in "if cache == nil".
+func nilCheckedGlobal(cond ast.Expr, ctx *gosec.Context) types.Object {
+	binary, ok := cond.(*ast.BinaryExpr)
+	if !ok || binary.Op != token.EQL {
+		return nil
+	}
+	operand := binary.X
+	if ident, ok := binary.X.(*ast.Ident); ok && ident.Name == "nil" {
+		operand = binary.Y
+	} else if ident, ok := binary.Y.(*ast.Ident); !ok || ident.Name != "nil" {
+		return nil
+	}
+	ident, ok := operand.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	obj, ok := ctx.Info.ObjectOf(ident).(*types.Var)
+	if !ok || obj.Parent() != ctx.Pkg.Scope() {
+		return nil
+	}
+	switch obj.Type().Underlying().(type) {
+	case *types.Pointer, *types.Map:
+		return obj
+	default:
+		return nil
+	}
+}
+
+func assignsTo(body *ast.BlockStmt, obj types.Object, ctx *gosec.Context) bool {
+	assigned := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok && assign.Tok == token.ASSIGN {
+			for _, lhs := range assign.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && ctx.Info.ObjectOf(ident) == obj {
+					assigned = true
+				}
+			}
+		}
+		return !assigned
+	})
+	return assigned
+}
+
+func isSyncMethodCall(call *ast.CallExpr, ctx *gosec.Context, name string) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Name() != name {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	return recv != nil && isSyncType(recv.Type())
+}
+
+// isSynchronized returns true if the statement runs in a function passed to
+// sync.Once.Do, in an init function or after a mutex was locked.
+func isSynchronized(stmt ast.Stmt, ctx *gosec.Context) bool {
+	path, _ := astutil.PathEnclosingInterval(ctx.Root, stmt.Pos(), stmt.End())
+	for i, node := range path {
+		switch n := node.(type) {
+		case *ast.FuncDecl:
+			return n.Recv == nil && n.Name.Name == "init"
+		case *ast.FuncLit:
+			if i+1 < len(path) {
+				if call, ok := path[i+1].(*ast.CallExpr); ok && isSyncMethodCall(call, ctx, "Do") {
+					return true
+				}
+			}
+		case *ast.BlockStmt:
+			if i == 0 {
+				continue
+			}
+			for _, s := range n.List {
+				if s == path[i-1] {
+					break
+				}
+				if expr, ok := s.(*ast.ExprStmt); ok {
+					if call, ok := expr.X.(*ast.CallExpr); ok && isSyncMethodCall(call, ctx, "Lock") {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (r *lazyInit) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	ifStmt, ok := node.(*ast.IfStmt)
+	if !ok || ctx.SkipTestFile() {
+		return nil, nil
+	}
+	obj := nilCheckedGlobal(ifStmt.Cond, ctx)
+	if obj == nil || !assignsTo(ifStmt.Body, obj, ctx) || isSynchronized(ifStmt, ctx) {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, ifStmt, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewLazyInitCheck flags the package level pointers and maps initialized behind
+// a nil check without sync.Once or a locked mutex.
+func NewLazyInitCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &lazyInit{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "Lazy initialization of a package level variable behind a nil check races, use sync.Once",
+			HelpURL:    helpURL("lazy-initialization-without-synconce"),
+		},
+	}, []ast.Node{(*ast.IfStmt)(nil)}
+}
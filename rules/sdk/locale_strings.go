@@ -0,0 +1,200 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the case mappings of strings stored or hashed by the state
+// machine: strings.ToUpper and the like follow the Unicode tables of the Go
+// version the node is built with, so the keys and the hashes derived from
+// them can change with the Go version. The values only logged or formatted in
+// errors are left out.
+
+type localeStrings struct {
+	gosec.MetaData
+}
+
+func (r *localeStrings) ID() string {
+	return r.MetaData.ID
+}
+
+// caseMappings lists the functions mapping the case of strings per package
+var caseMappings = map[string]map[string]bool{
+	"strings": {
+		"Title":   true,
+		"ToLower": true,
+		"ToTitle": true,
+		"ToUpper": true,
+	},
+	"bytes": {
+		"Title":   true,
+		"ToLower": true,
+		"ToTitle": true,
+		"ToUpper": true,
+	},
+}
+
+// outputMethods lists the methods of the loggers, whatever their types
+var outputMethods = map[string]bool{
+	"Debug":   true,
+	"Debugf":  true,
+	"Error":   true,
+	"Errorf":  true,
+	"Fatal":   true,
+	"Fatalf":  true,
+	"Info":    true,
+	"Infof":   true,
+	"Log":     true,
+	"Logf":    true,
+	"Print":   true,
+	"Printf":  true,
+	"Println": true,
+	"Warn":    true,
+	"Warnf":   true,
+}
+
+// caseMapping returns the name of the case mapping called by call, if any,
+// e.g. strings.ToUpper.
+func caseMapping(call *ast.CallExpr, ctx *gosec.Context) string {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil || !caseMappings[fn.Pkg().Path()][fn.Name()] {
+		return ""
+	}
+	return fn.Pkg().Name() + "." + fn.Name()
+}
+
+// isOutputCall returns true if the call only logs or prints its arguments, or
+// formats them into an error.
+func isOutputCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		return outputMethods[fn.Name()]
+	}
+	if fn.Pkg() == nil {
+		return false
+	}
+	switch fn.Pkg().Path() {
+	case "log":
+		return true
+	case "fmt":
+		switch fn.Name() {
+		case "Print", "Printf", "Println", "Fprint", "Fprintf", "Fprintln", "Errorf":
+			return true
+		}
+	case "errors":
+		return fn.Name() == "New"
+	}
+	return false
+}
+
+func (r *localeStrings) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil || ctx.SkipTestFile() || isCommandPkg(ctx) || isCLIPkg(ctx) {
+		return nil, nil
+	}
+
+	var (
+		mappings []*ast.CallExpr
+		assigned = make(map[*ast.CallExpr]types.Object) // the mappings assigned to a local variable
+		outputs  = make(map[ast.Expr]bool)              // the arguments of the output calls
+		uses     = make(map[types.Object][]*ast.Ident)
+		stack    []ast.Node
+	)
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+
+		switch node := n.(type) {
+		case *ast.Ident:
+			if obj := ctx.Info.Uses[node]; obj != nil {
+				uses[obj] = append(uses[obj], node)
+			}
+		case *ast.CallExpr:
+			if isOutputCall(node, ctx) {
+				for _, arg := range node.Args {
+					outputs[arg] = true
+				}
+			}
+			if caseMapping(node, ctx) == "" {
+				return true
+			}
+			mappings = append(mappings, node)
+			if len(stack) < 2 {
+				return true
+			}
+			// The mappings defined into a local variable are followed through
+			// the uses of the variable
+			if assign, ok := stack[len(stack)-2].(*ast.AssignStmt); ok && len(assign.Lhs) == len(assign.Rhs) {
+				for i, rhs := range assign.Rhs {
+					ident, ok := assign.Lhs[i].(*ast.Ident)
+					if rhs != node || !ok {
+						continue
+					}
+					if obj := ctx.Info.Defs[ident]; obj != nil {
+						assigned[node] = obj
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	for _, call := range mappings {
+		if outputs[call] {
+			continue
+		}
+		if obj, ok := assigned[call]; ok && len(uses[obj]) > 0 {
+			logged := true
+			for _, use := range uses[obj] {
+				if !outputs[use] {
+					logged = false
+				}
+			}
+			if logged {
+				continue
+			}
+		}
+		return gosec.NewIssue(ctx, call, r.ID(), fmt.Sprintf(r.What, caseMapping(call, ctx)), r.Severity, r.Confidence), nil
+	}
+	return nil, nil
+}
+
+// NewLocaleStringsCheck flags the case mappings of strings whose results are
+// not only logged or formatted into errors.
+func NewLocaleStringsCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &localeStrings{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.Medium,
+			What:       "%s follows the Unicode tables of the Go version, use golang.org/x/text/cases with an explicit language or an ASCII-only mapping for the values stored or hashed",
+			HelpURL:    helpURL("unicode-case-mappings-of-stored-or-hashed-strings"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
@@ -0,0 +1,106 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the resources released by a defer inside a loop: the
+// deferred calls only run when the function returns, so every iteration keeps
+// its file, lock or context until then, and a long loop exhausts the file
+// descriptors or deadlocks on the lock taken again.
+
+type loopDefer struct {
+	gosec.MetaData
+}
+
+func (r *loopDefer) ID() string {
+	return r.MetaData.ID
+}
+
+// releaseMethods lists the methods releasing a resource
+var releaseMethods = map[string]bool{
+	"Close":   true,
+	"Release": true,
+	"RUnlock": true,
+	"Stop":    true,
+	"Unlock":  true,
+}
+
+// releasedResource returns the name of the release deferred by the call, e.g.
+// f.Close or cancel, if any.
+func releasedResource(call *ast.CallExpr, ctx *gosec.Context) string {
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		if releaseMethods[fn.Sel.Name] {
+			if ident, ok := fn.X.(*ast.Ident); ok {
+				return ident.Name + "." + fn.Sel.Name
+			}
+			return fn.Sel.Name
+		}
+	case *ast.Ident:
+		if typ := ctx.Info.TypeOf(fn); typ != nil && typ.String() == "context.CancelFunc" {
+			return fn.Name
+		}
+	}
+	return ""
+}
+
+func (r *loopDefer) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	var body *ast.BlockStmt
+	switch loop := node.(type) {
+	case *ast.ForStmt:
+		body = loop.Body
+	case *ast.RangeStmt:
+		body = loop.Body
+	default:
+		return nil, nil
+	}
+
+	var issue *gosec.Issue
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.FuncLit:
+			// The deferred calls of a closure run when the closure returns
+			return false
+		case *ast.ForStmt, *ast.RangeStmt:
+			// The nested loops are matched on their own
+			return false
+		case *ast.DeferStmt:
+			if name := releasedResource(stmt.Call, ctx); name != "" {
+				issue = gosec.NewIssue(ctx, stmt, r.ID(), fmt.Sprintf(r.What, name), r.Severity, r.Confidence)
+			}
+		}
+		return issue == nil
+	})
+	return issue, nil
+}
+
+// NewLoopDeferCheck flags the releases of resources deferred inside loops.
+func NewLoopDeferCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &loopDefer{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "Deferred %s inside a loop only runs when the function returns, release the resource within the iteration",
+			HelpURL:    helpURL("releases-of-resources-deferred-inside-loops"),
+		},
+	}, []ast.Node{(*ast.ForStmt)(nil), (*ast.RangeStmt)(nil)}
+}
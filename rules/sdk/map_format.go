@@ -0,0 +1,175 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass tracks the strings formatted from values holding maps: fmt sorts
+// the keys of the maps since Go 1.12, but not the entries of a sync.Map nor
+// the maps of the older toolchains, so such strings must not be used as keys,
+// hashed or persisted. It is an opt-in rule given the Go 1.12 guarantee.
+
+type mapFormat struct {
+	gosec.MetaData
+}
+
+func (r *mapFormat) ID() string {
+	return r.MetaData.ID
+}
+
+// containsMap returns true if the values of typ hold a map, such as the maps
+// themselves, the structs with map fields and sync.Map.
+func containsMap(typ types.Type, seen map[types.Type]bool) bool {
+	if seen[typ] {
+		return false
+	}
+	seen[typ] = true
+	switch t := typ.Underlying().(type) {
+	case *types.Map:
+		return true
+	case *types.Pointer:
+		return containsMap(t.Elem(), seen)
+	case *types.Slice:
+		return containsMap(t.Elem(), seen)
+	case *types.Array:
+		return containsMap(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if containsMap(t.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isMapFormat returns true for the fmt.Sprint functions formatting a value
+// holding a map.
+func isMapFormat(call *ast.CallExpr, ctx *gosec.Context) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" || !strings.HasPrefix(fn.Name(), "Sprint") {
+		return false
+	}
+	for _, arg := range call.Args {
+		if typ := ctx.Info.TypeOf(arg); typ != nil && containsMap(typ, make(map[types.Type]bool)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPersistCall returns true for the calls writing to a store, such as the
+// Set method of the KVStores.
+func isPersistCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	selection, ok := ctx.Info.Selections[sel]
+	return ok && selection.Kind() == types.MethodVal && (sel.Sel.Name == "Set" || sel.Sel.Name == "Put")
+}
+
+func (r *mapFormat) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil {
+		return nil, nil
+	}
+
+	// The formatted strings, either used straight away or assigned to variables.
+	formatted := make(map[ast.Node]bool)
+	vars := make(map[types.Object]bool)
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if isMapFormat(node, ctx) {
+				formatted[node] = true
+			}
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, rhs := range node.Rhs {
+				if call, ok := rhs.(*ast.CallExpr); ok && isMapFormat(call, ctx) {
+					if ident, ok := node.Lhs[i].(*ast.Ident); ok && ident.Name != "_" {
+						vars[ctx.Info.ObjectOf(ident)] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+	if len(formatted) == 0 {
+		return nil, nil
+	}
+
+	usesFormatted := func(expr ast.Node) bool {
+		found := false
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if formatted[n] {
+				found = true
+			}
+			if ident, ok := n.(*ast.Ident); ok && vars[ctx.Info.ObjectOf(ident)] {
+				found = true
+			}
+			return !found
+		})
+		return found
+	}
+
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if issue != nil {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.IndexExpr:
+			if _, ok := ctx.Info.TypeOf(node.X).Underlying().(*types.Map); ok && usesFormatted(node.Index) {
+				issue = gosec.NewIssue(ctx, node, r.ID(), r.What, r.Severity, r.Confidence)
+			}
+		case *ast.CallExpr:
+			if !isHashCall(node, ctx) && !isPersistCall(node, ctx) {
+				return true
+			}
+			for _, arg := range node.Args {
+				if usesFormatted(arg) {
+					issue = gosec.NewIssue(ctx, node, r.ID(), r.What, r.Severity, r.Confidence)
+				}
+			}
+		}
+		return issue == nil
+	})
+	return issue, nil
+}
+
+// NewMapFormatCheck flags the strings formatted from values holding maps which
+// are used as map keys, hashed or written to a store. It is an opt-in rule.
+func NewMapFormatCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &mapFormat{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Low,
+			What:       "The formatting of a value holding a map may not be deterministic, do not use it as a key, a hash input or a persisted string",
+			HelpURL:    helpURL("formatting-of-values-holding-maps-opt-in"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
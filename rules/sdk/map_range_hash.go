@@ -0,0 +1,105 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass tracks the entries of a map iteration into a hash: the hash of the
+// entries concatenated in iteration order differs from one node to another,
+// which breaks consensus on any state derived from it.
+
+type mapRangeHash struct {
+	gosec.MetaData
+}
+
+func (mh *mapRangeHash) ID() string {
+	return mh.MetaData.ID
+}
+
+// rangeVars returns the key and value variables of a range statement.
+func rangeVars(rangeStmt *ast.RangeStmt, ctx *gosec.Context) map[types.Object]bool {
+	vars := make(map[types.Object]bool)
+	for _, expr := range []ast.Expr{rangeStmt.Key, rangeStmt.Value} {
+		if ident, ok := expr.(*ast.Ident); ok && ident.Name != "_" {
+			if obj := ctx.Info.ObjectOf(ident); obj != nil {
+				vars[obj] = true
+			}
+		}
+	}
+	return vars
+}
+
+func (mh *mapRangeHash) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if pkgExcusedFromMapRangingChecks(ctx) {
+		return nil, nil
+	}
+
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil {
+		return nil, nil
+	}
+
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if issue != nil {
+			return false
+		}
+		rangeStmt, ok := n.(*ast.RangeStmt)
+		if !ok || !isMapRange(rangeStmt, ctx) {
+			return true
+		}
+
+		// The map entries are written into the hash straight from the loop.
+		vars := rangeVars(rangeStmt, ctx)
+		direct := false
+		ast.Inspect(rangeStmt.Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && isHashCall(call, ctx) && refersTo(call, vars, ctx) {
+				direct = true
+			}
+			return !direct
+		})
+		if direct {
+			issue = gosec.NewIssue(ctx, rangeStmt, mh.ID(), mh.What, mh.Severity, gosec.High)
+			return false
+		}
+
+		// The map entries are collected in the loop and hashed afterwards without sorting.
+		if unsortedSink(funcDecl.Body, rangeStmt, rangeOutputs(rangeStmt, ctx), isHashCall, ctx) {
+			issue = gosec.NewIssue(ctx, rangeStmt, mh.ID(), mh.What, mh.Severity, mh.Confidence)
+			return false
+		}
+		return true
+	})
+	return issue, nil
+}
+
+// NewMapRangeHashCheck flags the map iterations whose entries are written into
+// a hash, either from within the loop or afterwards without being sorted.
+func NewMapRangeHashCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &mapRangeHash{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.Medium,
+			What:       "Non-deterministic map iteration is written into a hash; sort the keys before hashing the entries",
+			HelpURL:    helpURL("map-iteration-written-into-a-hash"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
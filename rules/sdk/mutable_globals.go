@@ -0,0 +1,142 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass is advisory: the package level variables outlive the blocks, so
+// any state they hold may leak from one block to the next and differ between
+// the nodes which restarted and the ones which did not.
+
+// globalDirective acknowledges an intentional global variable.
+const globalDirective = "gosec:global"
+
+type mutableGlobal struct {
+	gosec.MetaData
+}
+
+func (r *mutableGlobal) ID() string {
+	return r.MetaData.ID
+}
+
+func hasGlobalDirective(groups ...*ast.CommentGroup) bool {
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, comment := range group.List {
+			if strings.Contains(comment.Text, globalDirective) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isSyncType(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	path := named.Obj().Pkg().Path()
+	return path == "sync" || path == "sync/atomic"
+}
+
+// isMutableType returns true for the maps, slices, pointers and basic types,
+// which are the types of the variables holding state.
+func isMutableType(typ types.Type) bool {
+	if isSyncType(typ) {
+		return false
+	}
+	switch typ.Underlying().(type) {
+	case *types.Map, *types.Slice, *types.Pointer, *types.Basic:
+		return true
+	default:
+		return false
+	}
+}
+
+// packageVarSpec returns the package level var declaration of the file and
+// its spec declaring the name, nil if the name is not declared by one.
+func packageVarSpec(name *ast.Ident, file *ast.File) (*ast.GenDecl, *ast.ValueSpec) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR || name.Pos() < genDecl.Pos() || name.End() > genDecl.End() {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+				for _, declared := range valueSpec.Names {
+					if declared == name {
+						return genDecl, valueSpec
+					}
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (r *mutableGlobal) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	name, ok := node.(*ast.Ident)
+	if !ok || name.Name == "_" {
+		return nil, nil
+	}
+	obj, ok := ctx.Info.Defs[name].(*types.Var)
+	if !ok || obj.Parent() != ctx.Pkg.Scope() || !isMutableType(obj.Type()) {
+		return nil, nil
+	}
+	// the main packages and the simulations are not run by the nodes, and may hold state of their own
+	if ctx.SkipTestFile() || ctx.Pkg.Name() == "main" || isSimulationPkg(ctx) {
+		return nil, nil
+	}
+	decl, spec := packageVarSpec(name, ctx.Root)
+	if decl == nil || hasGlobalDirective(decl.Doc, spec.Doc, spec.Comment) {
+		return nil, nil
+	}
+
+	// The exported variables can be mutated from any package.
+	severity := r.Severity
+	if name.IsExported() {
+		severity = gosec.Medium
+	}
+	what := fmt.Sprintf(r.What, name.Name, obj.Type())
+	return gosec.NewIssue(ctx, name, r.ID(), what, severity, r.Confidence), nil
+}
+
+// NewMutableGlobalCheck flags each package level variable of a mutable type,
+// unless it is acknowledged with a "gosec:global" comment.
+func NewMutableGlobalCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &mutableGlobal{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.Medium,
+			What:       "Package level variable %s of type %s may leak state between blocks; acknowledge intentional globals with a //gosec:global comment",
+			HelpURL:    helpURL("mutable-package-level-variables"),
+		},
+	}, []ast.Node{(*ast.Ident)(nil)}
+}
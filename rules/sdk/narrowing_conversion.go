@@ -0,0 +1,152 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// This pass flags the conversions of 64-bit integers, such as coin amounts, to
+// narrower types: int and uint are 32-bit wide on 32-bit platforms, so such a
+// conversion overflows on some nodes only and their states diverge.
+
+// sizes32 are the sizes of the types on the 32-bit platforms, where int is the
+// narrowest.
+var sizes32 = types.SizesFor("gc", "386")
+
+type narrowingConversion struct {
+	gosec.MetaData
+}
+
+func (r *narrowingConversion) ID() string {
+	return r.MetaData.ID
+}
+
+func integerType(typ types.Type) (*types.Basic, bool) {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return basic, ok && basic.Info()&types.IsInteger != 0
+}
+
+// comparesTo returns true if cond compares the variable obj to a bound.
+func comparesTo(cond ast.Expr, obj types.Object, ctx *gosec.Context) bool {
+	found := false
+	ast.Inspect(cond, func(n ast.Node) bool {
+		binary, ok := n.(*ast.BinaryExpr)
+		if !ok {
+			return !found
+		}
+		switch binary.Op {
+		case token.LSS, token.LEQ, token.GTR, token.GEQ:
+			for _, operand := range []ast.Expr{binary.X, binary.Y} {
+				if ident, ok := operand.(*ast.Ident); ok && ctx.Info.ObjectOf(ident) == obj {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isBoundsChecked returns true if the conversion is guarded by a comparison of
+// its operand, either in an enclosing if statement or in an if statement which
+// precedes it in the same block.
+func isBoundsChecked(call *ast.CallExpr, ctx *gosec.Context) bool {
+	ident, ok := call.Args[0].(*ast.Ident)
+	if !ok {
+		return false
+	}
+	obj := ctx.Info.ObjectOf(ident)
+	if obj == nil {
+		return false
+	}
+
+	path, _ := astutil.PathEnclosingInterval(ctx.Root, call.Pos(), call.End())
+	var block *ast.BlockStmt
+	var stmt ast.Node
+	for i, node := range path {
+		if ifStmt, ok := node.(*ast.IfStmt); ok && comparesTo(ifStmt.Cond, obj, ctx) {
+			return true
+		}
+		if b, ok := node.(*ast.BlockStmt); ok && block == nil && i > 0 {
+			block = b
+			stmt = path[i-1]
+		}
+	}
+	if block == nil {
+		return false
+	}
+	for _, s := range block.List {
+		if s == stmt {
+			break
+		}
+		if ifStmt, ok := s.(*ast.IfStmt); ok && comparesTo(ifStmt.Cond, obj, ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *narrowingConversion) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || ctx.SkipTestFile() {
+		return nil, nil
+	}
+	fun, ok := ctx.Info.Types[call.Fun]
+	if !ok || !fun.IsType() {
+		return nil, nil
+	}
+	arg, ok := ctx.Info.Types[call.Args[0]]
+	if !ok || arg.Value != nil {
+		// The overflow of a constant conversion is a compile error.
+		return nil, nil
+	}
+
+	dst, ok := integerType(fun.Type)
+	if !ok {
+		return nil, nil
+	}
+	src, ok := integerType(arg.Type)
+	if !ok || (src.Kind() != types.Int64 && src.Kind() != types.Uint64) {
+		return nil, nil
+	}
+	if sizes32.Sizeof(dst) >= sizes32.Sizeof(src) || isBoundsChecked(call, ctx) {
+		return nil, nil
+	}
+
+	what := fmt.Sprintf(r.What, arg.Type, fun.Type)
+	return gosec.NewIssue(ctx, call, r.ID(), what, r.Severity, r.Confidence), nil
+}
+
+// NewNarrowingConversionCheck flags the conversions of 64-bit integers to the
+// types which are narrower on 32-bit platforms, unless a bounds check of the
+// converted variable guards them.
+func NewNarrowingConversionCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &narrowingConversion{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "Conversion of %s to %s may overflow on 32-bit platforms; check the bounds or use the cosmossdk.io/math types",
+			HelpURL:    helpURL("narrowing-conversions-of-64-bit-integers"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
@@ -0,0 +1,70 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass is a hygiene check: os.Exit skips the deferred functions, such as
+// the ones flushing or closing the stores, and takes the decision to stop the
+// node away from the caller.
+
+type osExit struct {
+	gosec.MetaData
+}
+
+func (r *osExit) ID() string {
+	return r.MetaData.ID
+}
+
+func isOsExitCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "os" && fn.Name() == "Exit"
+}
+
+func (r *osExit) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil || ctx.SkipTestFile() || funcDecl.Name.Name == "TestMain" || isCommandPkg(ctx) {
+		return nil, nil
+	}
+
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && issue == nil && isOsExitCall(call, ctx) {
+			issue = gosec.NewIssue(ctx, call, r.ID(), r.What, r.Severity, r.Confidence)
+		}
+		return issue == nil
+	})
+	return issue, nil
+}
+
+// NewOsExitCheck flags the calls of os.Exit outside of the tests, of the main
+// and cmd packages, and of the TestMain functions.
+func NewOsExitCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &osExit{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "os.Exit skips the deferred functions and must only be called by the commands, return an error instead",
+			HelpURL:    helpURL("calls-of-osexit-outside-of-the-commands"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
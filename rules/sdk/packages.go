@@ -0,0 +1,131 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// configuredPackages returns the packages listed in the configuration of the
+// rule, and false if the rule doesn't configure them:
+//
+//	{"G709": {"packages": ["crypto/*", "encoding/*"]}}
+func configuredPackages(id string, conf gosec.Config) ([]string, bool) {
+	ruleConf, ok := conf[id].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	configPackages, ok := ruleConf["packages"].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	packages := []string{}
+	for _, pkg := range configPackages {
+		if pkg, ok := pkg.(string); ok {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages, true
+}
+
+// matchesPackage returns true if path is one of the packages, where a package
+// ending in "/*" matches all of the packages below it.
+func matchesPackage(path string, packages []string) bool {
+	for _, pkg := range packages {
+		if strings.HasSuffix(pkg, "/*") {
+			if strings.HasPrefix(path, strings.TrimSuffix(pkg, "*")) {
+				return true
+			}
+		} else if path == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// The rules leave out the packages of some roles in an application, e.g. the
+// commands own the process and may exit it. Each rule combines the roles it
+// leaves out, so that changing the packages of a role is a decision about
+// the role, not about a rule.
+
+// pkgNameOrDir returns true if the package is named after one of the names or
+// is under a directory named after one of them.
+func pkgNameOrDir(ctx *gosec.Context, names ...string) bool {
+	elems := append(strings.Split(ctx.Pkg.Path(), "/"), ctx.Pkg.Name())
+	for _, elem := range elems {
+		for _, name := range names {
+			if elem == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isCommandPkg returns true for the commands, the main packages and the
+// packages under a cmd directory, which own the process.
+func isCommandPkg(ctx *gosec.Context) bool {
+	if ctx.Pkg.Name() == "main" {
+		return true
+	}
+	for _, elem := range strings.Split(ctx.Pkg.Path(), "/") {
+		if elem == "cmd" {
+			return true
+		}
+	}
+	return false
+}
+
+// isServerPkg returns true for the servers, which run the long-lived loops
+// outside of the state logic.
+func isServerPkg(ctx *gosec.Context) bool {
+	return pkgNameOrDir(ctx, "server")
+}
+
+// isCLIPkg returns true for the command line interfaces of the modules, which
+// run on the machine of the user.
+func isCLIPkg(ctx *gosec.Context) bool {
+	return ctx.Pkg.Name() == "cli"
+}
+
+// isSimulationPkg returns true for the simulations and their helpers, which
+// never write to the state of a node.
+func isSimulationPkg(ctx *gosec.Context) bool {
+	switch ctx.Pkg.Name() {
+	case "simapp", "simulation", "testutil":
+		return true
+	default:
+		return false
+	}
+}
+
+// isTestHelperPkg returns true for the packages providing the test helpers to
+// the other packages, which import the testing packages by design.
+func isTestHelperPkg(ctx *gosec.Context) bool {
+	switch ctx.Pkg.Name() {
+	case "simapp", "testutil":
+		return true
+	default:
+		return false
+	}
+}
+
+// isKeeperOrHandlerPkg returns true for the packages handling the messages:
+// the keepers and the handlers.
+func isKeeperOrHandlerPkg(ctx *gosec.Context) bool {
+	return pkgNameOrDir(ctx, "keeper", "handler", "handlers")
+}
@@ -0,0 +1,146 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the keeper methods writing into the maps and slices they
+// are given: the caller still holds them, often as a part of its own state or
+// of a cached value, which the keeper then changes behind its back.
+
+type paramMutation struct {
+	gosec.MetaData
+}
+
+func (r *paramMutation) ID() string {
+	return r.MetaData.ID
+}
+
+// isKeeperMethod returns true for the exported methods of a keeper, i.e. of a
+// type named Keeper or defined in a keeper package.
+func isKeeperMethod(funcDecl *ast.FuncDecl, ctx *gosec.Context) bool {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 || !funcDecl.Name.IsExported() {
+		return false
+	}
+	typ := ctx.Info.TypeOf(funcDecl.Recv.List[0].Type)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+	return strings.HasSuffix(named.Obj().Name(), "Keeper") || ctx.Pkg.Name() == "keeper"
+}
+
+// mutableParams returns the parameters of the function holding a map or a
+// slice.
+func mutableParams(funcDecl *ast.FuncDecl, ctx *gosec.Context) map[types.Object]bool {
+	params := make(map[types.Object]bool)
+	for _, field := range funcDecl.Type.Params.List {
+		for _, name := range field.Names {
+			obj := ctx.Info.Defs[name]
+			if obj == nil {
+				continue
+			}
+			switch obj.Type().Underlying().(type) {
+			case *types.Map, *types.Slice:
+				params[obj] = true
+			}
+		}
+	}
+	return params
+}
+
+// paramOf returns the parameter among params the expression refers to, if any.
+func paramOf(expr ast.Expr, params map[types.Object]bool, ctx *gosec.Context) types.Object {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	if obj := ctx.Info.Uses[ident]; params[obj] {
+		return obj
+	}
+	return nil
+}
+
+// mutatesParam returns true if the statement writes into an element of a
+// parameter, or appends to a parameter and assigns it back.
+func mutatesParam(stmt *ast.AssignStmt, params map[types.Object]bool, ctx *gosec.Context) bool {
+	for i, lhs := range stmt.Lhs {
+		if index, ok := lhs.(*ast.IndexExpr); ok && paramOf(index.X, params, ctx) != nil {
+			return true
+		}
+		if len(stmt.Lhs) != len(stmt.Rhs) {
+			continue
+		}
+		param := paramOf(lhs, params, ctx)
+		call, ok := stmt.Rhs[i].(*ast.CallExpr)
+		if param == nil || !ok || len(call.Args) == 0 {
+			continue
+		}
+		if fn, ok := call.Fun.(*ast.Ident); ok && ctx.Info.Uses[fn] == types.Universe.Lookup("append") && paramOf(call.Args[0], params, ctx) == param {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *paramMutation) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil || ctx.SkipTestFile() || !isKeeperMethod(funcDecl, ctx) {
+		return nil, nil
+	}
+	params := mutableParams(funcDecl, ctx)
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			if mutatesParam(stmt, params, ctx) {
+				issue = gosec.NewIssue(ctx, stmt, r.ID(), r.What, r.Severity, r.Confidence)
+			}
+		case *ast.IncDecStmt:
+			if index, ok := stmt.X.(*ast.IndexExpr); ok && paramOf(index.X, params, ctx) != nil {
+				issue = gosec.NewIssue(ctx, stmt, r.ID(), r.What, r.Severity, r.Confidence)
+			}
+		}
+		return issue == nil
+	})
+	return issue, nil
+}
+
+// NewParamMutationCheck flags the exported keeper methods writing into the
+// maps and slices passed as parameters.
+func NewParamMutationCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &paramMutation{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "Keeper method mutating a map or a slice owned by the caller, copy it before changing it",
+			HelpURL:    helpURL("keeper-methods-mutating-their-map-or-slice-parameters"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
@@ -0,0 +1,102 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass flags the maps created with keys holding pointers: the pointers
+// are compared by address, so two equal values allocated apart are different
+// keys, and anything ordered or hashed after the addresses differs from one
+// run to the other.
+
+type pointerMapKeys struct {
+	gosec.MetaData
+}
+
+func (r *pointerMapKeys) ID() string {
+	return r.MetaData.ID
+}
+
+// hasPointer returns true if typ is a pointer, or a struct or an array holding
+// a pointer.
+func hasPointer(typ types.Type, seen map[types.Type]bool) bool {
+	if seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	switch t := typ.Underlying().(type) {
+	case *types.Pointer:
+		return true
+	case *types.Basic:
+		return t.Kind() == types.UnsafePointer
+	case *types.Array:
+		return hasPointer(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if hasPointer(t.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *pointerMapKeys) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if ctx.SkipTestFile() {
+		return nil, nil
+	}
+	var typ types.Type
+	switch node := n.(type) {
+	case *ast.CompositeLit:
+		typ = ctx.Info.TypeOf(node)
+	case *ast.CallExpr:
+		ident, ok := node.Fun.(*ast.Ident)
+		if !ok || ident.Name != "make" || len(node.Args) == 0 {
+			return nil, nil
+		}
+		if _, ok := ctx.Info.ObjectOf(ident).(*types.Builtin); !ok {
+			return nil, nil
+		}
+		typ = ctx.Info.TypeOf(node.Args[0])
+	}
+	if typ == nil {
+		return nil, nil
+	}
+	m, ok := typ.Underlying().(*types.Map)
+	if !ok || !hasPointer(m.Key(), make(map[types.Type]bool)) {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewPointerMapKeysCheck flags the map literals and the maps made with keys
+// which are pointers or hold pointers.
+func NewPointerMapKeysCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &pointerMapKeys{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "Map keys holding pointers are compared by address, equal values are different keys and their order changes across runs; key the map by value",
+			HelpURL:    helpURL("map-keys-holding-pointers"),
+		},
+	}, []ast.Node{(*ast.CompositeLit)(nil), (*ast.CallExpr)(nil)}
+}
@@ -0,0 +1,117 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass extends the math/rand entry of the SDK blocklist to the other
+// random number packages, which are just as non-deterministic. Their types are
+// matched as well, so that a package re-exporting them, e.g. with a type alias
+// and a constructor, does not hide them.
+
+type randImport struct {
+	gosec.MetaData
+	Blocklisted map[string]string
+}
+
+func (r *randImport) ID() string {
+	return r.MetaData.ID
+}
+
+// randPackages lists the known random number packages besides math/rand and
+// crypto/rand, which are blocklisted by G702
+var randPackages = []string{
+	"golang.org/x/exp/rand",
+	"math/rand/v2",
+	"github.com/valyala/fastrand",
+	"lukechampine.com/frand",
+	"pgregory.net/rand",
+	"github.com/seehuhn/mt19937",
+}
+
+// randType returns the named type, or the type pointed to, of a blocklisted
+// package.
+func (r *randImport) randType(typ types.Type) (*types.Named, bool) {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil, false
+	}
+	_, ok = r.Blocklisted[named.Obj().Pkg().Path()]
+	return named, ok
+}
+
+func (r *randImport) Match(n ast.Node, c *gosec.Context) (*gosec.Issue, error) {
+	if !forbiddenFromBlockedImports(c) {
+		return nil, nil
+	}
+	switch node := n.(type) {
+	case *ast.ImportSpec:
+		if description, ok := r.Blocklisted[unquote(node.Path.Value)]; ok {
+			return gosec.NewIssue(c, node, r.ID(), description, r.Severity, r.Confidence), nil
+		}
+	case *ast.CallExpr:
+		typ := c.Info.TypeOf(node)
+		if typ == nil {
+			return nil, nil
+		}
+		named, ok := r.randType(typ)
+		if !ok {
+			return nil, nil
+		}
+		// The calls of the blocklisted packages are covered by their import
+		_, obj := gosec.GetCallObject(node, c)
+		if obj == nil || obj.Pkg() == nil || obj.Pkg() == named.Obj().Pkg() {
+			return nil, nil
+		}
+		randPkg := named.Obj().Pkg()
+		description := fmt.Sprintf("Use of %s.%s re-exported by %s from the blocklisted random number package %s",
+			randPkg.Name(), named.Obj().Name(), obj.Pkg().Path(), randPkg.Path())
+		return gosec.NewIssue(c, node, r.ID(), description, r.Severity, r.Confidence), nil
+	}
+	return nil, nil
+}
+
+// NewRandImport reports the imports of the random number packages other than
+// math/rand, and the uses of their types re-exported by other packages. More
+// packages are blocklisted, or some of the known ones allowed, with:
+//
+//	{"G732": {"packages": ["example.com/prng"], "disabled": ["math/rand/v2"]}}
+func NewRandImport(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	configured, _ := configuredPackages(id, conf)
+	paths := append(append([]string{}, randPackages...), configured...)
+	blocklist := make(map[string]string, len(paths))
+	for _, path := range paths {
+		blocklist[path] = "Blocklisted import " + path
+	}
+
+	return &randImport{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			HelpURL:    helpURL("import-blocklist-for-the-other-random-number-packages"),
+		},
+		Blocklisted: enabledEntries(id, conf, blocklist),
+	}, []ast.Node{(*ast.ImportSpec)(nil), (*ast.CallExpr)(nil)}
+}
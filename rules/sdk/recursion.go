@@ -0,0 +1,94 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"regexp"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass is advisory: a handler recursing as deep as a message asks for,
+// e.g. over nested messages or a chain of accounts, can overflow the stack of
+// the goroutine, which crashes the node instead of failing the transaction.
+
+// reDepthParam matches the names of the parameters bounding a recursion.
+var reDepthParam = regexp.MustCompile(`(?i)depth|level|limit|remaining|budget|^max`)
+
+type recursion struct {
+	gosec.MetaData
+}
+
+func (r *recursion) ID() string {
+	return r.MetaData.ID
+}
+
+// hasDepthParam returns true if the function takes an integer parameter named
+// after a depth bound, e.g. depth or maxDepth.
+func hasDepthParam(funcDecl *ast.FuncDecl, ctx *gosec.Context) bool {
+	for _, field := range funcDecl.Type.Params.List {
+		for _, name := range field.Names {
+			obj := ctx.Info.Defs[name]
+			if obj == nil || !reDepthParam.MatchString(name.Name) {
+				continue
+			}
+			if _, ok := integerType(obj.Type()); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *recursion) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil || ctx.SkipTestFile() || !isKeeperOrHandlerPkg(ctx) {
+		return nil, nil
+	}
+	fn, ok := ctx.Info.Defs[funcDecl.Name].(*types.Func)
+	if !ok || hasDepthParam(funcDecl, ctx) {
+		return nil, nil
+	}
+
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return issue == nil
+		}
+		if _, obj := gosec.GetCallObject(call, ctx); obj == fn {
+			issue = gosec.NewIssue(ctx, call, r.ID(), fmt.Sprintf(r.What, fn.Name()), r.Severity, r.Confidence)
+		}
+		return issue == nil
+	})
+	return issue, nil
+}
+
+// NewRecursionCheck flags the functions of the keepers and of the handlers
+// calling themselves without a parameter bounding the depth of the recursion.
+func NewRecursionCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &recursion{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.Low,
+			What:       "Function %s calls itself without a depth bound, a deep input can overflow the stack; pass and check a depth parameter",
+			HelpURL:    helpURL("recursive-handlers-without-a-depth-bound"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
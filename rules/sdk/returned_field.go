@@ -0,0 +1,176 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// This pass flags the exported methods returning an unexported slice field of
+// their receiver: the caller shares the backing array of the field, so
+// appending to the result or setting its elements can overwrite the state
+// cached by a keeper without going through its methods.
+
+type returnedField struct {
+	gosec.MetaData
+}
+
+func (r *returnedField) ID() string {
+	return r.MetaData.ID
+}
+
+// receiverField returns the unexported slice field of the receiver which expr
+// refers to, directly or by slicing it, e.g. k.denoms or k.cache.denoms[:n].
+func receiverField(expr ast.Expr, recv types.Object, ctx *gosec.Context) *types.Var {
+	expr = astutil.Unparen(expr)
+	if slice, ok := expr.(*ast.SliceExpr); ok && !slice.Slice3 {
+		expr = astutil.Unparen(slice.X)
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	selection, ok := ctx.Info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal {
+		return nil
+	}
+	field, ok := selection.Obj().(*types.Var)
+	if !ok || field.Exported() {
+		return nil
+	}
+	if _, ok := field.Type().Underlying().(*types.Slice); !ok {
+		return nil
+	}
+
+	// The field must be reached from the receiver, through other fields.
+	root := sel.X
+	for {
+		switch x := astutil.Unparen(root).(type) {
+		case *ast.SelectorExpr:
+			root = x.X
+			continue
+		case *ast.StarExpr:
+			root = x.X
+			continue
+		case *ast.Ident:
+			if ctx.Info.ObjectOf(x) == recv {
+				return field
+			}
+		}
+		return nil
+	}
+}
+
+// fieldAliases returns the local variables of the method which are only ever
+// assigned a slice field of the receiver, with the field they alias.
+func fieldAliases(funcDecl *ast.FuncDecl, recv types.Object, ctx *gosec.Context) map[types.Object]*types.Var {
+	aliases := make(map[types.Object]*types.Var)
+	reassigned := make(map[types.Object]bool)
+	assign := func(lhs *ast.Ident, rhs ast.Expr) {
+		obj := ctx.Info.ObjectOf(lhs)
+		if obj == nil {
+			return
+		}
+		if field := receiverField(rhs, recv, ctx); field != nil && !reassigned[obj] {
+			aliases[obj] = field
+			return
+		}
+		reassigned[obj] = true
+		delete(aliases, obj)
+	}
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				var rhs ast.Expr
+				if len(node.Lhs) == len(node.Rhs) {
+					rhs = node.Rhs[i]
+				}
+				assign(ident, rhs)
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				var value ast.Expr
+				if len(node.Names) == len(node.Values) {
+					value = node.Values[i]
+				}
+				assign(name, value)
+			}
+		}
+		return true
+	})
+	return aliases
+}
+
+func (r *returnedField) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Recv == nil || funcDecl.Body == nil || !funcDecl.Name.IsExported() || ctx.SkipTestFile() {
+		return nil, nil
+	}
+	if len(funcDecl.Recv.List) != 1 || len(funcDecl.Recv.List[0].Names) != 1 {
+		return nil, nil
+	}
+	recv := ctx.Info.Defs[funcDecl.Recv.List[0].Names[0]]
+	if recv == nil {
+		return nil, nil
+	}
+
+	aliases := fieldAliases(funcDecl, recv, ctx)
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, result := range stmt.Results {
+				field := receiverField(result, recv, ctx)
+				if ident, ok := result.(*ast.Ident); ok && field == nil {
+					field = aliases[ctx.Info.ObjectOf(ident)]
+				}
+				if field != nil {
+					what := fmt.Sprintf(r.What, funcDecl.Name.Name, field.Name())
+					issue = gosec.NewIssue(ctx, result, r.ID(), what, r.Severity, r.Confidence)
+					return false
+				}
+			}
+		}
+		return issue == nil
+	})
+	return issue, nil
+}
+
+// NewReturnedFieldCheck flags the exported methods returning an unexported
+// slice field of their receiver, directly or through a local variable, without
+// copying it.
+func NewReturnedFieldCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &returnedField{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "Method %s returns the internal slice %s, the callers appending to it can overwrite the state; return a copy of the slice instead",
+			HelpURL:    helpURL("exported-methods-returning-internal-slices"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
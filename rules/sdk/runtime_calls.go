@@ -0,0 +1,79 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass flags the calls tuning the garbage collector or the scheduler of
+// the runtime: the state code runs the same way on every node whatever the
+// load, and a decision depending on the number of goroutines or a forced
+// collection in the middle of a block makes its result or its timing differ
+// between the nodes. It complements the runtime entry of G702, for the
+// packages where the import is allowed.
+
+type runtimeCall struct {
+	gosec.MetaData
+	calls map[string]string
+}
+
+func (r *runtimeCall) ID() string {
+	return r.MetaData.ID
+}
+
+func (r *runtimeCall) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || ctx.SkipTestFile() || isCommandPkg(ctx) {
+		return nil, nil
+	}
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil, nil
+	}
+	if description, ok := r.calls[qualifiedFuncName(fn)]; ok {
+		return gosec.NewIssue(ctx, call, r.ID(), description, r.Severity, r.Confidence), nil
+	}
+	return nil, nil
+}
+
+// NewRuntimeCallCheck flags the calls tuning the runtime outside of the main
+// packages and of the commands. Some of the calls can be allowed with:
+//
+//	{"G744": {"disabled": ["runtime.NumGoroutine"]}}
+func NewRuntimeCallCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &runtimeCall{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			HelpURL:    helpURL("calls-tuning-the-runtime-in-state-code"),
+		},
+		calls: enabledEntries(id, conf, map[string]string{
+			"runtime.GC":                  "Call of runtime.GC forces a collection in the middle of the state code",
+			"runtime.Gosched":             "Call of runtime.Gosched makes the state code depend on the scheduler",
+			"runtime.NumGoroutine":        "Call of runtime.NumGoroutine makes the state code depend on the load of the node",
+			"runtime.GOMAXPROCS":          "Call of runtime.GOMAXPROCS tunes the scheduler from the state code",
+			"runtime/debug.FreeOSMemory":  "Call of debug.FreeOSMemory forces a collection in the middle of the state code",
+			"runtime/debug.SetGCPercent":  "Call of debug.SetGCPercent tunes the garbage collector from the state code",
+			"runtime/debug.SetMaxStack":   "Call of debug.SetMaxStack tunes the runtime from the state code",
+			"runtime/debug.SetMaxThreads": "Call of debug.SetMaxThreads tunes the runtime from the state code",
+		}),
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
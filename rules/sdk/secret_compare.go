@@ -0,0 +1,155 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the comparisons of secrets: bytes.Equal and == return as
+// soon as a byte differs, so the time they take tells how much of a guessed MAC
+// or password is right. The secrets are recognized by the names of the values
+// compared and of their types, which is heuristic, hence the conservative
+// default pattern which can be widened in the configuration.
+
+type secretCompare struct {
+	gosec.MetaData
+	pattern *regexp.Regexp
+	calls   gosec.CallList
+}
+
+func (r *secretCompare) ID() string {
+	return r.MetaData.ID
+}
+
+// snakeCase returns the words of an identifier in lower case joined with
+// underscores, e.g. expected_mac for expectedMAC.
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, c := range runes {
+		if c == '_' {
+			b.WriteRune('_')
+			continue
+		}
+		if i > 0 && unicode.IsUpper(c) && runes[i-1] != '_' &&
+			(unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
+
+// isSecret returns true if an identifier of the expression, or the name of its
+// type, matches the pattern of the secrets.
+func (r *secretCompare) isSecret(expr ast.Expr, ctx *gosec.Context) bool {
+	if named, ok := ctx.Info.TypeOf(expr).(*types.Named); ok && r.pattern.MatchString(snakeCase(named.Obj().Name())) {
+		return true
+	}
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && r.pattern.MatchString(snakeCase(ident.Name)) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isComparedAsBytes returns true if the == comparison of the values of typ
+// compares them byte by byte, i.e. for the strings and the byte arrays.
+func isComparedAsBytes(typ types.Type) bool {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		return t.Info()&types.IsString != 0
+	case *types.Array:
+		basic, ok := t.Elem().Underlying().(*types.Basic)
+		return ok && basic.Kind() == types.Byte
+	}
+	return false
+}
+
+func (r *secretCompare) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if ctx.SkipTestFile() {
+		return nil, nil
+	}
+	var x, y ast.Expr
+	switch node := n.(type) {
+	case *ast.CallExpr:
+		if r.calls.ContainsPkgCallExpr(node, ctx, false) == nil || len(node.Args) != 2 {
+			return nil, nil
+		}
+		x, y = node.Args[0], node.Args[1]
+	case *ast.BinaryExpr:
+		if node.Op != token.EQL && node.Op != token.NEQ {
+			return nil, nil
+		}
+		typ := ctx.Info.TypeOf(node.X)
+		if typ == nil || !isComparedAsBytes(typ) {
+			return nil, nil
+		}
+		x, y = node.X, node.Y
+	default:
+		return nil, nil
+	}
+	// The comparisons with a constant, e.g. the empty string, are left out, the
+	// hardcoded secrets being checked by the hardcoded credentials rule
+	if tv, ok := ctx.Info.Types[x]; ok && tv.Value != nil {
+		return nil, nil
+	}
+	if tv, ok := ctx.Info.Types[y]; ok && tv.Value != nil {
+		return nil, nil
+	}
+	if !r.isSecret(x, ctx) && !r.isSecret(y, ctx) {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewSecretCompareCheck flags the comparisons of secrets with bytes.Equal or
+// ==. The pattern of the secret names, matched against the snake_case form of
+// the identifiers and of the type names, can be set in the configuration.
+func NewSecretCompareCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	pattern := `(^|_)(h?mac|secret|passw(or)?d|priv(ate)?_?key)(_|$)`
+	if val, ok := conf[id]; ok {
+		if ruleConf, ok := val.(map[string]interface{}); ok {
+			if configured, ok := ruleConf["pattern"].(string); ok {
+				pattern = configured
+			}
+		}
+	}
+
+	calls := gosec.NewCallList()
+	calls.Add("bytes", "Equal")
+	return &secretCompare{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Low,
+			What:       "Comparison of secrets which is not constant time, use crypto/subtle.ConstantTimeCompare or hmac.Equal",
+			HelpURL:    helpURL("comparisons-of-secrets-which-are-not-constant-time"),
+		},
+		pattern: regexp.MustCompile(pattern),
+		calls:   calls,
+	}, []ast.Node{(*ast.CallExpr)(nil), (*ast.BinaryExpr)(nil)}
+}
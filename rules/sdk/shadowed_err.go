@@ -0,0 +1,147 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// This pass flags the err variables declared with := in an inner scope which
+// shadow an outer err holding an error that is never checked: the error
+// assigned to the outer variable is dropped, e.g. when the inner block was
+// meant to handle it along with its own.
+
+type shadowedErr struct {
+	gosec.MetaData
+}
+
+func (r *shadowedErr) ID() string {
+	return r.MetaData.ID
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+// shadowedErrVar returns the outer err of the function shadowed by the err
+// defined by ident, if any.
+func shadowedErrVar(ident *ast.Ident, ctx *gosec.Context) *types.Var {
+	inner, ok := ctx.Info.Defs[ident].(*types.Var)
+	if !ok || inner.Parent() == nil || inner.Parent().Parent() == nil {
+		return nil
+	}
+	_, obj := inner.Parent().Parent().LookupParent(ident.Name, ident.Pos())
+	outer, ok := obj.(*types.Var)
+	if !ok || outer.Parent() == nil || outer.Parent() == ctx.Pkg.Scope() || !types.Identical(outer.Type(), errorType) {
+		return nil
+	}
+	return outer
+}
+
+// enclosingBody returns the body of the innermost function of the path which
+// declares the variable, the parameters and the results being left out as
+// they are read by the callers.
+func enclosingBody(path []ast.Node, v *types.Var) *ast.BlockStmt {
+	for _, node := range path {
+		var body *ast.BlockStmt
+		switch fn := node.(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+		if body != nil && body.Pos() <= v.Pos() && v.Pos() < body.End() {
+			return body
+		}
+	}
+	return nil
+}
+
+// isChecked returns true if the value last assigned to v before pos is read
+// anywhere in the body, or if no value is assigned to v before pos.
+func isChecked(body *ast.BlockStmt, v *types.Var, pos token.Pos, ctx *gosec.Context) bool {
+	writes := make(map[*ast.Ident]bool)
+	var lastWrite token.Pos
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for _, lhs := range assign.Lhs {
+			ident, ok := lhs.(*ast.Ident)
+			if !ok || (ctx.Info.Defs[ident] != v && ctx.Info.Uses[ident] != v) {
+				continue
+			}
+			writes[ident] = true
+			if ident.Pos() < pos && ident.Pos() > lastWrite {
+				lastWrite = ident.Pos()
+			}
+		}
+		return true
+	})
+	if lastWrite == token.NoPos {
+		return true
+	}
+
+	checked := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && !writes[ident] && ident.Pos() > lastWrite && ctx.Info.Uses[ident] == v {
+			checked = true
+		}
+		return !checked
+	})
+	return checked
+}
+
+func (r *shadowedErr) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	assign, ok := n.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE {
+		return nil, nil
+	}
+	for _, lhs := range assign.Lhs {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok || ident.Name != "err" {
+			continue
+		}
+		outer := shadowedErrVar(ident, ctx)
+		if outer == nil {
+			continue
+		}
+		path, _ := astutil.PathEnclosingInterval(ctx.Root, assign.Pos(), assign.End())
+		body := enclosingBody(path, outer)
+		if body == nil || isChecked(body, outer, assign.Pos(), ctx) {
+			continue
+		}
+		return gosec.NewIssue(ctx, assign, r.ID(), r.What, r.Severity, r.Confidence), nil
+	}
+	return nil, nil
+}
+
+// NewShadowedErrCheck flags the err variables declared with := which shadow an
+// outer err whose error is never checked.
+func NewShadowedErrCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &shadowedErr{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "Declaration of err shadows an outer err whose error is never checked, assign it with = or check the outer error first",
+			HelpURL:    helpURL("shadowing-of-err-dropping-an-outer-error"),
+		},
+	}, []ast.Node{(*ast.AssignStmt)(nil)}
+}
@@ -0,0 +1,152 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the store keys built from a shared prefix: append writes
+// into the backing array of the prefix when it has room for the new elements,
+// so two keys built from the same package level prefix can overwrite each other.
+
+type sharedAppend struct {
+	gosec.MetaData
+}
+
+func (r *sharedAppend) ID() string {
+	return r.MetaData.ID
+}
+
+// sharedSlice returns the package level slice variable appended to by the call,
+// e.g. KeyPrefix in append(KeyPrefix, addr...).
+func sharedSlice(call *ast.CallExpr, ctx *gosec.Context) types.Object {
+	fn, ok := call.Fun.(*ast.Ident)
+	if !ok || len(call.Args) == 0 || ctx.Info.Uses[fn] != types.Universe.Lookup("append") {
+		return nil
+	}
+	arg, ok := call.Args[0].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	obj, ok := ctx.Info.ObjectOf(arg).(*types.Var)
+	if !ok || obj.Parent() != ctx.Pkg.Scope() {
+		return nil
+	}
+	if _, ok := obj.Type().Underlying().(*types.Slice); !ok {
+		return nil
+	}
+	return obj
+}
+
+// returnedVars returns the variables returned by the function, including the
+// named results of the bare returns.
+func returnedVars(funcDecl *ast.FuncDecl, ctx *gosec.Context) map[types.Object]bool {
+	returned := make(map[types.Object]bool)
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) == 0 {
+				for obj := range namedResults(funcDecl, ctx) {
+					returned[obj] = true
+				}
+			}
+			for _, result := range node.Results {
+				if ident, ok := result.(*ast.Ident); ok {
+					returned[ctx.Info.ObjectOf(ident)] = true
+				}
+			}
+		}
+		return true
+	})
+	return returned
+}
+
+// escapes returns true if the value assigned to the expression outlives the
+// function: a returned variable, a package level variable or a field or an
+// element of another value.
+func escapes(lhs ast.Expr, shared types.Object, returned map[types.Object]bool, ctx *gosec.Context) bool {
+	switch expr := lhs.(type) {
+	case *ast.Ident:
+		obj := ctx.Info.ObjectOf(expr)
+		if obj == nil || obj == shared {
+			return false
+		}
+		return returned[obj] || obj.Parent() == ctx.Pkg.Scope()
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.StarExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *sharedAppend) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil || ctx.SkipTestFile() {
+		return nil, nil
+	}
+
+	returned := returnedVars(funcDecl, ctx)
+	var issue *gosec.Issue
+	report := func(call *ast.CallExpr) {
+		if issue == nil {
+			issue = gosec.NewIssue(ctx, call, r.ID(), r.What, r.Severity, r.Confidence)
+		}
+	}
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.ReturnStmt:
+			for _, result := range stmt.Results {
+				if call, ok := result.(*ast.CallExpr); ok && sharedSlice(call, ctx) != nil {
+					report(call)
+				}
+			}
+		case *ast.AssignStmt:
+			if len(stmt.Lhs) != len(stmt.Rhs) {
+				return true
+			}
+			for i, rhs := range stmt.Rhs {
+				call, ok := rhs.(*ast.CallExpr)
+				if !ok {
+					continue
+				}
+				if shared := sharedSlice(call, ctx); shared != nil && escapes(stmt.Lhs[i], shared, returned, ctx) {
+					report(call)
+				}
+			}
+		}
+		return issue == nil
+	})
+	return issue, nil
+}
+
+// NewSharedAppendCheck flags the slices built by appending to a package level
+// slice which are returned or stored by the function.
+func NewSharedAppendCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &sharedAppend{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.Medium,
+			What:       "Appending to a package level slice can write into its shared backing array, copy the slice before appending",
+			HelpURL:    helpURL("appending-to-package-level-slices"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
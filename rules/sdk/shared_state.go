@@ -0,0 +1,166 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass is advisory: the goroutines and the HTTP handlers run
+// concurrently, so their accesses of the package level variables race with
+// each other unless a mutex guards them, which corrupts the maps and the
+// values the servers of the node share.
+
+type sharedState struct {
+	gosec.MetaData
+}
+
+func (r *sharedState) ID() string {
+	return r.MetaData.ID
+}
+
+// isHandlerType returns true for the signature of the HTTP handlers,
+// func(http.ResponseWriter, *http.Request).
+func isHandlerType(typ types.Type) bool {
+	sig, ok := typ.(*types.Signature)
+	if !ok || sig.Params().Len() != 2 || sig.Results().Len() != 0 {
+		return false
+	}
+	writer, ok := sig.Params().At(0).Type().(*types.Named)
+	if !ok || writer.Obj().Pkg() == nil || writer.Obj().Pkg().Path() != "net/http" || writer.Obj().Name() != "ResponseWriter" {
+		return false
+	}
+	request, ok := sig.Params().At(1).Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := request.Elem().(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "net/http" && named.Obj().Name() == "Request"
+}
+
+// isSyncCall returns true for the calls of the sync and sync/atomic packages,
+// e.g. mu.Lock() or atomic.AddInt64(&count, 1).
+func isSyncCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+	path := fn.Pkg().Path()
+	return path == "sync" || path == "sync/atomic"
+}
+
+// isLocking returns true if the body takes a lock of the sync package.
+func isLocking(body *ast.BlockStmt, ctx *gosec.Context) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && isSyncCall(call, ctx) {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && (sel.Sel.Name == "Lock" || sel.Sel.Name == "RLock") {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isSharedType returns true for the maps, slices and basic types, which are
+// the types of the shared state. The pointers are usually set once at init,
+// e.g. to a logger or to a database handle, and left out.
+func isSharedType(typ types.Type) bool {
+	switch typ.Underlying().(type) {
+	case *types.Map, *types.Slice, *types.Basic:
+		return true
+	default:
+		return false
+	}
+}
+
+// sharedAccess returns the first package level variable of a shared type
+// accessed by the body, outside of the calls of the sync packages and of the
+// nested function literals, which are checked on their own.
+func sharedAccess(body *ast.BlockStmt, ctx *gosec.Context) *ast.Ident {
+	var access *ast.Ident
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			return !isSyncCall(node, ctx)
+		case *ast.Ident:
+			obj, ok := ctx.Info.Uses[node].(*types.Var)
+			if ok && obj.Pkg() == ctx.Pkg && obj.Parent() == ctx.Pkg.Scope() && isSharedType(obj.Type()) {
+				access = node
+			}
+		}
+		return access == nil
+	})
+	return access
+}
+
+func (r *sharedState) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if ctx.SkipTestFile() {
+		return nil, nil
+	}
+	var body *ast.BlockStmt
+	kind := "an HTTP handler"
+	switch n := node.(type) {
+	case *ast.GoStmt:
+		lit, ok := n.Call.Fun.(*ast.FuncLit)
+		if !ok {
+			return nil, nil
+		}
+		body, kind = lit.Body, "a goroutine"
+	case *ast.FuncDecl:
+		if n.Body == nil || !isHandlerType(ctx.Info.TypeOf(n.Name)) {
+			return nil, nil
+		}
+		body = n.Body
+	case *ast.FuncLit:
+		if !isHandlerType(ctx.Info.TypeOf(n)) {
+			return nil, nil
+		}
+		body = n.Body
+	default:
+		return nil, nil
+	}
+
+	if isLocking(body, ctx) {
+		return nil, nil
+	}
+	if access := sharedAccess(body, ctx); access != nil {
+		what := fmt.Sprintf(r.What, access.Name, kind)
+		return gosec.NewIssue(ctx, access, r.ID(), what, r.Severity, r.Confidence), nil
+	}
+	return nil, nil
+}
+
+// NewSharedStateCheck flags the goroutines and the HTTP handlers accessing a
+// package level variable without taking a lock.
+func NewSharedStateCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &sharedState{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Low,
+			What:       "Package level variable %s is accessed from %s without a lock, guard it with a sync.Mutex",
+			HelpURL:    helpURL("shared-state-accessed-concurrently-without-a-lock"),
+		},
+	}, []ast.Node{(*ast.GoStmt)(nil), (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}
+}
@@ -0,0 +1,82 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass flags the conversions between signed and unsigned integers: a
+// negative amount converted to uint64 wraps around to a huge value, and a
+// large uint64 converted to int64 turns negative, so an amount taken from a
+// message can bypass the checks made on the converted value.
+
+type signConversion struct {
+	gosec.MetaData
+}
+
+func (r *signConversion) ID() string {
+	return r.MetaData.ID
+}
+
+func (r *signConversion) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || ctx.SkipTestFile() {
+		return nil, nil
+	}
+	fun, ok := ctx.Info.Types[call.Fun]
+	if !ok || !fun.IsType() {
+		return nil, nil
+	}
+	arg, ok := ctx.Info.Types[call.Args[0]]
+	if !ok || arg.Value != nil {
+		// The conversion of a negative constant is a compile error.
+		return nil, nil
+	}
+
+	dst, ok := integerType(fun.Type)
+	if !ok {
+		return nil, nil
+	}
+	src, ok := integerType(arg.Type)
+	if !ok {
+		return nil, nil
+	}
+	if (src.Info()&types.IsUnsigned != 0) == (dst.Info()&types.IsUnsigned != 0) || isBoundsChecked(call, ctx) {
+		return nil, nil
+	}
+
+	what := fmt.Sprintf(r.What, arg.Type, fun.Type)
+	return gosec.NewIssue(ctx, call, r.ID(), what, r.Severity, r.Confidence), nil
+}
+
+// NewSignConversionCheck flags the conversions of signed integers to unsigned
+// types and of unsigned integers to signed types, unless a sign or bounds check
+// of the converted variable guards them.
+func NewSignConversionCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &signConversion{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.Medium,
+			What:       "Conversion of %s to %s can wrap around the sign; check the value is in range before converting it",
+			HelpURL:    helpURL("conversions-between-signed-and-unsigned-integers"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
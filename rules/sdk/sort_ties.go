@@ -0,0 +1,123 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// This pass is a heuristic: sort.Slice is not stable, so the elements its less
+// function does not tell apart end up in any order. A less function comparing
+// a single field of elements made of several fields likely leaves such ties,
+// e.g. the validators sorted by power alone.
+
+type sortTies struct {
+	gosec.MetaData
+	calls gosec.CallList
+}
+
+func (r *sortTies) ID() string {
+	return r.MetaData.ID
+}
+
+// structElem returns the struct of the elements of a slice of structs or of
+// pointers to structs.
+func structElem(typ types.Type) (*types.Struct, bool) {
+	slice, ok := typ.Underlying().(*types.Slice)
+	if !ok {
+		return nil, false
+	}
+	elem := slice.Elem()
+	if ptr, ok := elem.Underlying().(*types.Pointer); ok {
+		elem = ptr.Elem()
+	}
+	st, ok := elem.Underlying().(*types.Struct)
+	return st, ok
+}
+
+// comparedFields returns the fields compared by the body of a less function,
+// the innermost ones of the selector chains such as Height in s[i].Key.Height.
+func comparedFields(body *ast.BlockStmt, info *types.Info) map[*types.Var]bool {
+	selected := make(map[*ast.SelectorExpr]*types.Var)
+	inner := make(map[ast.Expr]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if selection, ok := info.Selections[sel]; ok && selection.Kind() == types.FieldVal {
+			if field, ok := selection.Obj().(*types.Var); ok {
+				selected[sel] = field
+				inner[astutil.Unparen(sel.X)] = true
+			}
+		}
+		return true
+	})
+
+	fields := make(map[*types.Var]bool)
+	for sel, field := range selected {
+		if !inner[sel] {
+			fields[field] = true
+		}
+	}
+	return fields
+}
+
+func (r *sortTies) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if ctx.SkipTestFile() {
+		return nil, nil
+	}
+	call, ok := n.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || r.calls.ContainsPkgCallExpr(call, ctx, false) == nil {
+		return nil, nil
+	}
+	less, ok := call.Args[1].(*ast.FuncLit)
+	if !ok {
+		return nil, nil
+	}
+	typ := ctx.Info.TypeOf(call.Args[0])
+	if typ == nil {
+		return nil, nil
+	}
+	elem, ok := structElem(typ)
+	if !ok || elem.NumFields() < 2 {
+		return nil, nil
+	}
+	if len(comparedFields(less.Body, ctx.Info)) != 1 {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, n, r.ID(), r.What, r.Severity, r.Confidence), nil
+}
+
+// NewSortTiesCheck flags the sort.Slice calls whose less function compares a
+// single field of elements made of several fields.
+func NewSortTiesCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	calls := gosec.NewCallList()
+	calls.Add("sort", "Slice")
+	return &sortTies{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Low,
+			Confidence: gosec.Medium,
+			What:       "sort.Slice compares a single field of the elements, the ties are left in an unstable order; use sort.SliceStable with a tiebreaker",
+			HelpURL:    helpURL("sorting-with-a-less-function-leaving-ties"),
+		},
+		calls: calls,
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
@@ -149,6 +149,7 @@ func NewStrconvIntBitSizeOverflow(id string, config gosec.Config) (rule gosec.Ru
 			Severity:   gosec.High,
 			Confidence: gosec.Medium,
 			What:       "Overflow due to wrong bitsize in strconv.ParseUint yet cast from uint64 to int*",
+			HelpURL:    helpURL("strconv-unsigned-integers-cast-to-signed-integers-overflow"),
 		},
 		calls: calls,
 	}
@@ -0,0 +1,149 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass flags the recover calls which swallow the panic: a node which
+// recovers silently carries on with a state that the nodes which halted on
+// the panic do not have, and the chain forks.
+
+type swallowedRecover struct {
+	gosec.MetaData
+}
+
+func (r *swallowedRecover) ID() string {
+	return r.MetaData.ID
+}
+
+func isRecoverCall(expr ast.Expr, ctx *gosec.Context) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ctx.Info.Uses[ident] == types.Universe.Lookup("recover")
+}
+
+func isNilIdent(expr ast.Expr, ctx *gosec.Context) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ctx.Info.Uses[ident] == types.Universe.Lookup("nil")
+}
+
+// isHandled returns true if the recovered value is used within body for more
+// than a comparison with nil, e.g. re-panicked, logged or turned into an error.
+func isHandled(body *ast.BlockStmt, recovered types.Object, ctx *gosec.Context) bool {
+	handled := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if handled {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.BinaryExpr:
+			if node.Op == token.EQL || node.Op == token.NEQ {
+				if isNilIdent(node.X, ctx) || isNilIdent(node.Y, ctx) {
+					return false
+				}
+			}
+		case *ast.Ident:
+			if ctx.Info.Uses[node] == recovered {
+				handled = true
+			}
+		}
+		return true
+	})
+	return handled
+}
+
+func (r *swallowedRecover) match(body *ast.BlockStmt, ctx *gosec.Context) ast.Node {
+	var swallowed ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if swallowed != nil {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// The nested functions are matched on their own.
+			return false
+		case *ast.ExprStmt:
+			if isRecoverCall(node.X, ctx) {
+				swallowed = node
+			}
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 || !isRecoverCall(node.Rhs[0], ctx) {
+				return true
+			}
+			ident, ok := node.Lhs[0].(*ast.Ident)
+			if !ok {
+				// The value is stored in a field or an element, hence propagated.
+				return true
+			}
+			if ident.Name == "_" || !isHandled(body, ctx.Info.ObjectOf(ident), ctx) {
+				swallowed = node
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) != 1 || len(node.Values) != 1 || !isRecoverCall(node.Values[0], ctx) {
+				return true
+			}
+			if node.Names[0].Name == "_" || !isHandled(body, ctx.Info.ObjectOf(node.Names[0]), ctx) {
+				swallowed = node
+			}
+		}
+		return true
+	})
+	return swallowed
+}
+
+func (r *swallowedRecover) Match(n ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	// the simulations recover from the panics they provoke on purpose
+	if isSimulationPkg(ctx) {
+		return nil, nil
+	}
+
+	var body *ast.BlockStmt
+	switch node := n.(type) {
+	case *ast.FuncDecl:
+		body = node.Body
+	case *ast.FuncLit:
+		body = node.Body
+	}
+	if body == nil {
+		return nil, nil
+	}
+	if swallowed := r.match(body, ctx); swallowed != nil {
+		return gosec.NewIssue(ctx, swallowed, r.ID(), r.What, r.Severity, r.Confidence), nil
+	}
+	return nil, nil
+}
+
+// NewSwallowedRecoverCheck detects the calls to the builtin recover whose
+// result is discarded instead of being re-panicked, logged or returned.
+func NewSwallowedRecoverCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &swallowedRecover{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.Medium,
+			What:       "Recovered panic is swallowed, which may make this node diverge from the nodes that halted; propagate it as an error or re-panic",
+			HelpURL:    helpURL("swallowed-recovered-panics"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}
+}
@@ -0,0 +1,81 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// sync.Map.Range visits the entries in an unspecified order, which carries
+// the same non-determinism as ranging over a plain map.
+
+type syncMapRange struct {
+	gosec.MetaData
+}
+
+func (sr *syncMapRange) ID() string {
+	return sr.MetaData.ID
+}
+
+// isSyncMap returns true if typ is sync.Map or a pointer to it.
+func isSyncMap(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == "sync" && named.Obj().Name() == "Map"
+}
+
+func (sr *syncMapRange) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if pkgExcusedFromMapRangingChecks(ctx) {
+		return nil, nil
+	}
+
+	call, ok := node.(*ast.CallExpr)
+	if !ok {
+		return nil, nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Range" {
+		return nil, nil
+	}
+
+	// Rely on the selection rather than on the method name, so that
+	// other types exposing a Range method are not reported.
+	selection, ok := ctx.Info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal || !isSyncMap(selection.Recv()) {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, call, sr.ID(), sr.What, sr.Severity, sr.Confidence), nil
+}
+
+// NewSyncMapRangeCheck flags the iterations over a sync.Map with its Range method.
+func NewSyncMapRangeCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &syncMapRange{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.High,
+			What:       "Non-determinism from ranging over a sync.Map; collect and sort the keys into a slice instead",
+			HelpURL:    helpURL("non-deterministic-syncmap-iteration"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
@@ -0,0 +1,75 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass targets the timers and the tickers of the state logic: they fire
+// on the wall clock of the node and through the goroutine scheduling, so the
+// validators do not run what depends on them at the same point of a block,
+// and compute different states.
+
+type timers struct {
+	gosec.MetaData
+	packages []string
+}
+
+func (r *timers) ID() string {
+	return r.MetaData.ID
+}
+
+// timerFuncs lists the functions of the time package starting a timer
+var timerFuncs = map[string]bool{
+	"After":     true,
+	"NewTicker": true,
+	"NewTimer":  true,
+	"Tick":      true,
+}
+
+func (r *timers) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	sel, ok := node.(*ast.SelectorExpr)
+	if !ok || ctx.SkipTestFile() || isCommandPkg(ctx) || isServerPkg(ctx) || matchesPackage(ctx.Pkg.Path(), r.packages) {
+		return nil, nil
+	}
+	obj := ctx.Info.Uses[sel.Sel]
+	if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != "time" || obj.Parent() != obj.Pkg().Scope() ||
+		!timerFuncs[obj.Name()] {
+		return nil, nil
+	}
+	return gosec.NewIssue(ctx, sel, r.ID(), fmt.Sprintf(r.What, obj.Name()), r.Severity, r.Confidence), nil
+}
+
+// NewTimerCheck flags the uses of the timers and the tickers of the time
+// package outside of the tests, of the commands, of the servers and of the
+// allowlisted packages.
+func NewTimerCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	packages, _ := configuredPackages(id, conf)
+
+	return &timers{
+		packages: packages,
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "time.%s fires on the wall clock of the node, the validators do not run what depends on it at the same point and compute different states, use the block time or height instead",
+			HelpURL:    helpURL("timers-and-tickers-in-deterministic-packages"),
+		},
+	}, []ast.Node{(*ast.SelectorExpr)(nil)}
+}
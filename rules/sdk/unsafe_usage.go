@@ -0,0 +1,84 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass complements the import blocklist, which lets the crypto packages
+// import unsafe: each use of unsafe is reported at its site so that it can be
+// reviewed individually, whichever name the package is imported under.
+
+type unsafeUsage struct {
+	gosec.MetaData
+}
+
+func (r *unsafeUsage) ID() string {
+	return r.MetaData.ID
+}
+
+// isUnsafePointerConversion returns true for the unsafe.Pointer(...) conversions.
+func isUnsafePointerConversion(call *ast.CallExpr, ctx *gosec.Context) bool {
+	tv, ok := ctx.Info.Types[call.Fun]
+	return ok && tv.IsType() && types.Identical(tv.Type, types.Typ[types.UnsafePointer])
+}
+
+// isUnsafeBuiltin returns true for the calls of unsafe.Sizeof, unsafe.Offsetof
+// and unsafe.Alignof.
+func isUnsafeBuiltin(call *ast.CallExpr, ctx *gosec.Context) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	obj, ok := ctx.Info.Uses[sel.Sel].(*types.Builtin)
+	if !ok || types.Unsafe.Scope().Lookup(obj.Name()) != obj {
+		return false
+	}
+	switch obj.Name() {
+	case "Sizeof", "Offsetof", "Alignof":
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *unsafeUsage) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || (!isUnsafePointerConversion(call, ctx) && !isUnsafeBuiltin(call, ctx)) {
+		return nil, nil
+	}
+	what := fmt.Sprintf(r.What, types.ExprString(call))
+	return gosec.NewIssue(ctx, call, r.ID(), what, r.Severity, r.Confidence), nil
+}
+
+// NewUnsafeUsage flags every unsafe.Pointer conversion and every call of
+// unsafe.Sizeof, unsafe.Offsetof and unsafe.Alignof, even in the packages
+// which are allowed to import unsafe.
+func NewUnsafeUsage(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &unsafeUsage{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.High,
+			What:       "Use of unsafe must be reviewed: %s",
+			HelpURL:    helpURL("use-of-unsafe"),
+		},
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
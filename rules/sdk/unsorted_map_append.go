@@ -0,0 +1,208 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/types"
+	"strings"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass completes the map ranging check, which permits collecting the keys
+// of a map into a slice: such a slice is in iteration order until it is
+// sorted, so returning it unsorted hands a non-deterministic order to the
+// callers, e.g. the genesis export.
+
+type unsortedMapAppend struct {
+	gosec.MetaData
+}
+
+func (r *unsortedMapAppend) ID() string {
+	return r.MetaData.ID
+}
+
+// appendedSlices returns the slices the range statement appends to, as in
+// s = append(s, ...).
+func appendedSlices(rangeStmt *ast.RangeStmt, ctx *gosec.Context) map[types.Object]bool {
+	slices := make(map[types.Object]bool)
+	ast.Inspect(rangeStmt.Body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return true
+		}
+		ident, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok {
+			return true
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		if fn, ok := call.Fun.(*ast.Ident); !ok || ctx.Info.Uses[fn] != types.Universe.Lookup("append") {
+			return true
+		}
+		if arg, ok := call.Args[0].(*ast.Ident); ok && ctx.Info.ObjectOf(arg) == ctx.Info.ObjectOf(ident) {
+			slices[ctx.Info.ObjectOf(ident)] = true
+		}
+		return true
+	})
+	return slices
+}
+
+// namedResults returns the named results of the function declaration.
+func namedResults(funcDecl *ast.FuncDecl, ctx *gosec.Context) map[types.Object]bool {
+	results := make(map[types.Object]bool)
+	if funcDecl.Type.Results == nil {
+		return results
+	}
+	for _, field := range funcDecl.Type.Results.List {
+		for _, name := range field.Names {
+			results[ctx.Info.ObjectOf(name)] = true
+		}
+	}
+	return results
+}
+
+// returnedUnsorted returns the slice returned by the function after the range
+// statement without being sorted first, if any.
+func returnedUnsorted(funcDecl *ast.FuncDecl, rangeStmt *ast.RangeStmt, slices map[types.Object]bool, ctx *gosec.Context) types.Object {
+	results := namedResults(funcDecl, ctx)
+	sorted := false
+	var returned types.Object
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if sorted || returned != nil {
+			return false
+		}
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		if n == nil || n.Pos() < rangeStmt.End() {
+			return true
+		}
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if isSortCall(node, ctx) && refersTo(node, slices, ctx) {
+				sorted = true
+			}
+		case *ast.ReturnStmt:
+			if len(node.Results) == 0 {
+				for obj := range slices {
+					if results[obj] {
+						returned = obj
+					}
+				}
+				return false
+			}
+			for _, result := range node.Results {
+				if ident, ok := result.(*ast.Ident); ok && slices[ctx.Info.ObjectOf(ident)] {
+					returned = ctx.Info.ObjectOf(ident)
+				}
+			}
+			return false
+		}
+		return true
+	})
+	return returned
+}
+
+// sortFix returns the range statement followed by the sort of the slice, for
+// the slices of strings, ints and float64s in the files already importing sort.
+func sortFix(rangeStmt *ast.RangeStmt, slice types.Object, ctx *gosec.Context) string {
+	imported := false
+	for _, spec := range ctx.Root.Imports {
+		imported = imported || (spec.Name == nil && unquote(spec.Path.Value) == "sort")
+	}
+	sliceType, ok := slice.Type().Underlying().(*types.Slice)
+	if !imported || !ok {
+		return ""
+	}
+	elem, ok := sliceType.Elem().(*types.Basic)
+	if !ok {
+		return ""
+	}
+	sortFunc := map[types.BasicKind]string{types.String: "Strings", types.Int: "Ints", types.Float64: "Float64s"}[elem.Kind()]
+	if sortFunc == "" {
+		return ""
+	}
+
+	// The replacement spans whole lines, so it is indented as the range statement.
+	indent := strings.Repeat("\t", ctx.FileSet.Position(rangeStmt.Pos()).Column-1)
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, ctx.FileSet, rangeStmt); err != nil {
+		return ""
+	}
+	code := strings.ReplaceAll(buf.String(), "\n", "\n"+indent)
+	return fmt.Sprintf("%s%s\n%ssort.%s(%s)\n", indent, code, indent, sortFunc, slice.Name())
+}
+
+func (r *unsortedMapAppend) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	if pkgExcusedFromMapRangingChecks(ctx) {
+		return nil, nil
+	}
+
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil || funcDecl.Type.Results == nil {
+		return nil, nil
+	}
+
+	var issue *gosec.Issue
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if issue != nil {
+			return false
+		}
+		rangeStmt, ok := n.(*ast.RangeStmt)
+		if !ok || !isMapRange(rangeStmt, ctx) {
+			return true
+		}
+		slices := appendedSlices(rangeStmt, ctx)
+		if len(slices) == 0 {
+			return true
+		}
+		slice := returnedUnsorted(funcDecl, rangeStmt, slices, ctx)
+		if slice == nil {
+			return true
+		}
+
+		// The exported functions are the ones likely to feed the genesis or the exports.
+		severity := r.Severity
+		if funcDecl.Name.IsExported() {
+			severity = gosec.High
+		}
+		what := fmt.Sprintf(r.What, ctx.Info.TypeOf(rangeStmt.X))
+		issue = gosec.NewIssue(ctx, rangeStmt, r.ID(), what, severity, r.Confidence).
+			WithAutofix(fmt.Sprintf("Sort %s after the range statement", slice.Name()), sortFix(rangeStmt, slice, ctx))
+		return false
+	})
+	return issue, nil
+}
+
+// NewUnsortedMapAppendCheck flags the slices appended to while ranging over a
+// map which are returned without being sorted.
+func NewUnsortedMapAppendCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	return &unsortedMapAppend{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.Medium,
+			Confidence: gosec.High,
+			What:       "Slice built from an iteration over %s is returned unsorted; sort it before returning to get a deterministic order",
+			HelpURL:    helpURL("unsorted-slice-built-from-a-map-iteration-returned"),
+		},
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
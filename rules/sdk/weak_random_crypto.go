@@ -0,0 +1,216 @@
+// (c) Copyright 2021 Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+	"unicode"
+
+	"github.com/cosmos/gosec/v2"
+)
+
+// This pass is a security check rather than a determinism one: keys, salts,
+// nonces and IVs generated from math/rand are predictable. Unlike the import
+// blocklist it also runs in the crypto packages, which are the most likely to
+// generate such secrets.
+
+type weakRandomCrypto struct {
+	gosec.MetaData
+	names map[string]bool
+	// tainted holds the variables assigned from or filled by math/rand.
+	tainted map[types.Object]bool
+}
+
+func (wr *weakRandomCrypto) ID() string {
+	return wr.MetaData.ID
+}
+
+// nameWords splits an identifier such as privKeyBytes or iv_seed into its
+// lower cased words.
+func nameWords(name string) []string {
+	var words []string
+	var word []rune
+	prev := '_'
+	for _, r := range name {
+		if r == '_' || (unicode.IsUpper(r) && !unicode.IsUpper(prev)) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = word[:0]
+		}
+		if r != '_' {
+			word = append(word, unicode.ToLower(r))
+		}
+		prev = r
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+func (wr *weakRandomCrypto) isSecretName(name string) bool {
+	for _, word := range nameWords(name) {
+		if wr.names[word] {
+			return true
+		}
+	}
+	return false
+}
+
+func isMathRandCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	_, obj := gosec.GetCallObject(call, ctx)
+	fn, ok := obj.(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "math/rand"
+}
+
+func isCryptoPkg(path string) bool {
+	return path == "crypto" || strings.HasPrefix(path, "crypto/") || strings.HasPrefix(path, "golang.org/x/crypto/")
+}
+
+// randomFlow returns whether expr holds a value straight out of math/rand, and
+// otherwise whether it refers to a variable previously tainted by math/rand.
+func (wr *weakRandomCrypto) randomFlow(expr ast.Expr, ctx *gosec.Context) (direct bool, indirect bool) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if isMathRandCall(node, ctx) {
+				direct = true
+			}
+		case *ast.Ident:
+			if wr.tainted[ctx.Info.ObjectOf(node)] {
+				indirect = true
+			}
+		}
+		return !direct
+	})
+	return direct, indirect
+}
+
+func (wr *weakRandomCrypto) taint(expr ast.Expr, ctx *gosec.Context) {
+	if ident, ok := expr.(*ast.Ident); ok && ident.Name != "_" {
+		if obj := ctx.Info.ObjectOf(ident); obj != nil {
+			wr.tainted[obj] = true
+		}
+	}
+}
+
+func (wr *weakRandomCrypto) issue(node ast.Node, direct bool, ctx *gosec.Context) *gosec.Issue {
+	confidence := gosec.Medium
+	if direct {
+		confidence = gosec.High
+	}
+	return gosec.NewIssue(ctx, node, wr.ID(), wr.What, wr.Severity, confidence)
+}
+
+func (wr *weakRandomCrypto) matchAssign(node ast.Node, lhs []ast.Expr, rhs []ast.Expr, ctx *gosec.Context) *gosec.Issue {
+	var found *gosec.Issue
+	for i, expr := range lhs {
+		value := expr
+		if len(rhs) == len(lhs) {
+			value = rhs[i]
+		} else if len(rhs) == 1 {
+			value = rhs[0]
+		} else {
+			continue
+		}
+		direct, indirect := wr.randomFlow(value, ctx)
+		if !direct && !indirect {
+			continue
+		}
+		wr.taint(expr, ctx)
+		if ident, ok := expr.(*ast.Ident); ok && found == nil && wr.isSecretName(ident.Name) {
+			found = wr.issue(node, direct, ctx)
+		}
+	}
+	return found
+}
+
+func (wr *weakRandomCrypto) matchCall(call *ast.CallExpr, ctx *gosec.Context) *gosec.Issue {
+	// math/rand.Read fills its argument with predictable bytes.
+	if isMathRandCall(call, ctx) {
+		_, obj := gosec.GetCallObject(call, ctx)
+		if obj.Name() != "Read" || len(call.Args) == 0 {
+			return nil
+		}
+		wr.taint(call.Args[0], ctx)
+		if ident, ok := call.Args[0].(*ast.Ident); ok && wr.isSecretName(ident.Name) {
+			return wr.issue(call, true, ctx)
+		}
+		return nil
+	}
+
+	_, obj := gosec.GetCallObject(call, ctx)
+	if obj == nil || obj.Pkg() == nil || !isCryptoPkg(obj.Pkg().Path()) {
+		return nil
+	}
+	for _, arg := range call.Args {
+		if direct, indirect := wr.randomFlow(arg, ctx); direct || indirect {
+			return wr.issue(call, direct, ctx)
+		}
+	}
+	return nil
+}
+
+func (wr *weakRandomCrypto) Match(node ast.Node, ctx *gosec.Context) (*gosec.Issue, error) {
+	switch n := node.(type) {
+	case *ast.AssignStmt:
+		return wr.matchAssign(n, n.Lhs, n.Rhs, ctx), nil
+	case *ast.ValueSpec:
+		lhs := make([]ast.Expr, 0, len(n.Names))
+		for _, name := range n.Names {
+			lhs = append(lhs, name)
+		}
+		return wr.matchAssign(n, lhs, n.Values, ctx), nil
+	case *ast.CallExpr:
+		return wr.matchCall(n, ctx), nil
+	}
+	return nil, nil
+}
+
+// NewWeakRandomCrypto flags math/rand values flowing into variables named like
+// secrets (key, salt, nonce, iv) or into the arguments of crypto functions.
+// The variable names can be configured with a list of words:
+//
+//	{"G708": {"names": ["key", "salt", "nonce", "iv", "seed"]}}
+func NewWeakRandomCrypto(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	names := map[string]bool{"key": true, "salt": true, "nonce": true, "iv": true}
+	if val, ok := conf[id]; ok {
+		if ruleConf, ok := val.(map[string]interface{}); ok {
+			if configNames, ok := ruleConf["names"].([]interface{}); ok {
+				names = make(map[string]bool)
+				for _, name := range configNames {
+					if name, ok := name.(string); ok {
+						names[strings.ToLower(name)] = true
+					}
+				}
+			}
+		}
+	}
+
+	return &weakRandomCrypto{
+		MetaData: gosec.MetaData{
+			ID:         id,
+			Severity:   gosec.High,
+			Confidence: gosec.High,
+			What:       "Cryptographic material generated from math/rand is predictable, use crypto/rand instead",
+			HelpURL:    helpURL("weak-random-source-for-cryptographic-material"),
+		},
+		names:   names,
+		tainted: make(map[types.Object]bool),
+	}, []ast.Node{(*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil), (*ast.CallExpr)(nil)}
+}
@@ -0,0 +1,8 @@
+package helper
+
+import "github.com/cosmos/gosec/v2/testdata/blocklist/thirdparty"
+
+// Size returns the size of a uint64.
+func Size() uintptr {
+	return thirdparty.Size()
+}
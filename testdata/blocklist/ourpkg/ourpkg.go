@@ -0,0 +1,12 @@
+package ourpkg
+
+import (
+	"fmt"
+
+	"github.com/cosmos/gosec/v2/testdata/blocklist/helper"
+)
+
+// Describe describes the size of a uint64.
+func Describe() string {
+	return fmt.Sprint(helper.Size())
+}
@@ -0,0 +1,9 @@
+package thirdparty
+
+import "unsafe"
+
+// Size returns the size of a uint64.
+func Size() uintptr {
+	var v uint64
+	return unsafe.Sizeof(v)
+}
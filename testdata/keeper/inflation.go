@@ -0,0 +1,7 @@
+package keeper
+
+import "math/big"
+
+func (k Keeper) inflation() *big.Float {
+	return new(big.Float).Mul(new(big.Float).SetInt(k.supply), big.NewFloat(0.07))
+}
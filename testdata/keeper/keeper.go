@@ -0,0 +1,14 @@
+package keeper
+
+import "math/big"
+
+// Keeper mints the inflation rewards.
+type Keeper struct {
+	supply *big.Int
+}
+
+// Minted returns the supply minted by the inflation rate.
+func (k Keeper) Minted() *big.Int {
+	minted, _ := k.inflation().Int(nil)
+	return minted
+}
@@ -0,0 +1,12 @@
+package keeper
+
+import "github.com/cosmos/gosec/v2/testdata/rand/wrapper"
+
+// Shuffle shuffles the validators with a generator seeded with the height.
+func Shuffle(validators []string, height uint64) {
+	r := wrapper.NewRand(height)
+	for i := len(validators) - 1; i > 0; i-- {
+		j := int(r.Uint64() % uint64(i+1))
+		validators[i], validators[j] = validators[j], validators[i]
+	}
+}
@@ -0,0 +1,17 @@
+package prng
+
+// Rand is a pseudo-random number generator.
+type Rand struct {
+	state uint64
+}
+
+// New returns a generator seeded with seed.
+func New(seed uint64) *Rand {
+	return &Rand{state: seed}
+}
+
+// Uint64 returns the next pseudo-random number.
+func (r *Rand) Uint64() uint64 {
+	r.state = r.state*6364136223846793005 + 1442695040888963407
+	return r.state
+}
@@ -0,0 +1,11 @@
+package wrapper
+
+import "github.com/cosmos/gosec/v2/testdata/rand/prng"
+
+// Rand re-exports the generator of prng.
+type Rand = prng.Rand
+
+// NewRand returns a generator seeded with seed.
+func NewRand(seed uint64) *Rand {
+	return prng.New(seed)
+}
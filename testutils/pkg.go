@@ -110,16 +110,18 @@ func (p *TestPackage) CreateContext(filename string) *gosec.Context {
 
 	for _, pkg := range p.build.pkgs {
 		for _, file := range pkg.Syntax {
-			pkgFile := pkg.Fset.File(file.Pos()).Name()
+			fullPath := pkg.Fset.File(file.Pos()).Name()
 			strip := fmt.Sprintf("%s%c", p.Path, os.PathSeparator)
-			pkgFile = strings.TrimPrefix(pkgFile, strip)
+			pkgFile := strings.TrimPrefix(fullPath, strip)
 			if pkgFile == filename {
 				ctx := &gosec.Context{
 					FileSet:      pkg.Fset,
+					Filename:     fullPath,
 					Root:         file,
 					Config:       gosec.NewConfig(),
 					Info:         pkg.TypesInfo,
 					Pkg:          pkg.Types,
+					PkgImports:   pkg.Imports,
 					Imports:      gosec.NewImportTracker(),
 					PassedValues: make(map[string]interface{}),
 				}
@@ -2528,4 +2528,2941 @@ func noop(keys []string) []string {return keys}
 `}, 13, gosec.NewConfig(),
 		},
 	}
+
+	// SampleCodeGenesisMapHash - Detect map iterations hashed or encoded in genesis
+	SampleCodeGenesisMapHash = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+)
+
+type GenesisState struct {
+	Balances map[string]uint64
+}
+
+func InitGenesis(gs GenesisState) []byte {
+	h := sha256.New()
+	for addr := range gs.Balances {
+		h.Write([]byte(addr))
+	}
+	return h.Sum(nil)
+}
+
+func ExportGenesis(gs GenesisState) ([]byte, error) {
+	addrs := make([]string, 0, len(gs.Balances))
+	for addr := range gs.Balances {
+		addrs = append(addrs, addr)
+	}
+	return json.Marshal(addrs)
+}
+`}, 2, gosec.NewConfig()},
+		{[]string{`
+package keeper
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+type GenesisState struct {
+	Balances map[string]uint64
+}
+
+func ExportGenesis(gs GenesisState) ([]byte, error) {
+	addrs := make([]string, 0, len(gs.Balances))
+	for addr := range gs.Balances {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return json.Marshal(addrs)
+}
+
+func Export(gs GenesisState) ([]byte, error) {
+	addrs := make([]string, 0, len(gs.Balances))
+	for addr := range gs.Balances {
+		addrs = append(addrs, addr)
+	}
+	return json.Marshal(addrs)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeSyncMapRange - Detect iterations over a sync.Map
+	SampleCodeSyncMapRange = []CodeSample{
+		{[]string{`
+package keeper
+
+import "sync"
+
+type Keeper struct {
+	cache *sync.Map
+}
+
+func (k Keeper) Keys() []string {
+	var m sync.Map
+	m.Store("a", 1)
+	keys := []string{}
+	m.Range(func(key, value interface{}) bool {
+		keys = append(keys, key.(string))
+		return true
+	})
+	k.cache.Range(func(key, value interface{}) bool {
+		return true
+	})
+	return keys
+}
+`}, 2, gosec.NewConfig()},
+		{[]string{`
+package keeper
+
+type Ranger struct{}
+
+func (Ranger) Range(f func(key, value interface{}) bool) {}
+
+func Keys() {
+	var r Ranger
+	r.Range(func(key, value interface{}) bool {
+		return true
+	})
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeWeakRandomCrypto - Detect keys, salts and nonces generated from math/rand
+	SampleCodeWeakRandomCrypto = []CodeSample{
+		{[]string{`
+package crypto
+
+import (
+	"crypto/aes"
+	"math/rand"
+)
+
+func generate() (int64, error) {
+	key := make([]byte, 32)
+	rand.Read(key)
+	salt := rand.Int63()
+	_, err := aes.NewCipher([]byte{byte(rand.Intn(256))})
+	return salt, err
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"math/rand"
+)
+
+func main() {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	mac := hmac.New(sha256.New, buf)
+	fmt.Println(mac.Size())
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	mrand "math/rand"
+)
+
+func main() {
+	privKey := make([]byte, 32)
+	if _, err := rand.Read(privKey); err != nil {
+		panic(err)
+	}
+	count := mrand.Intn(10)
+	fmt.Println(privKey, count)
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+func main() {
+	seed := rand.Int63()
+	key := rand.Int63()
+	fmt.Println(seed, key)
+}
+`}, 1, gosec.Config{"G708": map[string]interface{}{"names": []interface{}{"seed"}}}},
+	}
+
+	// SampleCodeUnhandledCryptoErrors - Detect discarded errors of crypto and encoding calls
+	SampleCodeUnhandledCryptoErrors = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+func verify(pub *rsa.PublicKey, msg, sig string) []byte {
+	hashed := sha256.Sum256([]byte(msg))
+	raw, _ := hex.DecodeString(sig)
+	rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], raw)
+	_ = rsa.VerifyPSS(pub, crypto.SHA256, hashed[:], raw, nil)
+	return raw
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+func verify(pub *rsa.PublicKey, msg, sig string) error {
+	hashed := sha256.Sum256([]byte(msg))
+	raw, err := hex.DecodeString(sig)
+	if err != nil {
+		return err
+	}
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], raw); err != nil {
+		return err
+	}
+	return nil
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+func verify(pub *rsa.PublicKey, msg, sig string) {
+	hashed := sha256.Sum256([]byte(msg))
+	raw, _ := hex.DecodeString(sig)
+	rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], raw)
+}
+`}, 1, gosec.Config{"G709": map[string]interface{}{"packages": []interface{}{"encoding/hex"}}}},
+	}
+
+	// SampleCodeBlocklistedCalls - Detect calls of blocklisted functions
+	SampleCodeBlocklistedCalls = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+func main() {
+	fmt.Println(math.Mod(7, 3))
+	fmt.Println(new(big.Int).Exp(big.NewInt(2), big.NewInt(10), nil))
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+func main() {
+	fmt.Println(math.Max(7, 3))
+	fmt.Println(new(big.Int).Add(big.NewInt(2), big.NewInt(10)))
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeIotaEnum - Detect constant blocks using iota
+	SampleCodeIotaEnum = []CodeSample{
+		{[]string{`
+package types
+
+type Status int32
+
+const (
+	StatusUnspecified Status = iota
+	StatusBonded
+	StatusUnbonded
+)
+
+type Validator struct {
+	Status Status ` + "`json:\"status\"`" + `
+}
+
+const (
+	A = iota
+	B
+)
+`}, 2, gosec.NewConfig()}, {[]string{`
+package types
+
+type Status int32
+
+const (
+	StatusUnspecified Status = 0
+	StatusBonded      Status = 1
+	StatusUnbonded    Status = 2
+)
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeMapRangeHash - Detect map iterations written into a hash
+	SampleCodeMapRangeHash = []CodeSample{
+		{[]string{`
+package keeper
+
+import "crypto/sha256"
+
+func hashEntries(m map[string][]byte) []byte {
+	h := sha256.New()
+	for k, v := range m {
+		h.Write([]byte(k))
+		h.Write(v)
+	}
+	return h.Sum(nil)
+}
+
+func hashKeys(m map[string]int) [32]byte {
+	var buf []byte
+	for k := range m {
+		buf = append(buf, k...)
+	}
+	return sha256.Sum256(buf)
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"crypto/sha256"
+	"sort"
+	"strings"
+)
+
+func hashKeys(m map[string]int) [32]byte {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return sha256.Sum256([]byte(strings.Join(keys, ",")))
+}
+
+func hashEntries(m map[string][]byte) []byte {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write(m[k])
+	}
+	return h.Sum(nil)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeDeferredClose - Detect deferred Close calls discarding their error
+	SampleCodeDeferredClose = []CodeSample{
+		{[]string{`
+package keeper
+
+import "os"
+
+func export(path string, data []byte) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(data)
+	return err
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func export(path string, data []byte) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+	rc := ioutil.NopCloser(strings.NewReader("unrelated"))
+	defer rc.Close()
+	_, err = f.Write(data)
+	return err
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "os"
+
+func export(path string, data []byte) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(data)
+	return err
+}
+`}, 0, gosec.Config{"G712": map[string]interface{}{"packages": []interface{}{"compress/*"}}}},
+	}
+
+	// SampleCodeJSONMapKeys - Detect JSON marshaling of maps with non-string keys
+	SampleCodeJSONMapKeys = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type State struct {
+	Balances map[uint64]int64
+}
+
+func export(w io.Writer, state State) ([]byte, error) {
+	if err := json.NewEncoder(w).Encode(&state); err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[int]string{1: "one"})
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "encoding/json"
+
+type State struct {
+	Names map[string]string
+	cache map[int]string
+}
+
+func export(state State) ([]byte, error) {
+	if _, err := json.Marshal(state); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(map[string]string{"one": "1"}, "", "  ")
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeSwallowedRecover - Detect recovered panics which are swallowed
+	SampleCodeSwallowedRecover = []CodeSample{
+		{[]string{`
+package keeper
+
+func handleMsg(msg string) {
+	defer func() {
+		recover()
+	}()
+	process(msg)
+}
+
+func handleOther(msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			return
+		}
+	}()
+	process(msg)
+}
+
+func process(msg string) {
+	if msg == "" {
+		panic("empty message")
+	}
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "fmt"
+
+func handleMsg(msg string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while handling %s: %v", msg, r)
+		}
+	}()
+	process(msg)
+	return nil
+}
+
+func handleOther(msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			panic(r)
+		}
+	}()
+	process(msg)
+}
+
+func process(msg string) {
+	if msg == "" {
+		panic("empty message")
+	}
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeTestingImport - Detect imports of the testing packages in non-test files
+	SampleCodeTestingImport = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdd(t *testing.T) {
+	require.Equal(t, 1, 1)
+	_ = httptest.NewRecorder()
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package testutil
+
+import "testing"
+
+func Setup(t *testing.T) {
+	t.Helper()
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeUnsortedMapAppend - Detect unsorted slices built from map iterations being returned
+	SampleCodeUnsortedMapAppend = []CodeSample{
+		{[]string{`
+package keeper
+
+func ExportKeys(balances map[string]int64) []string {
+	keys := make([]string, 0, len(balances))
+	for key := range balances {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func values(balances map[string]int64) (vals []int64) {
+	for key := range balances {
+		vals = append(vals, balances[key])
+	}
+	return
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "sort"
+
+func ExportKeys(balances map[string]int64) []string {
+	keys := make([]string, 0, len(balances))
+	for key := range balances {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func count(balances map[string]int64) int {
+	keys := make([]string, 0, len(balances))
+	for key := range balances {
+		keys = append(keys, key)
+	}
+	return len(keys)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeUnsafeImport - Detect blocklisted imports unless disabled by the configuration
+	SampleCodeUnsafeImport = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+func size(v interface{}) uintptr {
+	return reflect.TypeOf(v).Size() + unsafe.Sizeof(v)
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+func size(v interface{}) uintptr {
+	return reflect.TypeOf(v).Size() + unsafe.Sizeof(v)
+}
+`}, 1, gosec.Config{"G702": map[string]interface{}{"disabled": []interface{}{"reflect"}}}}, {[]string{`
+package keeper
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+func size(v interface{}) uintptr {
+	return reflect.TypeOf(v).Size() + unsafe.Sizeof(v)
+}
+`}, 0, gosec.Config{gosec.DisabledRules: []interface{}{"G702"}}},
+	}
+
+	// SampleCodeUnsafeUsage - Detect each use of unsafe
+	SampleCodeUnsafeUsage = []CodeSample{
+		{[]string{`
+package crypto
+
+import u "unsafe"
+
+func bytesOf(v *uint64) *[8]byte {
+	return (*[8]byte)(u.Pointer(v))
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package crypto
+
+import "unsafe"
+
+type header struct {
+	version uint32
+	height  int64
+}
+
+func layout() uintptr {
+	var h header
+	return unsafe.Sizeof(h) + unsafe.Offsetof(h.height) + unsafe.Alignof(h.version)
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package crypto
+
+type Pointer uintptr
+
+type unsafe struct{}
+
+func (unsafe) Sizeof(v interface{}) uintptr { return 0 }
+
+func size(v interface{}) uintptr {
+	var u unsafe
+	return u.Sizeof(v) + uintptr(Pointer(0))
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeMutableGlobal - Detect mutable package level variables
+	SampleCodeMutableGlobal = []CodeSample{
+		{[]string{`
+package keeper
+
+var Balances = map[string]int64{}
+
+var height int64
+
+func SetBalance(addr string, amount int64) {
+	Balances[addr] = amount
+	height++
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+var (
+	balances = map[string]int64{}
+	pending  []string
+)
+
+var head, tail *int64
+
+func Queue(addr string, amount int64) {
+	balances[addr] = amount
+	pending = append(pending, addr)
+	head, tail = &amount, &amount
+}
+`}, 4, gosec.NewConfig()}, {[]string{`
+package keeper
+
+var (
+	balances = map[string]int64{} // #nosec G718 -- reset by each block
+	pending  []string
+)
+
+func Queue(addr string, amount int64) {
+	balances[addr] = amount
+	pending = append(pending, addr)
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"errors"
+	"sync"
+)
+
+type Status int
+
+const (
+	StatusActive Status = 1
+	StatusPaused Status = 2
+)
+
+var ErrNotFound = errors.New("not found")
+
+var mu sync.Mutex
+
+// The registry is filled at init and only read afterwards.
+var registry = map[string]Status{} // gosec:global
+
+//gosec:global
+var (
+	names []string
+)
+
+func Register(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	var local []string
+	local = append(local, name)
+	names = append(names, local...)
+	registry[name] = StatusActive
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeNarrowingConversion - Detect narrowing conversions of 64-bit integers
+	SampleCodeNarrowingConversion = []CodeSample{
+		{[]string{`
+package keeper
+
+type Amount uint64
+
+func shares(amount int64, total Amount) (int, int32) {
+	return int(amount), int32(total)
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"errors"
+	"math"
+)
+
+func signed(amount uint64) int64 {
+	return int64(amount)
+}
+
+func widen(count int32) int64 {
+	return int64(count)
+}
+
+func bounded(amount int64) (int, error) {
+	if amount > math.MaxInt32 || amount < math.MinInt32 {
+		return 0, errors.New("amount out of range")
+	}
+	return int(amount), nil
+}
+
+func guarded(amount uint64) uint32 {
+	if amount <= math.MaxUint32 {
+		return uint32(amount)
+	}
+	return 0
+}
+
+func constant() int {
+	return int(int64(42))
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeDeepEqual - Detect calls of reflect.DeepEqual
+	SampleCodeDeepEqual = []CodeSample{
+		{[]string{`
+package crypto
+
+import r "reflect"
+
+func SameKey(a, b interface{}) bool {
+	return r.DeepEqual(a, b)
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package crypto
+
+import "bytes"
+
+type PubKey struct {
+	Key []byte
+}
+
+func DeepEqual(a, b PubKey) bool {
+	return bytes.Equal(a.Key, b.Key)
+}
+
+func SameKey(a, b PubKey) bool {
+	return DeepEqual(a, b)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeOsExit - Detect calls of os.Exit outside of the commands
+	SampleCodeOsExit = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"fmt"
+	goos "os"
+)
+
+func (k Keeper) SetParams(params map[string]string) {
+	if len(params) == 0 {
+		fmt.Println("no params")
+		goos.Exit(1)
+	}
+}
+
+type Keeper struct{}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	return nil
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "os"
+
+type exiter struct{}
+
+func (exiter) Exit(code int) {}
+
+func TestMain(m interface{ Run() int }) {
+	os.Exit(m.Run())
+}
+
+func stop() {
+	var os exiter
+	os.Exit(1)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeMapFormat - Detect formatted values holding maps used as keys, hash inputs or persisted strings
+	SampleCodeMapFormat = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+)
+
+type Params struct {
+	Limits map[string]uint64
+}
+
+func cacheKey(cache map[string]bool, pending *sync.Map) {
+	key := fmt.Sprintf("%v", pending)
+	cache[key] = true
+}
+
+func paramsHash(params Params) []byte {
+	h := sha256.New()
+	h.Write([]byte(fmt.Sprintf("%+v", params)))
+	return h.Sum(nil)
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+type Coin struct {
+	Denom  string
+	Amount int64
+}
+
+type Params struct {
+	Limits map[string]uint64
+}
+
+func coinKey(cache map[string]bool, coin Coin) []byte {
+	cache[fmt.Sprintf("%v", coin)] = true
+	h := sha256.New()
+	h.Write([]byte(fmt.Sprintf("%+v", coin)))
+	return h.Sum(nil)
+}
+
+func describe(params Params) string {
+	return fmt.Sprintf("%+v", params)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeBigFloat - Detect the construction of and the arithmetic on math/big.Float values
+	SampleCodeBigFloat = []CodeSample{
+		{[]string{`
+package keeper
+
+import "math/big"
+
+func MintedSupply(supply *big.Int, inflation float64) *big.Int {
+	minted := new(big.Float).SetInt(supply)
+	minted.Mul(minted, big.NewFloat(inflation))
+	result, _ := minted.Int(nil)
+	return result
+}
+`}, 4, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "math/big"
+
+func Reward(power, total *big.Int) *big.Rat {
+	share := new(big.Int).Mul(power, big.NewInt(100))
+	return new(big.Rat).SetFrac(share, total)
+}
+
+func Ratio(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "math/big"
+
+func Approximate(x float64) string {
+	return big.NewFloat(x).Text('f', 2)
+}
+`}, 0, gosec.Config{"G723": map[string]interface{}{"packages": []interface{}{"command-line-arguments"}}}},
+	}
+
+	// SampleCodeLazyInit - Detect package level variables lazily initialized behind a nil check
+	SampleCodeLazyInit = []CodeSample{
+		{[]string{`
+package keeper
+
+import "sync"
+
+type Params struct{ MaxValidators uint32 }
+
+var (
+	denomCache map[string]string
+	params     *Params
+	mu         sync.Mutex
+)
+
+func Denom(base string) string {
+	if denomCache == nil {
+		denomCache = make(map[string]string)
+	}
+	return denomCache[base]
+}
+
+func DefaultParams() *Params {
+	if params == nil {
+		mu.Lock()
+		defer mu.Unlock()
+		if params == nil {
+			params = &Params{MaxValidators: 100}
+		}
+	}
+	return params
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "sync"
+
+type Params struct{ MaxValidators uint32 }
+
+var (
+	denomCache map[string]string
+	denomOnce  sync.Once
+	params     *Params
+	mu         sync.Mutex
+	registry   map[string]bool
+)
+
+func init() {
+	if registry == nil {
+		registry = map[string]bool{}
+	}
+}
+
+func Denom(base string) string {
+	denomOnce.Do(func() {
+		if denomCache == nil {
+			denomCache = make(map[string]string)
+		}
+	})
+	return denomCache[base]
+}
+
+func DefaultParams() *Params {
+	mu.Lock()
+	defer mu.Unlock()
+	if params == nil {
+		params = &Params{MaxValidators: 100}
+	}
+	return params
+}
+
+func Lookup(cache map[string]string, key string) string {
+	if cache == nil {
+		cache = map[string]string{}
+	}
+	if denomCache == nil {
+		return ""
+	}
+	return cache[key] + denomCache[key]
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeContextBackground - Detect contexts created with context.Background or context.TODO
+	SampleCodeContextBackground = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	gocontext "context"
+	"time"
+)
+
+type Keeper struct {
+	query func(ctx gocontext.Context, denom string) (int64, error)
+}
+
+func (k Keeper) Supply(denom string) (int64, error) {
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), time.Second)
+	defer cancel()
+	return k.query(ctx, denom)
+}
+
+func (k Keeper) Balance(denom string) (int64, error) {
+	return k.query(gocontext.TODO(), denom)
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "context"
+
+type Keeper struct {
+	query func(ctx context.Context, denom string) (int64, error)
+}
+
+type scheduler struct{}
+
+func (scheduler) Background() context.Context { return nil }
+
+func Background() context.Context { return nil }
+
+func (k Keeper) Supply(ctx context.Context, denom string) (int64, error) {
+	var s scheduler
+	_ = s.Background()
+	_ = Background()
+	return k.query(ctx, denom)
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fmt.Println(ctx.Err())
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeClearMap - Detect the calls of the clear builtin on maps
+	SampleCodeClearMap = []CodeSample{
+		{[]string{`
+package keeper
+
+type Keeper struct {
+	pending map[string]uint64
+	order   []string
+}
+
+func (k *Keeper) EndBlock() []string {
+	processed := k.order
+	clear(k.pending)
+	return processed
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package keeper
+
+type Keeper struct {
+	pending map[string]uint64
+	order   []string
+}
+
+func (k *Keeper) EndBlock() {
+	clear(k.order)
+	k.order = k.order[:0]
+	k.pending = make(map[string]uint64)
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package keeper
+
+func clear(m map[string]uint64) {
+	for key := range m {
+		m[key] = 0
+	}
+}
+
+func reset(m map[string]uint64) {
+	clear(m)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeSharedAppend - Detect the results built by appending to package level slices
+	SampleCodeSharedAppend = []CodeSample{
+		{[]string{`
+package types
+
+var (
+	BalancesPrefix = make([]byte, 1, 64)
+	SupplyPrefix   = []byte{0x02}
+)
+
+type Store struct {
+	lastKey []byte
+}
+
+func BalanceKey(addr []byte) []byte {
+	return append(BalancesPrefix, addr...)
+}
+
+func SupplyKey(denom string) []byte {
+	key := append(SupplyPrefix, denom...)
+	return key
+}
+
+func (s *Store) Track(addr []byte) {
+	s.lastKey = append(BalancesPrefix, addr...)
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package types
+
+var (
+	BalancesPrefix = make([]byte, 1, 64)
+	SupplyPrefix   = []byte{0x02}
+	registered     [][]byte
+)
+
+func BalanceKey(addr []byte) []byte {
+	key := make([]byte, 0, len(BalancesPrefix)+len(addr))
+	key = append(key, BalancesPrefix...)
+	return append(key, addr...)
+}
+
+func SupplyKey(denom string) []byte {
+	return append(append([]byte{}, SupplyPrefix...), denom...)
+}
+
+func DenomKey(denom string) string {
+	return string(append(SupplyPrefix[:len(SupplyPrefix):len(SupplyPrefix)], denom...))
+}
+
+func Register(prefix []byte) {
+	registered = append(registered, prefix)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeJSONFloat - Detect the JSON decoding of numbers into floats and empty interfaces
+	SampleCodeJSONFloat = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type Params struct {
+	Denom      string  ` + "`json:\"denom\"`" + `
+	InflationR float64 ` + "`json:\"inflation_rate\"`" + `
+}
+
+func ParseParams(bz []byte) (Params, error) {
+	var params Params
+	err := json.Unmarshal(bz, &params)
+	return params, err
+}
+
+func ReadGenesis(r io.Reader) (map[string]interface{}, error) {
+	var genesis map[string]interface{}
+	err := json.NewDecoder(r).Decode(&genesis)
+	return genesis, err
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+)
+
+type Dec struct {
+	value *big.Int
+	Float float64
+}
+
+func (d *Dec) UnmarshalJSON(bz []byte) error {
+	d.value = new(big.Int)
+	return d.value.UnmarshalJSON(bz)
+}
+
+type Params struct {
+	Denom      string      ` + "`json:\"denom\"`" + `
+	InflationR json.Number ` + "`json:\"inflation_rate\"`" + `
+	MaxSupply  uint64      ` + "`json:\"max_supply\"`" + `
+	Rate       Dec         ` + "`json:\"rate\"`" + `
+	Cached     float64     ` + "`json:\"-\"`" + `
+}
+
+func ParseParams(bz []byte) (Params, error) {
+	var params Params
+	err := json.Unmarshal(bz, &params)
+	return params, err
+}
+
+func decode(r io.Reader, v interface{}) error {
+	return json.NewDecoder(r).Decode(v)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodePointerMapKeys - Detect the maps keyed by pointers or structs holding pointers
+	SampleCodePointerMapKeys = []CodeSample{
+		{[]string{`
+package keeper
+
+type Validator struct {
+	Address string
+	Power   int64
+}
+
+type votingKey struct {
+	validator *Validator
+	round     int64
+}
+
+func Tally(validators []*Validator) map[*Validator]int64 {
+	powers := make(map[*Validator]int64, len(validators))
+	for _, v := range validators {
+		powers[v] = v.Power
+	}
+	return powers
+}
+
+func Votes(v *Validator) map[votingKey]bool {
+	return map[votingKey]bool{{validator: v, round: 1}: true}
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+type Validator struct {
+	Address string
+	Power   int64
+}
+
+type votingKey struct {
+	address string
+	round   int64
+}
+
+func Tally(validators []*Validator) map[string]int64 {
+	powers := make(map[string]int64, len(validators))
+	for _, v := range validators {
+		powers[v.Address] = v.Power
+	}
+	return powers
+}
+
+func Votes(v *Validator) map[votingKey]*Validator {
+	return map[votingKey]*Validator{{address: v.Address, round: 1}: v}
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeSortTies - Detect the sort.Slice calls whose less function compares a single field
+	SampleCodeSortTies = []CodeSample{
+		{[]string{`
+package keeper
+
+import "sort"
+
+type Validator struct {
+	Address string
+	Power   int64
+}
+
+func SortByPower(validators []Validator) {
+	sort.Slice(validators, func(i, j int) bool {
+		return validators[i].Power > validators[j].Power
+	})
+}
+
+func SortPointersByPower(validators []*Validator) {
+	sort.Slice(validators, func(i, j int) bool { return validators[i].Power > validators[j].Power })
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "sort"
+
+type Key struct {
+	Height int64
+	Round  int64
+}
+
+type Validator struct {
+	Address string
+	Power   int64
+	Key     Key
+}
+
+func SortByPower(validators []Validator) {
+	sort.Slice(validators, func(i, j int) bool {
+		if validators[i].Power != validators[j].Power {
+			return validators[i].Power > validators[j].Power
+		}
+		return validators[i].Address < validators[j].Address
+	})
+}
+
+func SortByKey(validators []Validator) {
+	sort.Slice(validators, func(i, j int) bool {
+		a, b := validators[i].Key, validators[j].Key
+		return a.Height < b.Height || (a.Height == b.Height && a.Round < b.Round)
+	})
+}
+
+func SortPowers(powers []int64) {
+	sort.Slice(powers, func(i, j int) bool { return powers[i] < powers[j] })
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeFilesystemRead - Detect the reads from the filesystem outside of the commands
+	SampleCodeFilesystemRead = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+)
+
+type Params struct {
+	MaxValidators uint32
+}
+
+type Keeper struct {
+	paramsFile string
+}
+
+func (k Keeper) GetParams() (Params, error) {
+	var params Params
+	bz, err := os.ReadFile(k.paramsFile)
+	if err != nil {
+		return params, err
+	}
+	err = json.Unmarshal(bz, &params)
+	return params, err
+}
+
+func (k Keeper) Allowlist() ([]byte, error) {
+	return ioutil.ReadFile("allowlist.json")
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package cli
+
+import (
+	"encoding/json"
+	"os"
+)
+
+type Proposal struct {
+	Title string
+}
+
+func ParseProposal(path string) (Proposal, error) {
+	var proposal Proposal
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return proposal, err
+	}
+	err = json.Unmarshal(bz, &proposal)
+	return proposal, err
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "os"
+
+func ReadHeader(f *os.File) ([]byte, error) {
+	buf := make([]byte, 8)
+	_, err := f.Read(buf)
+	return buf, err
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeRandImport - Detect the imports of the random number packages other than math/rand
+	SampleCodeRandImport = []CodeSample{
+		{[]string{`
+package keeper
+
+import "golang.org/x/exp/rand"
+
+func Shuffle(validators []string, height uint64) {
+	r := rand.New(rand.NewSource(height))
+	r.Shuffle(len(validators), func(i, j int) {
+		validators[i], validators[j] = validators[j], validators[i]
+	})
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package simulation
+
+import "golang.org/x/exp/rand"
+
+func RandomValidators(r *rand.Rand, validators []string) []string {
+	r.Shuffle(len(validators), func(i, j int) {
+		validators[i], validators[j] = validators[j], validators[i]
+	})
+	return validators
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "golang.org/x/exp/rand"
+
+func Shuffle(validators []string, height uint64) {
+	r := rand.New(rand.NewSource(height))
+	r.Shuffle(len(validators), func(i, j int) {
+		validators[i], validators[j] = validators[j], validators[i]
+	})
+}
+`}, 0, gosec.Config{"G732": map[string]interface{}{"disabled": []interface{}{"golang.org/x/exp/rand"}}}},
+	}
+
+	// SampleCodeChannelRange - Detect the channel ranges appending to the results or updating the state
+	SampleCodeChannelRange = []CodeSample{
+		{[]string{`
+package keeper
+
+type Result struct {
+	Validator string
+	Power     int64
+}
+
+type Keeper struct {
+	total int64
+}
+
+func Collect(results <-chan Result) []Result {
+	var collected []Result
+	for res := range results {
+		collected = append(collected, res)
+	}
+	return collected
+}
+
+func (k *Keeper) Accumulate(powers chan int64) {
+	for power := range powers {
+		k.total += power
+	}
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"log"
+	"sort"
+)
+
+type Result struct {
+	Validator string
+	Power     int64
+}
+
+func LogResults(results <-chan Result) {
+	for res := range results {
+		log.Printf("validator %s has power %d", res.Validator, res.Power)
+	}
+}
+
+func Count(results <-chan Result) map[string]int64 {
+	powers := make(map[string]int64)
+	for res := range results {
+		powers[res.Validator] += res.Power
+	}
+	return powers
+}
+
+func Sorted(results []Result) []Result {
+	var sorted []Result
+	for _, res := range results {
+		sorted = append(sorted, res)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Validator < sorted[j].Validator })
+	return sorted
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeParamMutation - Detect the keeper methods writing into the maps and slices of the caller
+	SampleCodeParamMutation = []CodeSample{
+		{[]string{`
+package keeper
+
+type Keeper struct {
+	powers map[string]int64
+}
+
+func (k Keeper) AddPowers(powers map[string]int64) {
+	for addr, power := range k.powers {
+		powers[addr] += power
+	}
+}
+
+func (k *Keeper) AppendValidators(validators []string) []string {
+	for addr := range k.powers {
+		validators = append(validators, addr)
+	}
+	return validators
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+type Keeper struct {
+	powers map[string]int64
+}
+
+func (k Keeper) TotalPower(powers map[string]int64) int64 {
+	var total int64
+	for _, power := range powers {
+		total += power
+	}
+	return total
+}
+
+func (k Keeper) Powers(validators []string) map[string]int64 {
+	powers := make(map[string]int64, len(validators))
+	for _, addr := range validators {
+		powers[addr] = k.powers[addr]
+	}
+	return powers
+}
+
+func (k Keeper) fill(powers map[string]int64) {
+	for addr, power := range k.powers {
+		powers[addr] = power
+	}
+}
+
+func Merge(dst, src map[string]int64) {
+	for addr, power := range src {
+		dst[addr] = power
+	}
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeInitIO - Detect the init functions doing network or disk I/O
+	SampleCodeInitIO = []CodeSample{
+		{[]string{`
+package params
+
+import (
+	"io/ioutil"
+	"net/http"
+)
+
+var genesis []byte
+
+func init() {
+	resp, err := http.Get("https://example.com/genesis.json")
+	if err != nil {
+		panic(err)
+	}
+	defer resp.Body.Close()
+	genesis, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package params
+
+import "os"
+
+var config []byte
+
+func init() {
+	f, err := os.Open("config.toml")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package params
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+)
+
+var (
+	DefaultPower int64
+	Endpoint     *url.URL
+	load         func() (*http.Response, error)
+)
+
+func init() {
+	DefaultPower = 10
+	Endpoint, _ = url.Parse("https://example.com")
+	load = func() (*http.Response, error) {
+		return http.Get(Endpoint.String())
+	}
+}
+
+func Load() ([]byte, error) {
+	return os.ReadFile("genesis.json")
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeLocaleStrings - Detect the case mappings of the strings stored or hashed
+	SampleCodeLocaleStrings = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"crypto/sha256"
+	"strings"
+)
+
+var DenomPrefix = []byte{0x01}
+
+type KVStore interface {
+	Set(key, value []byte)
+}
+
+func DenomKey(denom string) []byte {
+	return append(DenomPrefix, []byte(strings.Title(denom))...)
+}
+
+func SetSymbol(store KVStore, symbol string, bz []byte) {
+	key := strings.ToUpper(symbol)
+	store.Set([]byte(key), bz)
+}
+
+func SymbolHash(symbol string) [32]byte {
+	return sha256.Sum256([]byte(strings.ToLower(symbol)))
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Logger interface {
+	Info(msg string, keyvals ...interface{})
+}
+
+type Keeper struct {
+	logger Logger
+}
+
+func (k Keeper) RegisterDenom(denom string) error {
+	k.logger.Info("registering denom", "denom", strings.Title(denom))
+	name := strings.ToUpper(denom)
+	if len(denom) > 64 {
+		return fmt.Errorf("denom %s is too long", name)
+	}
+	fmt.Println(name)
+	return nil
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func main() {
+	key := strings.ToUpper("denom")
+	fmt.Println([]byte(key))
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeSecretCompare - Detect the comparisons of secrets which are not constant time
+	SampleCodeSecretCompare = []CodeSample{
+		{[]string{`
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+type PrivKey [32]byte
+
+func VerifyMAC(secret, msg, expected []byte) bool {
+	h := hmac.New(sha256.New, secret)
+	h.Write(msg)
+	mac := h.Sum(nil)
+	return bytes.Equal(mac, expected)
+}
+
+func CheckPassword(password, input string) bool {
+	return password == input
+}
+
+func SameKey(a, b PrivKey) bool {
+	return a == b
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+)
+
+func SameStoreKey(key, other []byte) bool {
+	return bytes.Equal(key, other)
+}
+
+func SameToken(token, denom string) bool {
+	return token == denom
+}
+
+func HasPassword(password string) bool {
+	return password != ""
+}
+
+func VerifyMAC(mac, expected []byte) bool {
+	return hmac.Equal(mac, expected)
+}
+
+func SameMachine(machine, other string) bool {
+	return machine == other
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeLoopDefer - Detect the releases of resources deferred inside loops
+	SampleCodeLoopDefer = []CodeSample{
+		{[]string{`
+package snapshot
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+func ReadChunks(paths []string) ([][]byte, error) {
+	var chunks [][]byte
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		chunk, err := ioutil.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+func Count(mu *sync.Mutex, counts map[string]int, keys []string) {
+	for i := 0; i < len(keys); i++ {
+		mu.Lock()
+		defer mu.Unlock()
+		counts[keys[i]]++
+	}
+}
+
+func Poll(ctx context.Context, check func(context.Context) bool) {
+	for {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		if check(ctx) {
+			return
+		}
+	}
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+func readChunk(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(f)
+}
+
+func ReadChunks(paths []string) ([][]byte, error) {
+	var chunks [][]byte
+	for _, path := range paths {
+		chunk, err := readChunk(path)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+func Count(mu *sync.Mutex, counts map[string]int, keys []string) {
+	for _, key := range keys {
+		func() {
+			mu.Lock()
+			defer mu.Unlock()
+			counts[key]++
+		}()
+	}
+}
+
+func Log(lines []string) {
+	for _, line := range lines {
+		defer println(line)
+	}
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeFloatRounding - Detect the rounding of floats with the math package
+	SampleCodeFloatRounding = []CodeSample{
+		{[]string{`
+package keeper
+
+import "math"
+
+func Reward(stake, rate float64) int64 {
+	return int64(math.Floor(stake * rate))
+}
+
+func Remainder(amount, share float64) float64 {
+	return math.Mod(amount, share)
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "math"
+
+func Reward(stake, rate int64) int64 {
+	return stake * rate / 100
+}
+
+func Overflows(amount float64) bool {
+	return math.IsInf(amount, 0) || amount > math.MaxInt64
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package plot
+
+import "math"
+
+func Bucket(value, width float64) float64 {
+	return math.Floor(value/width) * width
+}
+`}, 0, gosec.Config{"G739": map[string]interface{}{"packages": []interface{}{"command-line-arguments"}}}}, {[]string{`
+package cli
+
+import (
+	"fmt"
+	"math"
+)
+
+func FormatPercent(ratio float64) string {
+	return fmt.Sprintf("%.0f%%", math.Round(ratio*100))
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeSignConversion - Detect the conversions between signed and unsigned integers
+	SampleCodeSignConversion = []CodeSample{
+		{[]string{`
+package keeper
+
+func Burn(supply uint64, amount int64) uint64 {
+	return supply - uint64(amount)
+}
+
+func Balance(total uint64) int64 {
+	return int64(total)
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"errors"
+	"math"
+)
+
+func Burn(supply uint64, amount int64) (uint64, error) {
+	if amount < 0 {
+		return 0, errors.New("negative amount")
+	}
+	return supply - uint64(amount), nil
+}
+
+func Balance(total uint64) int64 {
+	if total > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(total)
+}
+
+func Fee() uint64 {
+	return uint64(100)
+}
+
+func Size(n int32) int64 {
+	return int64(n)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeBlockForever - Detect the blocking forever outside of the main packages
+	SampleCodeBlockForever = []CodeSample{
+		{[]string{`
+package keeper
+
+type Keeper struct {
+	ready chan struct{}
+}
+
+func (k Keeper) WaitForUpgrade(upgraded bool) {
+	if !upgraded {
+		select {}
+	}
+}
+
+func (k Keeper) Halt() {
+	<-make(chan struct{})
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "context"
+
+type Keeper struct {
+	ready chan struct{}
+}
+
+func (k Keeper) WaitReady(ctx context.Context) error {
+	select {
+	case <-k.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package main
+
+import "net/http"
+
+func main() {
+	go http.ListenAndServe("localhost:26660", nil)
+	select {}
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeBlankInit - Detect the package level calls assigned to the blank identifier
+	SampleCodeBlankInit = []CodeSample{
+		{[]string{`
+package keeper
+
+var handlers = map[string]func() error{}
+
+func register() bool {
+	handlers["send"] = func() error { return nil }
+	return true
+}
+
+var _ = register()
+
+var (
+	_, _ = load()
+)
+
+func load() (int, error) {
+	return len(handlers), nil
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+var handlers = map[string]func() error{}
+
+func register(name string) bool {
+	handlers[name] = func() error { return nil }
+	return true
+}
+
+var (
+	_ = register("send")
+	_ = register("burn")
+)
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+var handlers = map[string]func() error{}
+
+func register(name string) bool {
+	handlers[name] = func() error { return nil }
+	return true
+}
+
+var (
+	// #nosec G742 -- registered before the keepers are built
+	_ = register("send")
+	_ = register("burn")
+)
+`}, 1, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "unsafe"
+
+type Hooks interface {
+	AfterSend()
+}
+
+type Keeper struct{}
+
+func (*Keeper) AfterSend() {}
+
+var _ Hooks = (*Keeper)(nil)
+
+var _ = Hooks((*Keeper)(nil))
+
+var _ = unsafe.Sizeof(Keeper{})
+
+var _ = func() error { return nil }
+
+func Send() {
+	var _ = load()
+}
+
+func load() error {
+	return nil
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeReturnedField - Detect the exported methods returning internal slices
+	SampleCodeReturnedField = []CodeSample{
+		{[]string{`
+package keeper
+
+type cache struct {
+	denoms []string
+}
+
+type Keeper struct {
+	params []string
+	cache  *cache
+}
+
+func (k Keeper) Params() []string {
+	return k.params
+}
+
+func (k *Keeper) Denoms() []string {
+	denoms := k.cache.denoms
+	return denoms
+}
+
+func (k Keeper) FirstParams(n int) []string {
+	return k.params[:n]
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package keeper
+
+type Keeper struct {
+	params []string
+	Hooks  []string
+	count  int
+}
+
+func (k Keeper) Params() []string {
+	params := make([]string, len(k.params))
+	copy(params, k.params)
+	return params
+}
+
+func (k Keeper) Denoms() []string {
+	return append([]string(nil), k.params...)
+}
+
+func (k Keeper) Copied() []string {
+	params := k.params
+	params = append([]string(nil), params...)
+	return params
+}
+
+func (k Keeper) AllHooks() []string {
+	return k.Hooks
+}
+
+func (k Keeper) loadParams() []string {
+	return k.params
+}
+
+func (k Keeper) Count() int {
+	return k.count
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeRuntimeCall - Detect the calls tuning the runtime in state code
+	SampleCodeRuntimeCall = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+type Keeper struct{}
+
+func (k Keeper) EndBlocker(txs [][]byte) {
+	for _, tx := range txs {
+		k.deliver(tx)
+		runtime.Gosched()
+	}
+	if runtime.NumGoroutine() > 1000 {
+		debug.FreeOSMemory()
+	}
+}
+
+func (k Keeper) deliver(tx []byte) {}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "runtime"
+
+func Caller() string {
+	_, file, _, _ := runtime.Caller(1)
+	return file
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package main
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+func main() {
+	runtime.GOMAXPROCS(4)
+	debug.SetGCPercent(50)
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "runtime"
+
+func Busy() bool {
+	return runtime.NumGoroutine() > 1000
+}
+`}, 0, gosec.Config{"G744": map[string]interface{}{"disabled": []interface{}{"runtime.NumGoroutine"}}}},
+	}
+
+	// SampleCodeRecursion - Detect the recursive handlers without a depth bound
+	SampleCodeRecursion = []CodeSample{
+		{[]string{`
+package keeper
+
+type Msg struct {
+	Inner []*Msg
+	Value int64
+}
+
+type Keeper struct{}
+
+func (k Keeper) Total(msg *Msg) int64 {
+	total := msg.Value
+	for _, inner := range msg.Inner {
+		total += k.Total(inner)
+	}
+	return total
+}
+
+func count(msg *Msg) int {
+	n := 1
+	for _, inner := range msg.Inner {
+		n += count(inner)
+	}
+	return n
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "errors"
+
+type Msg struct {
+	Inner []*Msg
+	Value int64
+}
+
+type Keeper struct{}
+
+func (k Keeper) Total(msg *Msg, depth int) (int64, error) {
+	if depth > 10 {
+		return 0, errors.New("messages nested too deep")
+	}
+	total := msg.Value
+	for _, inner := range msg.Inner {
+		sub, err := k.Total(inner, depth+1)
+		if err != nil {
+			return 0, err
+		}
+		total += sub
+	}
+	return total, nil
+}
+
+func (k Keeper) Sum(msg *Msg) int64 {
+	total, _ := k.Total(msg, 0)
+	return total
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package types
+
+type Msg struct {
+	Inner []*Msg
+}
+
+func count(msg *Msg) int {
+	n := 1
+	for _, inner := range msg.Inner {
+		n += count(inner)
+	}
+	return n
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeSharedState - Detect the shared state accessed concurrently without a lock
+	SampleCodeSharedState = []CodeSample{
+		{[]string{`
+package server
+
+import "net/http"
+
+var peers = map[string]int{}
+
+var requests int
+
+func Track(addr string) {
+	go func() {
+		peers[addr]++
+	}()
+}
+
+func Status(w http.ResponseWriter, r *http.Request) {
+	requests++
+	w.WriteHeader(http.StatusOK)
+}
+
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("/peers", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := peers[r.URL.Query().Get("addr")]; ok {
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package server
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	mu       sync.Mutex
+	peers    = map[string]int{}
+	requests int64
+	logger   *log.Logger
+)
+
+const maxPeers = 10
+
+func Track(addr string) {
+	go func() {
+		mu.Lock()
+		defer mu.Unlock()
+		peers[addr]++
+	}()
+}
+
+func Status(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt64(&requests, 1)
+	logger.Println("status", maxPeers)
+	w.WriteHeader(http.StatusOK)
+}
+
+func Count() int {
+	return len(peers)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeBigIntDivision - Detect the divisions of big.Int discarding their remainder
+	SampleCodeBigIntDivision = []CodeSample{
+		{[]string{`
+package keeper
+
+import "math/big"
+
+func Share(rewards *big.Int, delegators int64) *big.Int {
+	return new(big.Int).Quo(rewards, big.NewInt(delegators))
+}
+
+func Half(amount *big.Int) *big.Int {
+	half := new(big.Int)
+	half.Div(amount, big.NewInt(2))
+	return half
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "math/big"
+
+func Share(rewards *big.Int, delegators int64) (*big.Int, *big.Int) {
+	share, remainder := new(big.Int), new(big.Int)
+	share.DivMod(rewards, big.NewInt(delegators), remainder)
+	return share, remainder
+}
+
+func Split(rewards *big.Int, delegators int64) (*big.Int, *big.Int) {
+	n := big.NewInt(delegators)
+	share := new(big.Int).Quo(rewards, n)
+	remainder := new(big.Int).Rem(rewards, n)
+	return share, remainder
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeHardcodedMnemonic - Detect the hardcoded mnemonics and private keys
+	SampleCodeHardcodedMnemonic = []CodeSample{
+		{[]string{`
+package faucet
+
+const faucetMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+
+func Keys() []string {
+	return []string{
+		faucetMnemonic,
+		"cosmosvalconspriv1qv3jqwmzdq6kxetrvdhzqaz8yp5k2ct9wp6xzmrwdsertl",
+	}
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package faucet
+
+const usage = "The faucet sends the tokens to the address given, see the README for the rate limits."
+
+func Describe() string {
+	return "send the tokens of the faucet to the address given by the user of the command line"
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package faucet
+
+const faucetMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+const seed = "SEED-0123456789"
+`}, 1, gosec.Config{"G748": map[string]interface{}{"patterns": []interface{}{"^SEED-[0-9]+$"}}}},
+	}
+
+	// SampleCodeDuplicateMapKeys - Detect the duplicate keys in map literals
+	SampleCodeDuplicateMapKeys = []CodeSample{
+		{[]string{`
+package types
+
+type Coin struct {
+	Denom  string
+	Amount int64
+}
+
+const scale = 2
+
+var Weights = map[[2]int]string{
+	{1, 2}:         "low",
+	{1, 1 + 1}:     "medium",
+	{scale, scale}: "high",
+}
+
+var Fees = map[Coin]int{
+	{Denom: "stake"}:            1,
+	{Denom: "sta" + "ke", Amount: 0}: 2,
+}
+
+var Labels = map[interface{}]string{
+	[2]int{scale * 2, 0}: "four",
+	[2]int{4, 0}:         "also four",
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package types
+
+type Coin struct {
+	Denom  string
+	Amount int64
+}
+
+var Weights = map[[2]int]string{
+	{1, 2}: "low",
+	{2, 1}: "medium",
+	{2, 2}: "high",
+}
+
+var Fees = map[Coin]int{
+	{Denom: "stake"}:            1,
+	{Denom: "stake", Amount: 1}: 2,
+}
+
+var Labels = map[interface{}]string{
+	[2]int{4, 0}:   "four",
+	[2]int64{4, 0}: "another type",
+}
+
+func Keys(a, b int) map[[2]int]bool {
+	return map[[2]int]bool{{a, 1}: true, {b, 1}: true}
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeShadowedErr - Detect the shadowing of err dropping an outer error
+	SampleCodeShadowedErr = []CodeSample{
+		{[]string{`
+package keeper
+
+import "strconv"
+
+func Parse(amount, fee string) (int64, error) {
+	total, err := strconv.ParseInt(amount, 10, 64)
+	if fee != "" {
+		n, err := strconv.ParseInt(fee, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func Sum(values []string) (int64, error) {
+	var sum int64
+	var err error
+	for _, value := range values {
+		sum, err = strconv.ParseInt(value, 10, 64)
+		func() {
+			if _, err := strconv.Atoi(value); err != nil {
+				sum = 0
+			}
+		}()
+	}
+	return sum, nil
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "strconv"
+
+func Parse(amount, fee string) (int64, error) {
+	total, err := strconv.ParseInt(amount, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if fee != "" {
+		n, err := strconv.ParseInt(fee, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func Total(amount, fee string) (int64, error) {
+	var err error
+	if fee != "" {
+		n, err := strconv.ParseInt(fee, 10, 64)
+		if err != nil {
+			return n, err
+		}
+	}
+	total, err := strconv.ParseInt(amount, 10, 64)
+	return total, err
+}
+
+func Wrap(amount string) error {
+	_, err := strconv.ParseInt(amount, 10, 64)
+	if amount != "" {
+		_, err := strconv.Atoi(amount)
+		return err
+	}
+	return err
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeContextValueKeys - Detect the context values with keys of a built-in or non-comparable type
+	SampleCodeContextValueKeys = []CodeSample{
+		{[]string{`
+package keeper
+
+import "context"
+
+const heightKey = "height"
+
+func WithHeight(ctx context.Context, height int64) context.Context {
+	ctx = context.WithValue(ctx, "chain-id", "cosmoshub-4")
+	ctx = context.WithValue(ctx, heightKey, height)
+	return context.WithValue(ctx, 42, true)
+}
+
+func WithPath(ctx context.Context, path []string) context.Context {
+	return context.WithValue(ctx, path, len(path))
+}
+`}, 4, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "context"
+
+type contextKey struct{}
+
+type keyName string
+
+const heightKey keyName = "height"
+
+func WithHeight(ctx context.Context, height int64) context.Context {
+	ctx = context.WithValue(ctx, contextKey{}, "cosmoshub-4")
+	return context.WithValue(ctx, heightKey, height)
+}
+
+func WithKey(ctx context.Context, key interface{}, value string) context.Context {
+	return context.WithValue(ctx, key, value)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeGoroutineLeak - Detect the goroutines of the servers blocking without a case on ctx.Done()
+	SampleCodeGoroutineLeak = []CodeSample{
+		{[]string{`
+package server
+
+import "context"
+
+type Server struct {
+	jobs    chan string
+	results chan string
+}
+
+func (s *Server) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case job := <-s.jobs:
+				s.results <- job
+			}
+		}
+	}()
+	go func() {
+		job := <-s.jobs
+		s.results <- job
+	}()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case job := <-s.jobs:
+				s.results <- <-s.jobs + job
+			}
+		}
+	}()
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package server
+
+import (
+	"context"
+	"time"
+)
+
+type Server struct {
+	jobs    chan string
+	results chan string
+}
+
+func (s *Server) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case job := <-s.jobs:
+				s.results <- job
+			}
+		}
+	}()
+	go func() {
+		select {
+		case job := <-s.jobs:
+			s.results <- job
+		case <-time.After(time.Second):
+		}
+	}()
+	go func() {
+		select {
+		case job := <-s.jobs:
+			s.results <- job
+		default:
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		close(s.results)
+	}()
+	go func() {
+		for job := range s.jobs {
+			s.results <- job
+		}
+	}()
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package keeper
+
+type Keeper struct {
+	jobs    chan string
+	results chan string
+}
+
+func (k Keeper) Start() {
+	go func() {
+		job := <-k.jobs
+		k.results <- job
+	}()
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeDefaultHTTPClient - Detect the uses of the default HTTP client
+	SampleCodeDefaultHTTPClient = []CodeSample{
+		{[]string{`
+package keeper
+
+import "net/http"
+
+type Keeper struct {
+	oracle string
+}
+
+func (k Keeper) Price() (int, error) {
+	resp, err := http.Get(k.oracle)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func (k Keeper) Ping() error {
+	req, err := http.NewRequest(http.MethodHead, k.oracle, nil)
+	if err != nil {
+		return err
+	}
+	_, err = http.DefaultClient.Do(req)
+	return err
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package relayer
+
+import (
+	"net/http"
+	"strings"
+)
+
+func Submit(url, tx string) error {
+	_, err := http.Post(url, "application/json", strings.NewReader(tx))
+	return err
+}
+`}, 1, gosec.NewConfig()}, {[]string{`
+package cli
+
+import "net/http"
+
+func Status(node string) (int, error) {
+	resp, err := http.Get(node + "/status")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package relayer
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+func Submit(url, tx string) error {
+	_, err := client.Post(url, "application/json", strings.NewReader(tx))
+	return err
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package relayer
+
+import (
+	"net/http"
+	"strings"
+)
+
+func Submit(url, tx string) error {
+	_, err := http.Post(url, "application/json", strings.NewReader(tx))
+	return err
+}
+`}, 0, gosec.Config{"G753": map[string]interface{}{"packages": []interface{}{"command-line-arguments"}}}},
+	}
+
+	// SampleCodeErrorsAsTarget - Detect the calls of errors.As with a target which is not a pointer to an error
+	SampleCodeErrorsAsTarget = []CodeSample{
+		{[]string{`
+package keeper
+
+import "errors"
+
+type NotFoundError struct {
+	Key string
+}
+
+func (e *NotFoundError) Error() string {
+	return "not found: " + e.Key
+}
+
+func IsNotFound(err error) bool {
+	var target NotFoundError
+	return errors.As(err, target)
+}
+
+func IsNotFoundValue(err error) bool {
+	var target NotFoundError
+	return errors.As(err, &target)
+}
+
+func HasTarget(err error) bool {
+	return errors.As(err, nil)
+}
+`}, 3, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import (
+	"errors"
+	"os"
+)
+
+type NotFoundError struct {
+	Key string
+}
+
+func (e *NotFoundError) Error() string {
+	return "not found: " + e.Key
+}
+
+type timeout interface {
+	Timeout() bool
+}
+
+func IsNotFound(err error) bool {
+	var target *NotFoundError
+	return errors.As(err, &target)
+}
+
+func IsPathError(err error) bool {
+	var target *os.PathError
+	return errors.As(err, &target)
+}
+
+func IsTimeout(err error) bool {
+	var target timeout
+	return errors.As(err, &target)
+}
+
+func Is(err error, target interface{}) bool {
+	return errors.As(err, target)
+}
+`}, 0, gosec.NewConfig()},
+	}
+
+	// SampleCodeTimers - Detect the timers and the tickers in deterministic packages
+	SampleCodeTimers = []CodeSample{
+		{[]string{`
+package keeper
+
+import (
+	"context"
+	"time"
+)
+
+type Keeper struct {
+	results chan int
+}
+
+func (k Keeper) Settle(ctx context.Context) int {
+	select {
+	case res := <-k.results:
+		return res
+	case <-time.After(time.Second):
+		return 0
+	}
+}
+
+func (k Keeper) Poll() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		k.results <- 1
+	}
+}
+`}, 2, gosec.NewConfig()}, {[]string{`
+package keeper
+
+import "time"
+
+type Keeper struct {
+	timeout time.Duration
+}
+
+func (k Keeper) Expired(blockTime, start time.Time) bool {
+	return blockTime.Sub(start) > k.timeout
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package server
+
+import (
+	"context"
+	"time"
+)
+
+func Serve(ctx context.Context, work func()) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+			work()
+		}
+	}
+}
+`}, 0, gosec.NewConfig()}, {[]string{`
+package relayer
+
+import (
+	"context"
+	"time"
+)
+
+func Relay(ctx context.Context, work func()) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+			work()
+		}
+	}
+}
+`}, 0, gosec.Config{"G755": map[string]interface{}{"packages": []interface{}{"command-line-arguments"}}}},
+	}
 )